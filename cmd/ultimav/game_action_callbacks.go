@@ -55,9 +55,8 @@ func (g *GameActionCallbacks) JoinParty() error {
 }
 
 func (g *GameActionCallbacks) CallGuards() error {
-	// TODO: Implement guard calling logic
-	g.ShowOutput("[GUARDS CALLED - Not yet implemented]\n")
 	log.Printf("CallGuards: NPC at %+v called guards", g.npcRef.Position)
+	g.gameScene.gameState.RaiseGuardAlarm("Conversation callback")
 	return nil
 }
 
@@ -74,9 +73,8 @@ func (g *GameActionCallbacks) DecreaseKarma() error {
 }
 
 func (g *GameActionCallbacks) GoToJail() error {
-	// TODO: Implement jail logic
-	g.ShowOutput("[SENT TO JAIL - Not yet implemented]\n")
 	log.Printf("GoToJail: Avatar sent to jail by NPC at %+v", g.npcRef.Position)
+	g.gameScene.gameState.SendPartyToJail()
 	return nil
 }
 
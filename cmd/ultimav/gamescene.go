@@ -61,6 +61,9 @@ type GameScene struct {
 	ultimaFont       *text.UltimaFont
 	mapImage         *ebiten.Image
 	unscaledMapImage *ebiten.Image
+	// dungeonViewImage is the first-person view, redrawn only when dungeonViewKey changes
+	dungeonViewImage *ebiten.Image
+	dungeonViewKey   dungeonViewKey
 	// rightSideImage      *ebiten.Image
 	// debugWindowImage    *ebiten.Image
 	// debugWindowSizeRect *image.Rectangle
@@ -68,6 +71,10 @@ type GameScene struct {
 	characterSummary *mainscreen.CharacterSummary
 	provisionSummary *mainscreen.ProvisionSummary
 
+	moonAndSunSprites   *sprites.MoonAndSunSprites
+	sunAndMoonIndicator *mainscreen.SunAndMoonIndicator
+	windIndicator       *mainscreen.WindIndicator
+
 	dialogStack widgets.DialogStack
 
 	debugConsole *DebugConsole
@@ -158,6 +165,7 @@ func NewGameScene(gameConfig *config.UltimaVConfiguration) *GameScene {
 	talkCallbacks := game_state.NewTalkCallbacks(
 		gameScene.CreateTalkDialog,
 		gameScene.PushDialog,
+		gameScene.DoShopMenu,
 	)
 
 	systemCallbacks, err := game_state.NewSystemCallbacks(messageCallbacks, visualCallbacks, audioCallbacks, screenCallbacks, flowCallbacks, talkCallbacks)
@@ -206,6 +214,12 @@ func (g *GameScene) initializeResizeableVisualElements() {
 
 	g.characterSummary = mainscreen.NewCharacterSummary(g.spriteSheet)
 	g.provisionSummary = mainscreen.NewProvisionSummary(g.spriteSheet)
+	g.windIndicator = mainscreen.NewWindIndicator()
+
+	if g.moonAndSunSprites == nil {
+		g.moonAndSunSprites = sprites.NewMoonAndSunSprites()
+		g.sunAndMoonIndicator = mainscreen.NewSunAndMoonIndicator(g.moonAndSunSprites)
+	}
 }
 
 func (g *GameScene) appendToCurrentRowStr(str string) {
@@ -8,6 +8,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 
 	"github.com/bradhannah/Ultima5ReduxGo/internal/config"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
 	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites"
 )
 
@@ -32,20 +33,25 @@ func (g *GameScene) Draw(screen *ebiten.Image) {
 	}
 
 	g.mapImage.Fill(image.Black)
-	g.refreshAllMapLayerTiles()
-
-	g.drawMap(g.mapImage)
+	if g.gameState.MapState.PlayerLocation.Location.GetMapType() == references.DungeonMapType {
+		g.drawDungeonView(g.mapImage)
+	} else {
+		g.refreshAllMapLayerTiles()
+		g.drawMap(g.mapImage)
+	}
 
 	op := sprites.GetDrawOptionsFromPercentsForWholeScreen(g.mapImage,
 		gameScreenPercents)
 
 	screen.DrawImage(g.mapImage, op)
 	g.drawBorders(screen)
+	g.windIndicator.Draw(g.gameState, screen)
 
 	g.output.DrawRightSideOutput(screen)
 
 	g.characterSummary.Draw(&g.gameState.PartyState, screen)
 	g.provisionSummary.Draw(g.gameState, screen)
+	g.sunAndMoonIndicator.Draw(&g.gameState.DateTime, screen)
 
 	// draw the dialogs - but stacked on top of each other
 	g.drawDialogs(screen)
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites"
+)
+
+// dungeonViewKey is everything the first-person view depends on - when it hasn't changed the last
+// drawing is reused
+type dungeonViewKey struct {
+	view  map_state.DungeonView
+	level references.DungeonLevel
+}
+
+// drawDungeonView draws the first-person view of the dungeon in place of the map
+func (g *GameScene) drawDungeonView(screen *ebiten.Image) {
+	if g.gameState.IsInDungeonRoom() {
+		g.drawDungeonRoom(screen)
+		return
+	}
+
+	width := sprites.TileSize * xTilesVisibleOnGameScreen
+	height := sprites.TileSize * yTilesVisibleOnGameScreen
+	dungeonView := g.gameState.GetDungeonView(width, height)
+	level := g.gameState.GetCurrentDungeonLevel()
+
+	key := dungeonViewKey{view: dungeonView, level: *level}
+	if g.dungeonViewImage == nil {
+		g.dungeonViewImage = ebiten.NewImage(width, height)
+	} else if key == g.dungeonViewKey {
+		screen.DrawImage(g.dungeonViewImage, &ebiten.DrawImageOptions{})
+		return
+	}
+
+	g.dungeonViewKey = key
+	g.dungeonViewImage.WritePixels(dungeonView.Render(level).Pix)
+	screen.DrawImage(g.dungeonViewImage, &ebiten.DrawImageOptions{})
+}
+
+// drawDungeonRoom draws the room the party is in from above, in the middle of the map
+func (g *GameScene) drawDungeonRoom(screen *ebiten.Image) {
+	xOffset := (xTilesVisibleOnGameScreen - int(references.XCombatMapTiles)) / 2
+	yOffset := (yTilesVisibleOnGameScreen - int(references.YCombatMapTiles)) / 2
+
+	for y := references.Coordinate(0); y < references.YCombatMapTiles; y++ {
+		for x := references.Coordinate(0); x < references.XCombatMapTiles; x++ {
+			do := &ebiten.DrawImageOptions{}
+			do.GeoM.Translate(float64((int(x)+xOffset)*sprites.TileSize), float64((int(y)+yOffset)*sprites.TileSize))
+			tileIndex := g.gameState.GetDungeonRoomTile(references.Position{X: x, Y: y})
+			screen.DrawImage(g.spriteSheet.GetSprite(tileIndex), do)
+		}
+	}
+}
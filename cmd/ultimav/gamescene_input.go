@@ -25,10 +25,13 @@ var boundKeysGame = []ebiten.Key{
 	ebiten.KeyJ,
 	ebiten.KeyK,
 	ebiten.KeyP,
+	ebiten.KeyR,
 	ebiten.KeyG,
 	ebiten.KeyL,
 	ebiten.KeyI,
 	ebiten.KeyT,
+	ebiten.KeyU,
+	ebiten.KeyZ,
 	ebiten.KeySlash,
 	ebiten.KeyBackquote,
 	ebiten.KeyEscape,
@@ -61,6 +64,7 @@ func (g *GameScene) Update(_ *Game) error {
 		case references.CombatMapType:
 			g.combatMapHandleSecondaryInput()
 		case references.DungeonMapType:
+			g.dungeonMapHandleSecondaryInput()
 		}
 		return nil
 	}
@@ -85,6 +89,7 @@ func (g *GameScene) Update(_ *Game) error {
 	case references.CombatMapType:
 		g.combatMapInputHandler(*boundKey)
 	case references.DungeonMapType:
+		g.dungeonMapInputHandler(*boundKey)
 	}
 
 	return nil
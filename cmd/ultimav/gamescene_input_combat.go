@@ -76,8 +76,9 @@ func (g *GameScene) combatMapInputHandler(key ebiten.Key) {
 		g.keyboard.SetAllowKeyPressImmediately()
 	case ebiten.KeyU:
 		g.addRowStr("Use-")
-		g.secondaryKeyState = UseDirectionInput
-		g.keyboard.SetAllowKeyPressImmediately()
+		// the turn is finished by the use menu once an item is chosen
+		g.DoUseMenu()
+		return
 	case ebiten.KeyY:
 		g.addRowStr("Yell-")
 		g.secondaryKeyState = YellDirectionInput
@@ -88,13 +89,19 @@ func (g *GameScene) combatMapInputHandler(key ebiten.Key) {
 		g.keyboard.SetAllowKeyPressImmediately()
 	case ebiten.KeyR:
 		g.addRowStr("Ready...")
-		g.gameState.ActionReadyCombatMap()
+		// the turn is finished by the ready menu once an item is chosen
+		g.DoReadyMenu(g.gameState.ActionReadyCombatMap)
+		return
 	case ebiten.KeyV:
 		g.addRowStr("View...")
-		g.gameState.ActionViewCombatMap()
+		if g.gameState.ActionViewCombatMap() {
+			g.DoGemView()
+		}
 	case ebiten.KeyZ:
 		g.addRowStr("Ztats...")
-		g.gameState.ActionZtatsCombatMap()
+		// ztats is informational only and never finishes the turn
+		g.DoZtats(g.gameState.ActionZtatsCombatMap)
+		return
 	case ebiten.KeyM:
 		g.addRowStr("Mix...")
 		g.gameState.ActionMixCombatMap()
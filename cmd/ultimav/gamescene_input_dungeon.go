@@ -9,7 +9,7 @@ import (
 func (g *GameScene) dungeonMapInputHandler(key ebiten.Key) {
 	if ebiten.IsKeyPressed(ebiten.KeyControl) {
 		if ebiten.IsKeyPressed(ebiten.KeyX) {
-			// TODO: Implement dungeon quick exit when dungeon system is available
+			g.gameState.DebugQuickExitDungeon()
 			return
 		}
 	}
@@ -26,13 +26,13 @@ func (g *GameScene) dungeonMapInputHandler(key ebiten.Key) {
 		g.toggleDebug()
 		return
 	case ebiten.KeyUp:
-		g.handleMovement(references.Up.GetDirectionCompassName(), ebiten.KeyUp)
+		g.gameState.ActionMoveDungeonMap(references.Up)
 	case ebiten.KeyDown:
-		g.handleMovement(references.Down.GetDirectionCompassName(), ebiten.KeyDown)
+		g.gameState.ActionMoveDungeonMap(references.Down)
 	case ebiten.KeyLeft:
-		g.handleMovement(references.Left.GetDirectionCompassName(), ebiten.KeyLeft)
+		g.gameState.ActionMoveDungeonMap(references.Left)
 	case ebiten.KeyRight:
-		g.handleMovement(references.Right.GetDirectionCompassName(), ebiten.KeyRight)
+		g.gameState.ActionMoveDungeonMap(references.Right)
 	case ebiten.KeyL:
 		g.addRowStr("Look-")
 		g.secondaryKeyState = LookDirectionInput
@@ -74,8 +74,9 @@ func (g *GameScene) dungeonMapInputHandler(key ebiten.Key) {
 		g.keyboard.SetAllowKeyPressImmediately()
 	case ebiten.KeyU:
 		g.addRowStr("Use-")
-		g.secondaryKeyState = UseDirectionInput
-		g.keyboard.SetAllowKeyPressImmediately()
+		// the turn is finished by the use menu once an item is chosen
+		g.DoUseMenu()
+		return
 	case ebiten.KeyY:
 		g.addRowStr("Yell-")
 		g.secondaryKeyState = YellDirectionInput
@@ -86,13 +87,19 @@ func (g *GameScene) dungeonMapInputHandler(key ebiten.Key) {
 		g.keyboard.SetAllowKeyPressImmediately()
 	case ebiten.KeyR:
 		g.addRowStr("Ready...")
-		g.gameState.ActionReadyDungeonMap()
+		// the turn is finished by the ready menu once an item is chosen
+		g.DoReadyMenu(g.gameState.ActionReadyDungeonMap)
+		return
 	case ebiten.KeyV:
 		g.addRowStr("View...")
-		g.gameState.ActionViewDungeonMap()
+		if g.gameState.ActionViewDungeonMap() {
+			g.DoGemView()
+		}
 	case ebiten.KeyZ:
 		g.addRowStr("Ztats...")
-		g.gameState.ActionZtatsDungeonMap()
+		// ztats is informational only and never finishes the turn
+		g.DoZtats(g.gameState.ActionZtatsDungeonMap)
+		return
 	case ebiten.KeyM:
 		g.addRowStr("Mix...")
 		g.gameState.ActionMixDungeonMap()
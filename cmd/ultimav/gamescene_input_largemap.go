@@ -75,25 +75,35 @@ func (g *GameScene) largeMapInputHandler(key ebiten.Key) {
 		g.keyboard.SetAllowKeyPressImmediately()
 	case ebiten.KeyU:
 		g.addRowStr("Use-")
-		g.secondaryKeyState = UseDirectionInput
-		g.keyboard.SetAllowKeyPressImmediately()
+		// the turn is finished by the use menu once an item is chosen
+		g.DoUseMenu()
+		return
 	case ebiten.KeyY:
 		g.addRowStr("Yell-")
-		g.secondaryKeyState = YellDirectionInput
-		g.keyboard.SetAllowKeyPressImmediately()
+		// aboard a frigate yelling hoists or furls the sails straight away
+		if g.gameState.ActionYellSails() {
+			break
+		}
+		g.dialogStack.DoModalInputBox("What?", g.createTextCommandYellWordOfPower(), g.keyboard)
 	case ebiten.KeyF:
 		g.addRowStr("Fire-")
 		g.secondaryKeyState = FireDirectionInput
 		g.keyboard.SetAllowKeyPressImmediately()
 	case ebiten.KeyR:
 		g.addRowStr("Ready...")
-		g.gameState.ActionReadyLargeMap()
+		// the turn is finished by the ready menu once an item is chosen
+		g.DoReadyMenu(g.gameState.ActionReadyLargeMap)
+		return
 	case ebiten.KeyV:
 		g.addRowStr("View...")
-		g.gameState.ActionViewLargeMap()
+		if g.gameState.ActionViewLargeMap() {
+			g.DoGemView()
+		}
 	case ebiten.KeyZ:
 		g.addRowStr("Ztats...")
-		g.gameState.ActionZtatsLargeMap()
+		// ztats is informational only and never finishes the turn
+		g.DoZtats(g.gameState.ActionZtatsLargeMap)
+		return
 	case ebiten.KeyM:
 		g.addRowStr("Mix...")
 		g.gameState.ActionMixLargeMap()
@@ -171,11 +181,6 @@ func (g *GameScene) largeMapHandleSecondaryInput() {
 			g.gameState.ActionUseLargeMap(getCurrentPressedArrowKeyAsDirection())
 			g.secondaryKeyState = PrimaryInput
 		}
-	case YellDirectionInput:
-		if g.isDirectionKeyValidAndOutput() {
-			g.gameState.ActionYellLargeMap(getCurrentPressedArrowKeyAsDirection())
-			g.secondaryKeyState = PrimaryInput
-		}
 	case FireDirectionInput:
 		if g.isDirectionKeyValidAndOutput() {
 			g.gameState.ActionFireLargeMap(getCurrentPressedArrowKeyAsDirection())
@@ -84,8 +84,9 @@ func (g *GameScene) smallMapInputHandler(key ebiten.Key) {
 	case ebiten.KeyU:
 		g.debugMessage = "Use"
 		g.addRowStr("Use-")
-		g.secondaryKeyState = UseDirectionInput
-		g.keyboard.SetAllowKeyPressImmediately()
+		// the turn is finished by the use menu once an item is chosen
+		g.DoUseMenu()
+		return
 	case ebiten.KeyY:
 		g.debugMessage = "Yell"
 		g.addRowStr("Yell-")
@@ -94,15 +95,21 @@ func (g *GameScene) smallMapInputHandler(key ebiten.Key) {
 	case ebiten.KeyR:
 		g.debugMessage = "Ready"
 		g.addRowStr("Ready...")
-		g.gameState.ActionReadySmallMap()
+		// the turn is finished by the ready menu once an item is chosen
+		g.DoReadyMenu(g.gameState.ActionReadySmallMap)
+		return
 	case ebiten.KeyV:
 		g.debugMessage = "View"
 		g.addRowStr("View...")
-		g.gameState.ActionViewSmallMap()
+		if g.gameState.ActionViewSmallMap() {
+			g.DoGemView()
+		}
 	case ebiten.KeyZ:
 		g.debugMessage = "Ztats"
 		g.addRowStr("Ztats...")
-		g.gameState.ActionZtatsSmallMap()
+		// ztats is informational only and never finishes the turn
+		g.DoZtats(g.gameState.ActionZtatsSmallMap)
+		return
 	case ebiten.KeyM:
 		g.debugMessage = "Mix Reagents"
 		g.addRowStr("Mix...")
@@ -170,8 +177,14 @@ func (g *GameScene) smallMapHandleSecondaryInput() {
 		}
 	case LookDirectionInput:
 		if g.isDirectionKeyValidAndOutput() {
-			g.gameState.ActionLookSmallMap(getCurrentPressedArrowKeyAsDirection())
+			direction := getCurrentPressedArrowKeyAsDirection()
+			g.gameState.ActionLookSmallMap(direction)
 			g.secondaryKeyState = PrimaryInput
+			if g.gameState.IsTelescopeInDirection(direction) {
+				g.DoSelectDirection("Look-", func(telescopeDirection references.Direction) {
+					g.dialogStack.PushModalDialog(NewTelescopeDialog(g, telescopeDirection))
+				})
+			}
 		}
 	case TalkDirectionInput:
 		if g.isDirectionKeyValidAndOutput() {
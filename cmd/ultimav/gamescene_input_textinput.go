@@ -23,7 +23,7 @@ func (g *GameScene) createTextCommandExitBuilding() *grammar.TextCommand {
 			outputStr := strings.ToLower(ib.GetText())
 
 			if outputStr == "yes" {
-				g.gameState.DebugQuickExitSmallMap()
+				g.gameState.ExitSmallMap()
 				g.dialogStack.PopModalDialog()
 			} else if outputStr == "no" {
 				g.dialogStack.PopModalDialog()
@@ -31,3 +31,21 @@ func (g *GameScene) createTextCommandExitBuilding() *grammar.TextCommand {
 			g.keyboard.SetForceWaitAnyKey(500)
 		})
 }
+
+func (g *GameScene) createTextCommandYellWordOfPower() *grammar.TextCommand {
+	return grammar.NewTextCommand(
+		[]grammar.Match{
+			grammar.MatchAnyString{
+				Description: "A Word of Power",
+			},
+		},
+		func(s string, command *grammar.TextCommand) {
+			ib := g.dialogStack.GetOrAssertTopInputBox()
+			word := ib.GetText()
+			g.dialogStack.PopModalDialog()
+
+			g.addRowStr(strings.ToUpper(word))
+			g.gameState.ActionYellWordOfPower(word)
+			g.keyboard.SetForceWaitAnyKey(500)
+		})
+}
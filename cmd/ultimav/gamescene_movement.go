@@ -87,14 +87,20 @@ func (g *GameScene) handleMovement(directionStr string, key ebiten.Key) {
 	mapType := g.gameState.MapState.PlayerLocation.Location.GetMapType()
 
 	if mapType == references.LargeMapType {
-		if g.gameState.PartyVehicle.GetVehicleDetails().VehicleType == references.FrigateVehicle && !g.gameState.PartyVehicle.GetVehicleDetails().DoesMoveResultInMovement(direction) {
-			g.gameState.PartyVehicle.GetVehicleDetails().SetPartyVehicleDirection(direction)
+		// under sail the wind moves the ship, so a direction only changes its heading
+		vehicleDetails := g.gameState.PartyVehicle.GetVehicleDetails()
+		if vehicleDetails.VehicleType == references.FrigateVehicle &&
+			(vehicleDetails.AreSailsHoisted() || !vehicleDetails.DoesMoveResultInMovement(direction)) {
+			vehicleDetails.SetPartyVehicleDirection(direction)
 			g.output.AddRowStrWithTrim(fmt.Sprintf("Head %s", direction.GetDirectionCompassName()))
 			return
 		}
 
 		newPosition = newPosition.GetWrapped(references.XLargeMapTiles, references.YLargeMapTiles)
 
+		if !g.gameState.TryToRowPartyVehicle(direction) {
+			return
+		}
 	}
 	g.gameState.PartyVehicle.GetVehicleDetails().SetPartyVehicleDirection(direction)
 
@@ -122,6 +128,8 @@ func (g *GameScene) handleMovement(directionStr string, key ebiten.Key) {
 
 		// Process environmental hazards after successful movement
 		g.gameState.ProcessEnvironmentalHazardsAfterMovement()
+		g.gameState.EnterMoongateIfPresent()
+		g.gameState.EncounterBridgeTrollsIfPresent()
 	} else {
 		g.addRowStr("Blocked!")
 	}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/ui/widgets"
+)
+
+// DoReadyMenu asks for a party member and then the arms to ready or unready.
+// readyFunc is the map specific ActionReady* function.
+func (g *GameScene) DoReadyMenu(readyFunc func(playerIndex int, item references.Equipment) bool) {
+	g.DoSelectPartyMember("Ready", func(playerIndex int) {
+		g.doReadyEquipmentMenu(playerIndex, readyFunc)
+	})
+}
+
+func (g *GameScene) doReadyEquipmentMenu(playerIndex int, readyFunc func(playerIndex int, item references.Equipment) bool) {
+	character := &g.gameState.PartyState.Characters[playerIndex]
+	inventory := &g.gameState.PartyState.Inventory
+
+	bl := widgets.NewButtonListModal(
+		fmt.Sprintf("%s ATK %d DEF %d", character.GetNameAsString(), character.GetAttackValue(), character.GetDefenceValue()),
+		func() {
+			g.dialogStack.PopModalDialog()
+			g.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+			g.addRowStr("Done")
+		},
+		g.keyboard,
+		&gameScreenPercents)
+
+	nItems := 0
+	for item := references.LeatherHelm; item <= references.Ankh; item++ {
+		if item.GetDetails().Usage == references.AmmoUsage {
+			continue
+		}
+		_, bReadied := character.GetSlotOfEquipped(item)
+		if !bReadied && !inventory.Equipment.HasSome(item) {
+			continue
+		}
+		nItems++
+
+		label := g.gameState.GameReferences.InventoryItemReferences.Equipment[item].ItemName
+		if bReadied {
+			label = "* " + label
+		} else {
+			label = fmt.Sprintf("%s (%d)", label, inventory.Equipment.Get(item))
+		}
+		bl.AddButton(label, func() {
+			g.dialogStack.PopModalDialog()
+			g.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+			readyFunc(playerIndex, item)
+			g.gameState.FinishTurn()
+		})
+	}
+
+	if nItems == 0 {
+		g.addRowStr("Thou art empty-handed!")
+		return
+	}
+
+	g.dialogStack.PushModalDialog(bl)
+}
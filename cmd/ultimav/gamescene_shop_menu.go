@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/game_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/ui/widgets"
+)
+
+// DoShopMenu asks whether the party is buying or selling once a shopkeeper has greeted them.
+// Inns, healers, horse sellers and shipwrights offer their services instead.
+func (g *GameScene) DoShopMenu(shop *game_state.Shop) {
+	switch shop.Type {
+	case references.InnShop:
+		g.doInnMenu(shop)
+		return
+	case references.HealerShop:
+		g.doHealerMenu(shop)
+		return
+	case references.HorseShop:
+		g.doHorseMenu(shop)
+		return
+	case references.ShipwrightShop:
+		g.doShipwrightMenu(shop)
+		return
+	}
+	if len(references.GetShopStock(shop.Type)) == 0 {
+		return
+	}
+
+	bl := widgets.NewButtonListModal(
+		shop.Type.String(),
+		func() { g.closeShopMenu(shop) },
+		g.keyboard,
+		&gameScreenPercents)
+
+	bl.AddButton("Buy", func() {
+		g.dialogStack.PopModalDialog()
+		g.doShopBuyMenu(shop)
+	})
+	if shop.Type == references.ArmsShop {
+		bl.AddButton("Sell", func() {
+			g.dialogStack.PopModalDialog()
+			g.doShopSellMenu(shop)
+		})
+	}
+
+	g.dialogStack.PushModalDialog(bl)
+}
+
+func (g *GameScene) doShopBuyMenu(shop *game_state.Shop) {
+	bl := widgets.NewButtonListModal(
+		fmt.Sprintf("Buy - %d gp", g.gameState.PartyState.Inventory.Gold.Get()),
+		func() { g.closeShopMenu(shop) },
+		g.keyboard,
+		&gameScreenPercents)
+
+	for _, goods := range references.GetShopStock(shop.Type) {
+		label := fmt.Sprintf("%s %dgp",
+			g.gameState.GameReferences.InventoryItemReferences.GetReferenceByItem(goods.Item).ItemName,
+			g.gameState.GetShopBuyPrice(shop, goods))
+		bl.AddButton(label, func() {
+			g.dialogStack.PopModalDialog()
+			if goods.Item.Type() != references.ItemTypeProvision {
+				g.gameState.BuyFromShop(shop, goods.Item, 1)
+				g.doShopBuyMenu(shop)
+				return
+			}
+			// the guild's provisions are bought by the handful
+			g.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+			g.addRowStr("How many?")
+			g.dialogStack.PushModalDialog(NewQuantityDialog(g, func(quantity uint16) {
+				if quantity == 0 {
+					g.addRowStr("None")
+				} else {
+					g.gameState.BuyFromShop(shop, goods.Item, quantity)
+				}
+				g.doShopBuyMenu(shop)
+			}))
+		})
+	}
+
+	g.dialogStack.PushModalDialog(bl)
+}
+
+func (g *GameScene) doShopSellMenu(shop *game_state.Shop) {
+	inventory := &g.gameState.PartyState.Inventory
+
+	bl := widgets.NewButtonListModal(
+		fmt.Sprintf("Sell - %d gp", inventory.Gold.Get()),
+		func() { g.closeShopMenu(shop) },
+		g.keyboard,
+		&gameScreenPercents)
+
+	nItems := 0
+	for _, goods := range references.GetShopStock(shop.Type) {
+		item := references.Equipment(goods.Item.ID())
+		if !inventory.Equipment.HasSome(item) {
+			continue
+		}
+		nItems++
+
+		label := fmt.Sprintf("%s (%d) %dgp",
+			g.gameState.GameReferences.InventoryItemReferences.Equipment[item].ItemName,
+			inventory.Equipment.Get(item),
+			g.gameState.GetShopSellPrice(shop, goods))
+		bl.AddButton(label, func() {
+			g.dialogStack.PopModalDialog()
+			g.gameState.SellToShop(shop, goods.Item, 1)
+			g.doShopSellMenu(shop)
+		})
+	}
+
+	if nItems == 0 {
+		// TODO: TBD placeholder message - not documented
+		g.addRowStr("Thou hast nothing I would buy!")
+		g.gameState.LeaveShop(shop)
+		return
+	}
+
+	g.dialogStack.PushModalDialog(bl)
+}
+
+// doInnMenu offers a room for the night, or leaving and collecting companions
+func (g *GameScene) doInnMenu(shop *game_state.Shop) {
+	bl := widgets.NewButtonListModal(
+		shop.Type.String(),
+		func() { g.closeShopMenu(shop) },
+		g.keyboard,
+		&gameScreenPercents)
+
+	bl.AddButton(fmt.Sprintf("Room for the night %dgp", references.GetInnRoomPrice(shop.Location)), func() {
+		g.dialogStack.PopModalDialog()
+		g.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+		g.gameState.RentInnRoom(shop)
+	})
+	bl.AddButton(fmt.Sprintf("Leave a companion %dgp/month", references.GetInnMonthlyPrice(shop.Location)), func() {
+		g.dialogStack.PopModalDialog()
+		g.DoSelectPartyMember("Who stays?", func(playerIndex int) {
+			g.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+			g.addRowStr("How many months?")
+			g.dialogStack.PushModalDialog(NewQuantityDialog(g, func(months uint16) {
+				if months == 0 {
+					g.addRowStr("None")
+					return
+				}
+				g.gameState.LeaveCompanionAtInn(shop, playerIndex, int(min(months, game_state.MaxMonthsAtInn)))
+			}))
+		})
+	})
+	for _, playerIndex := range g.gameState.GetCompanionsAtInn(shop.Location) {
+		label := fmt.Sprintf("Collect %s", g.gameState.PartyState.Characters[playerIndex].GetNameAsString())
+		bl.AddButton(label, func() {
+			g.dialogStack.PopModalDialog()
+			g.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+			g.gameState.CollectCompanionFromInn(shop, playerIndex)
+		})
+	}
+
+	g.dialogStack.PushModalDialog(bl)
+}
+
+// doHealerMenu offers the healer's services, each for a party member
+func (g *GameScene) doHealerMenu(shop *game_state.Shop) {
+	bl := widgets.NewButtonListModal(
+		shop.Type.String(),
+		func() { g.closeShopMenu(shop) },
+		g.keyboard,
+		&gameScreenPercents)
+
+	for _, service := range []references.HealerService{references.HealerCure, references.HealerHeal, references.HealerResurrect} {
+		bl.AddButton(fmt.Sprintf("%s %dgp", service, references.GetHealerPrice(shop.Location, service)), func() {
+			g.dialogStack.PopModalDialog()
+			g.DoSelectPartyMember(service.String(), func(playerIndex int) {
+				g.gameState.BuyHealerService(shop, service, playerIndex)
+			})
+		})
+	}
+	bl.AddButton("Donate blood", func() {
+		g.dialogStack.PopModalDialog()
+		g.DoSelectPartyMember("Donor", func(playerIndex int) {
+			g.gameState.DonateBlood(playerIndex)
+		})
+	})
+
+	g.dialogStack.PushModalDialog(bl)
+}
+
+// doHorseMenu offers a horse, which waits outside town
+func (g *GameScene) doHorseMenu(shop *game_state.Shop) {
+	bl := widgets.NewButtonListModal(
+		shop.Type.String(),
+		func() { g.closeShopMenu(shop) },
+		g.keyboard,
+		&gameScreenPercents)
+
+	bl.AddButton(fmt.Sprintf("Horse %dgp", references.GetHorsePrice(shop.Location)), func() {
+		g.dialogStack.PopModalDialog()
+		g.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+		g.gameState.BuyHorse(shop)
+	})
+
+	g.dialogStack.PushModalDialog(bl)
+}
+
+// doShipwrightMenu offers boats for the dock, and repairs to a frigate tied up there
+func (g *GameScene) doShipwrightMenu(shop *game_state.Shop) {
+	bl := widgets.NewButtonListModal(
+		shop.Type.String(),
+		func() { g.closeShopMenu(shop) },
+		g.keyboard,
+		&gameScreenPercents)
+
+	for _, vehicleType := range []references.VehicleType{references.FrigateVehicle, references.SkiffVehicle} {
+		label := "Frigate"
+		if vehicleType == references.SkiffVehicle {
+			label = "Skiff"
+		}
+		bl.AddButton(fmt.Sprintf("%s %dgp", label, references.GetShipPrice(shop.Location, vehicleType)), func() {
+			g.dialogStack.PopModalDialog()
+			g.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+			g.gameState.BuyShip(shop, vehicleType)
+		})
+	}
+	if price, ok := g.gameState.GetHullRepairPrice(shop); ok {
+		bl.AddButton(fmt.Sprintf("Repair hull %dgp", price), func() {
+			g.dialogStack.PopModalDialog()
+			g.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+			g.gameState.RepairHull(shop)
+		})
+	}
+
+	g.dialogStack.PushModalDialog(bl)
+}
+
+func (g *GameScene) closeShopMenu(shop *game_state.Shop) {
+	g.dialogStack.PopModalDialog()
+	g.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+	g.gameState.LeaveShop(shop)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 
+	"github.com/bradhannah/Ultima5ReduxGo/internal/game_state"
 	"github.com/bradhannah/Ultima5ReduxGo/internal/map_state"
 	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
 	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites"
@@ -64,21 +65,6 @@ func (g *GameScene) getCorrectAvatarOnChairTile(spriteIndex indexes.SpriteIndex,
 	return spriteIndex
 }
 
-func (g *GameScene) setDrawBridge(theMap *map_state.LayeredMap, pos *references.Position, spriteIndex indexes.SpriteIndex) bool {
-	const (
-		leftXDrawBridge   = 14
-		rightXDrawBridge  = 16
-		topYDrawBridge    = 28
-		bottomYDrawBridge = 29
-	)
-
-	if (pos.X >= leftXDrawBridge && pos.X <= rightXDrawBridge) && (pos.Y >= topYDrawBridge && pos.Y <= bottomYDrawBridge) {
-		theMap.SetTileByLayer(map_state.MapOverrideLayer, pos, g.gameState.GetDrawBridgeWaterByTime(spriteIndex))
-		return true
-	}
-	return false
-}
-
 func (g *GameScene) getCorrectAvatarEatingInChairTile(avatarChairTileIndex indexes.SpriteIndex, pos *references.Position) indexes.SpriteIndex {
 	switch avatarChairTileIndex {
 	case indexes.ChairFacingDown:
@@ -103,15 +89,21 @@ func (g *GameScene) getCorrectAvatarEatingInChairTile(avatarChairTileIndex index
 // refreshSpecialTileOverrideExceptions
 // Refreshes the special tiles that are not in the map like Portcullis, drawbridge and mirrors
 func (g *GameScene) refreshSpecialTileOverrideExceptions(pos *references.Position, layer *map_state.LayeredMap) {
+	// gates are worked out from the tile the map was drawn with, as the override may already be hiding it
+	isSmallMap := g.gameState.MapState.PlayerLocation.Location.GetMapType() == references.SmallMapType
+	if mapIndex := layer.GetTileByLayer(map_state.MapLayer, pos).Index; isSmallMap && game_state.IsTownGate(pos, mapIndex) {
+		// never swap the gate out from under the party
+		if !g.gameState.IsAvatarAtPosition(pos) {
+			layer.SetTileByLayer(map_state.MapOverrideLayer, pos, g.gameState.GetTownGateSprite(pos, mapIndex))
+		}
+		return
+	}
+
 	tile := layer.GetTileTopMapOnlyTile(pos)
 	if tile == nil {
 		return
 	}
 	switch tile.Index {
-	case indexes.Portcullis, indexes.BrickWallArchway:
-		layer.SetTileByLayer(map_state.MapOverrideLayer, pos, g.gameState.GetArchwayPortcullisSpriteByTime())
-	case indexes.WoodenPlankVert1Floor, indexes.WoodenPlankVert2Floor:
-		g.setDrawBridge(layer, pos, tile.Index)
 	case indexes.Mirror, indexes.MirrorAvatar:
 		if g.gameState.IsAvatarAtPosition(pos.GetPositionDown()) {
 			layer.SetTileByLayer(map_state.MapOverrideLayer, pos, indexes.MirrorAvatar)
@@ -206,6 +198,10 @@ func (g *GameScene) refreshStaticMapTiles(pos *references.Position, mapLayer *ma
 		spriteIndex = tile.Index
 	}
 
+	if g.gameState.IsMoongateAt(pos) {
+		spriteIndex = indexes.Moongate
+	}
+
 	// get from the reference
 	spriteIndex = g.getCalculatedTileIndex(spriteIndex, pos)
 
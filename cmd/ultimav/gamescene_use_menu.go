@@ -0,0 +1,194 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/game_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/ui/widgets"
+)
+
+const useMenuForceWaitTimeMs = 250
+
+// DoUseMenu opens the inventory sub-menu for the Use command.
+// The turn is only finished once an item has actually been used. Fixtures such as fountains, wells and
+// levers are used in a direction instead, which the menu offers last - or straight away if there's
+// nothing in the inventory to use.
+func (g *GameScene) DoUseMenu() {
+	inventory := &g.gameState.PartyState.Inventory
+
+	bl := widgets.NewButtonListModal(
+		"Use",
+		func() {
+			g.dialogStack.PopModalDialog()
+			g.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+			g.addRowStr("Nothing")
+		},
+		g.keyboard,
+		&gameScreenPercents)
+
+	nItems := 0
+	for potion := references.Blue; potion <= references.White; potion++ {
+		if !inventory.Potions.HasSome(potion) {
+			continue
+		}
+		nItems++
+		itemRef := g.gameState.GameReferences.InventoryItemReferences.Potion[potion]
+		bl.AddButton(fmt.Sprintf("%s Potion (%d)", itemRef.ItemName, inventory.Potions.Get(potion)), func() {
+			g.dialogStack.PopModalDialog()
+			g.DoSelectPartyMember("Drink", func(playerIndex int) {
+				g.gameState.ActionUsePotion(potion, playerIndex)
+				g.gameState.FinishTurn()
+			})
+		})
+	}
+
+	for scroll := references.ScrollVasLor; scroll <= references.ScrollAnTym; scroll++ {
+		if !inventory.Scrolls.HasSome(scroll) || !game_state.IsScrollReadable(scroll) {
+			continue
+		}
+		nItems++
+		itemRef := g.gameState.GameReferences.InventoryItemReferences.Scroll[scroll]
+		bl.AddButton(fmt.Sprintf("%s Scroll (%d)", strings.ReplaceAll(itemRef.ItemName, "_", " "), inventory.Scrolls.Get(scroll)), func() {
+			g.dialogStack.PopModalDialog()
+			g.useScroll(scroll)
+		})
+	}
+
+	for questItem := references.Amulet; questItem <= references.Sceptre; questItem++ {
+		if !inventory.QuestItems.HasSome(questItem) {
+			continue
+		}
+		nItems++
+		itemRef := g.gameState.GameReferences.InventoryItemReferences.QuestItem[questItem]
+		bl.AddButton(itemRef.ItemName, func() {
+			g.dialogStack.PopModalDialog()
+			g.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+			g.gameState.ActionUseQuestItem(questItem)
+			g.gameState.FinishTurn()
+		})
+	}
+
+	if inventory.SpecialItems.HasSome(references.Carpet) {
+		nItems++
+		bl.AddButton(fmt.Sprintf("Magic Carpet (%d)", inventory.SpecialItems.Get(references.Carpet)), func() {
+			g.dialogStack.PopModalDialog()
+			g.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+			g.gameState.ActionUseCarpet()
+			g.gameState.FinishTurn()
+		})
+	}
+
+	if inventory.Provisions.SkullKeys.Get() > 0 {
+		nItems++
+		bl.AddButton(fmt.Sprintf("Skull Key (%d)", inventory.Provisions.SkullKeys.Get()), func() {
+			g.dialogStack.PopModalDialog()
+			g.DoSelectDirection("Direction", func(direction references.Direction) {
+				g.gameState.ActionUseSkullKey(direction)
+				g.gameState.FinishTurn()
+			})
+		})
+	}
+
+	if inventory.SpecialItems.HasSome(references.Spyglass) {
+		nItems++
+		bl.AddButton("Spyglass", func() {
+			g.dialogStack.PopModalDialog()
+			g.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+			if g.gameState.ActionUseSpyglass() {
+				g.dialogStack.PushModalDialog(NewSkyViewDialog(g))
+			}
+			g.gameState.FinishTurn()
+		})
+	}
+
+	if nItems == 0 {
+		g.useInDirection()
+		return
+	}
+
+	bl.AddButton("In a direction...", func() {
+		g.dialogStack.PopModalDialog()
+		g.useInDirection()
+	})
+
+	g.dialogStack.PushModalDialog(bl)
+}
+
+// useInDirection hands over to the map's direction prompt, which uses whatever is in that direction
+func (g *GameScene) useInDirection() {
+	g.secondaryKeyState = UseDirectionInput
+	g.keyboard.SetAllowKeyPressImmediately()
+}
+
+// useScroll collects any direction or target the scroll needs before reading it
+func (g *GameScene) useScroll(scroll references.Scroll) {
+	readScroll := func(input game_state.ScrollUseInput) {
+		if g.gameState.ActionUseScroll(scroll, input) && scroll == references.ScrollInQuasWis {
+			g.DoGemView()
+		}
+		g.gameState.FinishTurn()
+	}
+
+	switch {
+	case game_state.ScrollNeedsDirection(scroll):
+		g.DoSelectDirection("Direction", func(direction references.Direction) {
+			readScroll(game_state.ScrollUseInput{Direction: direction})
+		})
+	case game_state.ScrollNeedsPartyMember(scroll):
+		g.DoSelectPartyMember("Who", func(playerIndex int) {
+			readScroll(game_state.ScrollUseInput{PlayerIndex: playerIndex})
+		})
+	default:
+		readScroll(game_state.ScrollUseInput{})
+	}
+}
+
+// DoSelectDirection opens a menu of the four compass directions and calls onSelect with the choice
+func (g *GameScene) DoSelectDirection(title string, onSelect func(direction references.Direction)) {
+	bl := widgets.NewButtonListModal(
+		title,
+		func() {
+			g.dialogStack.PopModalDialog()
+			g.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+		},
+		g.keyboard,
+		&gameScreenPercents)
+
+	for _, direction := range []references.Direction{references.Up, references.Down, references.Left, references.Right} {
+		bl.AddButton(direction.GetDirectionCompassName(), func() {
+			g.dialogStack.PopModalDialog()
+			g.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+			onSelect(direction)
+		})
+	}
+
+	g.dialogStack.PushModalDialog(bl)
+}
+
+// DoSelectPartyMember opens a menu of the current party members and calls onSelect with the chosen index
+func (g *GameScene) DoSelectPartyMember(title string, onSelect func(playerIndex int)) {
+	bl := widgets.NewButtonListModal(
+		title,
+		func() {
+			g.dialogStack.PopModalDialog()
+			g.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+		},
+		g.keyboard,
+		&gameScreenPercents)
+
+	for i := 0; i < party_state.NPlayers; i++ {
+		if !g.gameState.PartyState.IsCharacterInParty(i) {
+			continue
+		}
+		bl.AddButton(g.gameState.PartyState.Characters[i].GetNameAsString(), func() {
+			g.dialogStack.PopModalDialog()
+			g.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+			onSelect(i)
+		})
+	}
+
+	g.dialogStack.PushModalDialog(bl)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/ui/widgets"
+)
+
+var _ widgets.Widget = &GemViewDialog{}
+
+var gemViewBoundKeys = []ebiten.Key{ebiten.KeyEnter, ebiten.KeyEscape, ebiten.KeySpace}
+
+// GemViewDialog shows the miniature map seen in a gem over the game screen until a key is pressed
+type GemViewDialog struct {
+	gameScene *GameScene
+	viewImage *ebiten.Image
+}
+
+func NewGemViewDialog(gameScene *GameScene) *GemViewDialog {
+	dialog := &GemViewDialog{gameScene: gameScene}
+	if img := gameScene.gameState.RenderGemView(); img != nil {
+		dialog.viewImage = ebiten.NewImageFromImage(img)
+	}
+	return dialog
+}
+
+func (d *GemViewDialog) Update() {
+	boundKey := d.gameScene.keyboard.GetBoundKeyPressed(&gemViewBoundKeys)
+	if boundKey == nil {
+		d.gameScene.keyboard.SetAllowKeyPressImmediately()
+		return
+	}
+	if !d.gameScene.keyboard.TryToRegisterKeyPress(*boundKey) {
+		return
+	}
+	d.gameScene.dialogStack.PopModalDialog()
+	d.gameScene.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+}
+
+// Draw keeps the map square, centred over the game screen
+func (d *GemViewDialog) Draw(screen *ebiten.Image) {
+	rect := sprites.GetRectangleFromPercents(gameScreenPercents)
+	vector.DrawFilledRect(screen, float32(rect.Min.X), float32(rect.Min.Y), float32(rect.Dx()), float32(rect.Dy()), color.Black, false)
+	if d.viewImage == nil {
+		return
+	}
+
+	scale := min(float64(rect.Dx())/float64(d.viewImage.Bounds().Dx()), float64(rect.Dy())/float64(d.viewImage.Bounds().Dy()))
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(
+		float64(rect.Min.X)+(float64(rect.Dx())-float64(d.viewImage.Bounds().Dx())*scale)/2,
+		float64(rect.Min.Y)+(float64(rect.Dy())-float64(d.viewImage.Bounds().Dy())*scale)/2)
+	screen.DrawImage(d.viewImage, op)
+}
+
+// DoGemView shows the gem view of the current map
+func (g *GameScene) DoGemView() {
+	g.dialogStack.PushModalDialog(NewGemViewDialog(g))
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/text"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/ui/widgets"
+	"github.com/bradhannah/Ultima5ReduxGo/pkg/color"
+	"github.com/bradhannah/Ultima5ReduxGo/pkg/grammar"
+)
+
+var _ widgets.Widget = &QuantityDialog{}
+
+const (
+	quantityFontPoint     = 20
+	quantityMaxCharsInput = 2
+
+	quantityBorderStartPercentX = .3
+	quantityBorderEndPercentX   = .46
+	quantityBorderStartPercentY = .45
+	quantityBorderEndPercentY   = .55
+)
+
+// QuantityDialog asks how many of something the party wants. The number typed is handed to onQuantity
+// when Enter is pressed; Escape, or anything that isn't a number, is taken as none at all.
+type QuantityDialog struct {
+	border    *widgets.Border
+	TextInput *widgets.TextInput
+
+	gameScene  *GameScene
+	onQuantity func(quantity uint16)
+}
+
+func NewQuantityDialog(gameScene *GameScene, onQuantity func(quantity uint16)) *QuantityDialog {
+	dialog := &QuantityDialog{gameScene: gameScene, onQuantity: onQuantity}
+	dialog.initializeResizeableVisualElements()
+	return dialog
+}
+
+func (d *QuantityDialog) Refresh() {
+	d.initializeResizeableVisualElements()
+}
+
+func (d *QuantityDialog) initializeResizeableVisualElements() {
+	d.border = widgets.NewBorder(
+		sprites.PercentBasedPlacement{
+			StartPercentX: quantityBorderStartPercentX,
+			EndPercentX:   quantityBorderEndPercentX,
+			StartPercentY: quantityBorderStartPercentY,
+			EndPercentY:   quantityBorderEndPercentY,
+		},
+		borderWidthScaling,
+		color.Black)
+
+	if d.TextInput == nil {
+		d.TextInput = widgets.NewTextInput(
+			sprites.PercentBasedPlacement{
+				StartPercentX: quantityBorderStartPercentX + percentTextIndentFromBorder,
+				EndPercentX:   quantityBorderEndPercentX - percentTextIndentFromBorder,
+				StartPercentY: quantityBorderStartPercentY + 0.03,
+				EndPercentY:   quantityBorderEndPercentY - 0.02,
+			},
+			text.GetScaledNumberToResolution(d.gameScene.gameConfig.DisplayManager, quantityFontPoint),
+			quantityMaxCharsInput,
+			&grammar.TextCommands{},
+			widgets.TextInputCallbacks{
+				AmbiguousAutoComplete: func(string) {},
+				OnEnter:               d.onEnter,
+			},
+			d.gameScene.keyboard)
+		d.TextInput.SetInputColors(widgets.TextInputColors{
+			DefaultColor:          color.Green,
+			NoMatchesColor:        color.Green,
+			OneMatchColor:         color.Green,
+			MoreThanOneMatchColor: color.Green,
+		})
+	} else {
+		d.TextInput.SetFontPoint(text.GetScaledNumberToResolution(d.gameScene.gameConfig.DisplayManager, quantityFontPoint))
+	}
+}
+
+// onEnter closes the dialog and hands over the number typed
+func (d *QuantityDialog) onEnter() {
+	d.gameScene.dialogStack.PopModalDialog()
+	quantity, err := strconv.Atoi(strings.TrimSpace(d.TextInput.GetText()))
+	if err != nil || quantity < 0 {
+		quantity = 0
+	}
+	d.onQuantity(uint16(quantity))
+}
+
+func (d *QuantityDialog) Update() {
+	if ebiten.IsKeyPressed(ebiten.KeyEscape) {
+		if !d.gameScene.keyboard.TryToRegisterKeyPress(ebiten.KeyEscape) {
+			return
+		}
+		d.gameScene.dialogStack.PopModalDialog()
+		d.onQuantity(0)
+		return
+	}
+	d.TextInput.Update()
+}
+
+func (d *QuantityDialog) Draw(screen *ebiten.Image) {
+	d.border.DrawBackground(screen)
+	d.border.DrawBorder(screen)
+	d.TextInput.Draw(screen)
+}
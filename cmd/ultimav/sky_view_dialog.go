@@ -0,0 +1,88 @@
+package main
+
+import (
+	"image/color"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/datetime"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/ui/widgets"
+)
+
+var _ widgets.Widget = &SkyViewDialog{}
+
+const (
+	skyViewStars    = 80
+	skyViewMoonSize = 18
+)
+
+var (
+	skyViewNightColour = color.RGBA{R: 0x05, G: 0x05, B: 0x20, A: 0xff}
+	skyViewStarColour  = color.RGBA{R: 0xe0, G: 0xe0, B: 0xff, A: 0xff}
+)
+
+var skyViewBoundKeys = []ebiten.Key{ebiten.KeyEnter, ebiten.KeyEscape, ebiten.KeySpace}
+
+// SkyViewDialog is the astronomy view seen through a spyglass - the stars and the two moons,
+// Trammel and Felucca in their current phases, drawn over the game screen until a key is pressed.
+type SkyViewDialog struct {
+	gameScene *GameScene
+	skyImage  *ebiten.Image
+}
+
+func NewSkyViewDialog(gameScene *GameScene) *SkyViewDialog {
+	dialog := &SkyViewDialog{gameScene: gameScene}
+	dialog.skyImage = ebiten.NewImage(sprites.TileSize*xTilesVisibleOnGameScreen, sprites.TileSize*yTilesVisibleOnGameScreen)
+	dialog.drawSky(&gameScene.gameState.DateTime)
+	return dialog
+}
+
+// drawSky paints the night sky once - the star field stays fixed for the night while the moons
+// drift from east to west as the hours pass
+func (d *SkyViewDialog) drawSky(date *datetime.UltimaDate) {
+	d.skyImage.Fill(skyViewNightColour)
+	width, height := d.skyImage.Bounds().Dx(), d.skyImage.Bounds().Dy()
+
+	nightOfYear := int64(date.Year)*datetime.MonthsPerYear*datetime.DaysInMonth +
+		int64(date.Month)*datetime.DaysInMonth + int64(date.Day)
+	starRng := rand.New(rand.NewSource(nightOfYear))
+	for range skyViewStars {
+		vector.DrawFilledRect(d.skyImage,
+			float32(starRng.Intn(width)), float32(starRng.Intn(height)),
+			1, 1, skyViewStarColour, false)
+	}
+
+	hourFraction := (float32(date.Hour) + float32(date.Minute)/datetime.MinutesPerHour) / datetime.HoursPerDay
+	trammel, felucca := date.GetMoonPhases()
+	drawMoon := func(offset float32, y float32, moon *ebiten.Image) {
+		position := hourFraction + offset
+		position -= float32(int(position))
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(skyViewMoonSize/float64(moon.Bounds().Dx()), skyViewMoonSize/float64(moon.Bounds().Dy()))
+		op.GeoM.Translate(float64(float32(width)*(1-position))-skyViewMoonSize/2, float64(y)-skyViewMoonSize/2)
+		d.skyImage.DrawImage(moon, op)
+	}
+	drawMoon(0, float32(height)/3, d.gameScene.moonAndSunSprites.Moons[trammel])
+	drawMoon(0.5, float32(height)/2, d.gameScene.moonAndSunSprites.Moons[felucca])
+}
+
+func (d *SkyViewDialog) Update() {
+	boundKey := d.gameScene.keyboard.GetBoundKeyPressed(&skyViewBoundKeys)
+	if boundKey == nil {
+		d.gameScene.keyboard.SetAllowKeyPressImmediately()
+		return
+	}
+	if !d.gameScene.keyboard.TryToRegisterKeyPress(*boundKey) {
+		return
+	}
+	d.gameScene.dialogStack.PopModalDialog()
+	d.gameScene.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+}
+
+func (d *SkyViewDialog) Draw(screen *ebiten.Image) {
+	op := sprites.GetDrawOptionsFromPercentsForWholeScreen(d.skyImage, gameScreenPercents)
+	screen.DrawImage(d.skyImage, op)
+}
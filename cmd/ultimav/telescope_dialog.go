@@ -0,0 +1,80 @@
+package main
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/game_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/ui/widgets"
+)
+
+var _ widgets.Widget = &TelescopeDialog{}
+
+const telescopeMsPerTilePanned = 120
+
+var telescopeBoundKeys = []ebiten.Key{ebiten.KeyEnter, ebiten.KeyEscape, ebiten.KeySpace}
+
+// TelescopeDialog shows the overworld beyond the town walls, slowly panning away from the town
+// in the chosen direction - see Fixtures.md Telescope. Any key puts the telescope down.
+type TelescopeDialog struct {
+	gameScene *GameScene
+	viewImage *ebiten.Image
+
+	direction   references.Direction
+	startMs     int64
+	tilesPanned int
+}
+
+func NewTelescopeDialog(gameScene *GameScene, direction references.Direction) *TelescopeDialog {
+	return &TelescopeDialog{
+		gameScene: gameScene,
+		viewImage: ebiten.NewImage(sprites.TileSize*xTilesVisibleOnGameScreen, sprites.TileSize*yTilesVisibleOnGameScreen),
+		direction: direction,
+		startMs:   gameScene.clk.ElapsedMs(),
+	}
+}
+
+func (d *TelescopeDialog) Update() {
+	d.tilesPanned = min(game_state.TelescopeViewTiles, int((d.gameScene.clk.ElapsedMs()-d.startMs)/telescopeMsPerTilePanned))
+
+	boundKey := d.gameScene.keyboard.GetBoundKeyPressed(&telescopeBoundKeys)
+	if boundKey == nil {
+		d.gameScene.keyboard.SetAllowKeyPressImmediately()
+		return
+	}
+	if !d.gameScene.keyboard.TryToRegisterKeyPress(*boundKey) {
+		return
+	}
+	d.gameScene.dialogStack.PopModalDialog()
+	d.gameScene.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+}
+
+func (d *TelescopeDialog) Draw(screen *ebiten.Image) {
+	d.viewImage.Fill(image.Black)
+
+	overworld := d.gameScene.gameState.MapState.LayeredMaps.GetLayeredMap(references.LargeMapType, 0)
+	centre := d.gameScene.gameState.GetTelescopeViewPosition(d.direction, d.tilesPanned)
+
+	var drawImageOptions ebiten.DrawImageOptions
+	pos := &references.Position{}
+	for x := range references.Coordinate(xTilesVisibleOnGameScreen) {
+		for y := range references.Coordinate(yTilesVisibleOnGameScreen) {
+			pos.X = x + centre.X - xCenter
+			pos.Y = y + centre.Y - yCenter
+			pos = pos.GetWrapped(references.XLargeMapTiles, references.YLargeMapTiles)
+
+			tile := overworld.GetTileTopMapOnlyTile(pos)
+			if tile == nil {
+				continue
+			}
+			drawImageOptions.GeoM.Reset()
+			drawImageOptions.GeoM.Translate(float64(x*sprites.TileSize), float64(y*sprites.TileSize))
+			d.viewImage.DrawImage(d.gameScene.spriteSheet.GetSprite(tile.Index), &drawImageOptions)
+		}
+	}
+
+	screen.DrawImage(d.viewImage, sprites.GetDrawOptionsFromPercentsForWholeScreen(d.viewImage, gameScreenPercents))
+}
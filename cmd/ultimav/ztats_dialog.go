@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	etext "github.com/hajimehoshi/ebiten/v2/text/v2"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/game_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/text"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/ui/widgets"
+	"github.com/bradhannah/Ultima5ReduxGo/pkg/color"
+)
+
+var _ widgets.Widget = &ZtatsDialog{}
+
+const (
+	ztatsFontPoint       = 18
+	ztatsFontLineSpacing = ztatsFontPoint + 6
+	ztatsMaxLinesShown   = 16
+	ztatsMaxChars        = 32
+)
+
+const (
+	ztatsBorderStartPercentX = 0.15
+	ztatsBorderEndPercentX   = 0.61
+	ztatsBorderStartPercentY = 0.08
+	ztatsBorderEndPercentY   = 0.92
+
+	ztatsTextStartPercentX = ztatsBorderStartPercentX + percentTextIndentFromBorder
+	ztatsTextEndPercentX   = ztatsBorderEndPercentX
+	ztatsTextStartPercentY = ztatsBorderStartPercentY + 0.03
+	ztatsTextEndPercentY   = ztatsBorderEndPercentY
+)
+
+var ztatsBoundKeys = []ebiten.Key{
+	ebiten.Key1, ebiten.Key2, ebiten.Key3, ebiten.Key4, ebiten.Key5, ebiten.Key6,
+	ebiten.KeyLeft, ebiten.KeyRight, ebiten.KeyUp, ebiten.KeyDown,
+	ebiten.KeyEnter, ebiten.KeyEscape,
+}
+
+// ZtatsDialog shows the stats of one party member followed by a page per inventory category.
+// 1-6 choose the party member, left/right turn the page, up/down scroll long pages.
+type ZtatsDialog struct {
+	border *widgets.Border
+	font   *text.UltimaFont
+	output *text.Output
+
+	gameScene *GameScene
+
+	selectCharacter func(playerIndex int) bool
+	playerIndex     int
+	page            game_state.ZtatsPage
+	firstLineShown  int
+}
+
+// NewZtatsDialog creates the dialog for the given party member. selectCharacter is the map specific
+// ActionZtats* function and is consulted whenever another party member is chosen.
+func NewZtatsDialog(gameScene *GameScene, playerIndex int, selectCharacter func(playerIndex int) bool) *ZtatsDialog {
+	dialog := &ZtatsDialog{
+		gameScene:       gameScene,
+		selectCharacter: selectCharacter,
+		playerIndex:     playerIndex,
+		page:            game_state.ZtatsCharacterPage,
+	}
+	dialog.initializeResizeableVisualElements()
+	dialog.refreshOutput()
+	return dialog
+}
+
+func (d *ZtatsDialog) initializeResizeableVisualElements() {
+	d.border = widgets.NewBorder(
+		sprites.PercentBasedPlacement{
+			StartPercentX: ztatsBorderStartPercentX,
+			EndPercentX:   ztatsBorderEndPercentX,
+			StartPercentY: ztatsBorderStartPercentY,
+			EndPercentY:   ztatsBorderEndPercentY,
+		},
+		borderWidthScaling,
+		color.Black)
+
+	d.font = text.NewUltimaFont(text.GetScaledNumberToResolution(d.gameScene.gameConfig.DisplayManager, ztatsFontPoint))
+	d.output = text.NewOutput(d.font,
+		text.GetScaledNumberToResolution(d.gameScene.gameConfig.DisplayManager, ztatsFontLineSpacing),
+		ztatsMaxLinesShown+2,
+		ztatsMaxChars)
+}
+
+// refreshOutput redraws the visible portion of the current page
+func (d *ZtatsDialog) refreshOutput() {
+	lines := d.gameScene.gameState.GetZtatsPageLines(d.playerIndex, d.page)
+	d.firstLineShown = max(0, min(d.firstLineShown, len(lines)-ztatsMaxLinesShown))
+
+	d.output.Clear()
+	d.output.AddRowStr(fmt.Sprintf("%s (%d/%d)", d.page.GetTitle(), d.page+1, game_state.NZtatsPages), false)
+	d.output.AddRowStr("", false)
+	for _, line := range lines[d.firstLineShown:min(len(lines), d.firstLineShown+ztatsMaxLinesShown)] {
+		d.output.AddRowStr(line, false)
+	}
+}
+
+func (d *ZtatsDialog) close() {
+	d.gameScene.dialogStack.PopModalDialog()
+	d.gameScene.keyboard.SetForceWaitAnyKey(useMenuForceWaitTimeMs)
+}
+
+func (d *ZtatsDialog) Update() {
+	boundKey := d.gameScene.keyboard.GetBoundKeyPressed(&ztatsBoundKeys)
+	if boundKey == nil {
+		d.gameScene.keyboard.SetAllowKeyPressImmediately()
+		return
+	}
+	if !d.gameScene.keyboard.TryToRegisterKeyPress(*boundKey) {
+		return
+	}
+
+	switch *boundKey {
+	case ebiten.KeyEscape, ebiten.KeyEnter:
+		d.close()
+		return
+	case ebiten.KeyLeft:
+		d.page = d.page.GetPrevious()
+		d.firstLineShown = 0
+	case ebiten.KeyRight:
+		d.page = d.page.GetNext()
+		d.firstLineShown = 0
+	case ebiten.KeyUp:
+		d.firstLineShown = max(0, d.firstLineShown-1)
+	case ebiten.KeyDown:
+		d.firstLineShown++
+	default:
+		playerIndex := int(*boundKey - ebiten.Key1)
+		if playerIndex < party_state.NPlayers && d.selectCharacter(playerIndex) {
+			d.playerIndex = playerIndex
+			d.page = game_state.ZtatsCharacterPage
+			d.firstLineShown = 0
+		}
+	}
+	d.refreshOutput()
+}
+
+func (d *ZtatsDialog) Draw(screen *ebiten.Image) {
+	d.border.DrawBackground(screen)
+
+	textRect := sprites.GetRectangleFromPercents(sprites.PercentBasedPlacement{
+		StartPercentX: ztatsTextStartPercentX,
+		EndPercentX:   ztatsTextEndPercentX,
+		StartPercentY: ztatsTextStartPercentY,
+		EndPercentY:   ztatsTextEndPercentY,
+	})
+	d.output.DrawContinuousOutputTexOnXy(screen, image.Point{
+		X: textRect.Min.X,
+		Y: textRect.Min.Y,
+	}, false, etext.AlignStart, etext.AlignStart)
+	d.border.DrawBorder(screen)
+}
+
+// DoZtats asks for a party member and opens the Ztats screen for them. Ztats never uses a turn.
+// selectCharacter is the map specific ActionZtats* function.
+func (g *GameScene) DoZtats(selectCharacter func(playerIndex int) bool) {
+	g.DoSelectPartyMember("Ztats", func(playerIndex int) {
+		if selectCharacter(playerIndex) {
+			g.dialogStack.PushModalDialog(NewZtatsDialog(g, playerIndex, selectCharacter))
+		}
+	})
+}
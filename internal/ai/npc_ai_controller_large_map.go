@@ -8,9 +8,18 @@ import (
 	"github.com/bradhannah/Ultima5ReduxGo/internal/map_state"
 	"github.com/bradhannah/Ultima5ReduxGo/internal/map_units"
 	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
 	"github.com/bradhannah/Ultima5ReduxGo/pkg/helpers"
 )
 
+const (
+	// one in pirateShipOdds monsters generated on the open sea is a pirate ship
+	// TODO: TBD - a placeholder, not sourced from the original game
+	pirateShipOdds = 4
+	// and one in whirlpoolOdds of the rest is a whirlpool. See Encounters.md pickmon.
+	whirlpoolOdds = 8
+)
+
 type NPCAIControllerLargeMap struct {
 	World           references.World
 	tileRefs        *references.Tiles
@@ -228,7 +237,16 @@ func (m *NPCAIControllerLargeMap) generateTileBasedMonster() {
 
 		// Use environment-based monster selection instead of era-based
 		environment := m.determineEnvironmentType(tile)
-		enemy := m.pickMonsterByEnvironment(environment, tile)
+		if m.shouldGeneratePirateShip(environment, tile) {
+			m.addPirateShip(pos)
+			return
+		}
+		var enemy *references.EnemyReference
+		if m.shouldGenerateWhirlpool(environment, tile) {
+			enemy = m.getWhirlpoolReference()
+		} else {
+			enemy = m.pickMonsterByEnvironment(environment, tile)
+		}
 		if enemy == nil {
 			continue
 		}
@@ -250,6 +268,38 @@ func (m *NPCAIControllerLargeMap) generateTileBasedMonster() {
 	}
 }
 
+// shouldGeneratePirateShip gives open sea a chance of producing a pirate ship rather than a sea
+// monster - pirate ships never sail the rivers
+func (m *NPCAIControllerLargeMap) shouldGeneratePirateShip(environment MonsterEnvironment, tile *references.Tile) bool {
+	return environment == WaterEnvironment && tile.IsBoatPassable() && helpers.OneInXOdds(pirateShipOdds)
+}
+
+// shouldGenerateWhirlpool gives the open sea of the overworld a chance of producing a whirlpool
+func (m *NPCAIControllerLargeMap) shouldGenerateWhirlpool(environment MonsterEnvironment, tile *references.Tile) bool {
+	return m.World == references.OVERWORLD && environment == WaterEnvironment && tile.IsBoatPassable() &&
+		helpers.OneInXOdds(whirlpoolOdds)
+}
+
+func (m *NPCAIControllerLargeMap) getWhirlpoolReference() *references.EnemyReference {
+	for i := range *m.enemyReferences {
+		enemy := &(*m.enemyReferences)[i]
+		if enemy.KeyFrameTile != nil && enemy.KeyFrameTile.Index == indexes.Whirlpool_KeyIndex {
+			return enemy
+		}
+	}
+	return nil
+}
+
+func (m *NPCAIControllerLargeMap) addPirateShip(pos references.Position) {
+	heading := indexes.SpriteIndex(helpers.RandomIntInRange(int(indexes.PirateShip_Up), int(indexes.PirateShip_Down)))
+	npc := map_units.NewPirateShipNPC(m.tileRefs.GetTile(heading), len(m.mapUnits))
+
+	npc.SetPos(pos)
+	npc.SetFloor(m.mapState.PlayerLocation.Floor)
+	npc.SetVisible(true)
+	m.mapUnits = append(m.mapUnits, &npc)
+}
+
 func (m *NPCAIControllerLargeMap) shouldGenerateTileBasedMonster() bool {
 	// Calculate tile-based probability first
 	probability := m.calculateTileBasedProbability()
@@ -15,6 +15,7 @@ import (
 type RNGProvider interface {
 	OneInXOdds(odds int) bool
 	ShuffleDirections(directions []references.Position)
+	RandomIntInRange(min, max int) int
 }
 
 type NPCAIControllerSmallMap struct {
@@ -26,6 +27,12 @@ type NPCAIControllerSmallMap struct {
 
 	positionOccupiedChance *map_units.XyOccupiedMap
 
+	// guardsWantToArrest is set while the towne is alerted - guards drop their schedules and chase the Avatar
+	guardsWantToArrest bool
+
+	// lastScheduleHour is the hour NPCs last looked at their schedules, so every hour that passes is seen
+	lastScheduleHour byte
+
 	// Dependency injection for deterministic RNG
 	rngProvider RNGProvider
 }
@@ -43,7 +50,7 @@ func NewNPCAIControllerSmallMap(
 	npcsAiCont.slr = slr
 	npcsAiCont.mapState = mapState
 	npcsAiCont.rngProvider = rngProvider
-	//npcsAiCont.guardsWantToArrest = false
+	npcsAiCont.guardsWantToArrest = false
 
 	xy := make(map_units.XyOccupiedMap)
 	npcsAiCont.positionOccupiedChance = &xy
@@ -68,15 +75,16 @@ func (n *NPCAIControllerSmallMap) PopulateMapFirstLoad() {
 
 		switch mapUnit := npc.(type) {
 		case *map_units.NPCFriendly:
-			if n.mapState.PlayerLocation.Floor == npc.Floor() {
-				indiv := mapUnit.NPCReference.Schedule.GetIndividualNPCBehaviourByUltimaDate(*n.dateTime)
-				mapUnit.SetPositionByIndividualNPCBehaviour(indiv)
-			}
+			// everyone starts where their schedule says, whichever floor that is on
+			indiv := mapUnit.NPCReference.Schedule.GetIndividualNPCBehaviourByUltimaDate(*n.dateTime)
+			mapUnit.SetPositionByIndividualNPCBehaviour(indiv)
+			mapUnit.MapUnitDetails().ScheduleAction = references.ScheduleInactive
 		case *map_units.NPCEnemy:
 			// do not support NPC Enemy on small map
 		}
 
 	}
+	n.lastScheduleHour = n.dateTime.Hour
 	n.placeNPCsOnLayeredMap()
 }
 
@@ -91,10 +99,36 @@ func (n *NPCAIControllerSmallMap) SetAttackAvatar() {
 	}
 }
 
+func (n *NPCAIControllerSmallMap) ClearAttackAvatar() {
+	for _, mu := range n.mapUnits {
+		switch npc := mu.(type) {
+		case *map_units.NPCFriendly:
+			if npc.NPCReference.WantsToAttackAvatarWhenBadStuffGoesDown() {
+				mu.MapUnitDetails().SetOverriddenAiType(references.Unset)
+			}
+		}
+	}
+}
+
+// SetGuardsWantToArrest sends the guards after the Avatar, or back to their posts when the alarm is over
+func (n *NPCAIControllerSmallMap) SetGuardsWantToArrest(guardsWantToArrest bool) {
+	n.guardsWantToArrest = guardsWantToArrest
+	for _, mu := range n.mapUnits {
+		// any path they were following is no longer where they want to go
+		mu.MapUnitDetails().SetCurrentPath(nil)
+	}
+	if guardsWantToArrest {
+		n.SetAttackAvatar()
+	} else {
+		n.ClearAttackAvatar()
+	}
+}
+
 func (n *NPCAIControllerSmallMap) AdvanceNextTurnCalcAndMoveNPCs() {
 	n.mapState.GetLayeredMapByCurrentLocation().ClearMapUnitTiles()
 	//n.updateAllNPCAiTypes()
 	n.positionOccupiedChance = n.mapUnits.CreateFreshXyOccupiedMap()
+	n.startScheduleTransitionsOnHourChange()
 
 	for _, mu := range n.mapUnits {
 		// very lazy approach - but making sure every NPC is in correct spot on map
@@ -113,6 +147,42 @@ func (n *NPCAIControllerSmallMap) FreshenExistingNPCsOnMap() {
 	n.placeNPCsOnLayeredMap()
 }
 
+// startScheduleTransitionsOnHourChange sets NPCs off for their next destinations once the hour ticks over. Every
+// hour since the last look is checked, so sleeping or waiting through a transition hour doesn't miss it.
+func (n *NPCAIControllerSmallMap) startScheduleTransitionsOnHourChange() {
+	hoursPassed := (int(n.dateTime.Hour) - int(n.lastScheduleHour) + datetime.HoursPerDay) % datetime.HoursPerDay
+	n.lastScheduleHour = n.dateTime.Hour
+	if hoursPassed == 0 {
+		return
+	}
+
+	for _, mu := range n.mapUnits {
+		friendly, ok := mu.(*map_units.NPCFriendly)
+		if !ok || friendly.IsEmptyMapUnit() {
+			continue
+		}
+		for nHour := 0; nHour < hoursPassed; nHour++ {
+			hour := byte((int(n.dateTime.Hour) - nHour + datetime.HoursPerDay) % datetime.HoursPerDay)
+			if friendly.NPCReference.Schedule.IsTransitionHour(hour) {
+				n.startScheduleTransition(friendly)
+				break
+			}
+		}
+	}
+}
+
+// startScheduleTransition drops whatever path the NPC was on and decides how it will reach its new destination
+func (n *NPCAIControllerSmallMap) startScheduleTransition(friendly *map_units.NPCFriendly) {
+	friendly.MapUnitDetails().SetCurrentPath(nil)
+	friendly.MapUnitDetails().ScheduleAction = n.getScheduleAction(friendly)
+}
+
+func (n *NPCAIControllerSmallMap) getScheduleAction(friendly *map_units.NPCFriendly) references.ScheduleAction {
+	refBehaviour := friendly.NPCReference.Schedule.GetIndividualNPCBehaviourByUltimaDate(*n.dateTime)
+	atDestination := friendly.Pos() == refBehaviour.Position && friendly.Floor() == refBehaviour.Floor
+	return references.GetScheduleAction(friendly.Floor(), refBehaviour.Floor, n.mapState.PlayerLocation.Floor, atDestination)
+}
+
 func (n *NPCAIControllerSmallMap) generateNPCs() {
 	npcs := make([]map_units.MapUnit, 0)
 	// get the correct schedule
@@ -172,31 +242,40 @@ func (n *NPCAIControllerSmallMap) placeNPCsOnLayeredMap() {
 }
 
 func (n *NPCAIControllerSmallMap) calculateNextNPCPosition(friendly *map_units.NPCFriendly) {
-	//refBehaviour := friendly.NPCReference.Schedule.GetIndividualNPCBehaviourByUltimaDate(*n.dateTime)
 	refBehaviour := friendly.GetIndividualBehaviourByUltimaData(*n.dateTime)
 
-	if friendly.GetVehicleDetails().VehicleType == references.CarpetVehicle {
-		_ = "a"
+	if n.guardsWantToArrest && friendly.NPCReference.WantsToAttackAvatarWhenBadStuffGoesDown() {
+		n.pursueAvatar(friendly)
+		return
+	}
+
+	details := friendly.MapUnitDetails()
+	scheduled := friendly.NPCReference.Schedule.GetIndividualNPCBehaviourByUltimaDate(*n.dateTime)
+	outOfSight := friendly.Floor() != n.mapState.PlayerLocation.Floor
+	if details.ScheduleAction == references.ScheduleInactive &&
+		(friendly.Floor() != scheduled.Floor || (outOfSight && friendly.Pos() != scheduled.Position)) {
+		// they were never told to go, but they aren't where they should be all the same
+		n.startScheduleTransition(friendly)
+	}
+	if details.ScheduleAction != references.ScheduleInactive {
+		n.performScheduleAction(friendly)
+		return
 	}
 
-	// TEST: let's always finish what they are doing first before considering the next logic
+	if outOfSight {
+		// nobody sees what goes on elsewhere, so NPCs on other floors stay put
+		return
+	}
+
+	// let's always finish what they are doing first before considering the next logic
 	if n.moveNPCOnCalculatedPath(friendly) {
 		return
 	}
 
-	if friendly.PosPtr().Equals(&refBehaviour.Position) && friendly.Floor() == refBehaviour.Floor {
+	if friendly.PosPtr().Equals(&refBehaviour.Position) {
 		if n.performAiMovementOnAssignedPosition(friendly) {
 			return
 		}
-	} else if friendly.Floor() != refBehaviour.Floor { // the NPC is on the wrong floor according to their schedule
-		if friendly.Floor() == n.mapState.PlayerLocation.Floor { // the NPC is on the Avatar's current floor
-			n.performAiMovementFromCurrentFloorToDifferentFloor(friendly)
-			return
-		}
-		// the NPC is on another floor and needs to come to ours
-		n.performAiMovementFromDifferentFloorToOurFloor(friendly)
-
-		return
 	}
 
 	if n.performAiMovementNotOnAssignedPosition(friendly) {
@@ -204,56 +283,82 @@ func (n *NPCAIControllerSmallMap) calculateNextNPCPosition(friendly *map_units.N
 	}
 }
 
-// performAiMovementFromCurrentFloorToDifferentFloor From a DIFFERENT floor to OUR floor
-func (n *NPCAIControllerSmallMap) performAiMovementFromDifferentFloorToOurFloor(friendly *map_units.NPCFriendly) bool {
-	// called if the NPC is currently on a different floor then the current floor
-	//refBehaviour := friendly.NPCReference.Schedule.GetIndividualNPCBehaviourByUltimaDate(*n.dateTime)
-	refBehaviour := friendly.GetIndividualBehaviourByUltimaData(*n.dateTime)
+// performScheduleAction takes the NPC one step closer to its scheduled destination. The action is worked out
+// afresh first, as the Avatar may have climbed to another floor since the NPC set off.
+func (n *NPCAIControllerSmallMap) performScheduleAction(friendly *map_units.NPCFriendly) {
+	details := friendly.MapUnitDetails()
+	details.ScheduleAction = n.getScheduleAction(friendly)
+
+	switch details.ScheduleAction {
+	case references.ScheduleMove:
+		n.moveTowardsScheduledPosition(friendly)
+	case references.ScheduleLeaveUp, references.ScheduleLeaveDown:
+		n.leaveCurrentFloorForScheduledFloor(friendly)
+	case references.ScheduleArriveFromAbove, references.ScheduleArriveFromBelow:
+		n.arriveOnCurrentFloorFromScheduledFloor(friendly)
+	case references.SchedulePop:
+		n.popToScheduledPosition(friendly)
+	case references.ScheduleInactive:
+	}
 
-	// current floor matters - if they are coming to your floor - then teleport them
-	closestLadderPos := n.slr.GetClosestLadder(refBehaviour.Position, friendly.Floor(), n.mapState.PlayerLocation.Floor)
+	// arriving on the floor turns into a walk, and reaching the destination makes them inactive
+	details.ScheduleAction = n.getScheduleAction(friendly)
+}
 
-	// check if something or someone else is on the ladder, if so then we skip it for this turn
-	// and try again next turn
-	tile := n.mapState.GetLayeredMapByCurrentLocation().GetTopTile(&closestLadderPos)
-	if !tile.IsWalkingPassable() {
-		return false
+// moveTowardsScheduledPosition walks the NPC along a path to its destination, or to the ladder off this floor.
+// When there is no way through they take a random step in the hope of getting unstuck.
+func (n *NPCAIControllerSmallMap) moveTowardsScheduledPosition(friendly *map_units.NPCFriendly) {
+	if n.moveNPCOnCalculatedPath(friendly) {
+		return
 	}
-
-	friendly.SetPos(closestLadderPos)
-	friendly.SetFloor(refBehaviour.Floor)
-	return true
+	if n.createFreshPathToScheduledLocation(friendly) && n.moveNPCOnCalculatedPath(friendly) {
+		return
+	}
+	n.wanderOneTileWithinN(friendly, friendly.Pos(), 1)
 }
 
-// performAiMovementFromCurrentFloorToDifferentFloor From OUR floor to DIFFERENT floor
-func (n *NPCAIControllerSmallMap) performAiMovementFromCurrentFloorToDifferentFloor(friendly *map_units.NPCFriendly) bool {
-	// called if the NPC is currently on a different floor then the current floor
-	//refBehaviour := friendly.NPCReference.Schedule.GetIndividualNPCBehaviourByUltimaDate(*n.dateTime)
-	refBehaviour := friendly.GetIndividualBehaviourByUltimaData(*n.dateTime)
+// leaveCurrentFloorForScheduledFloor heads for the ladder or stairs towards the NPC's scheduled floor. Once on
+// them the NPC is out of sight, so there is nothing left to watch and they are put straight at their destination.
+func (n *NPCAIControllerSmallMap) leaveCurrentFloorForScheduledFloor(friendly *map_units.NPCFriendly) {
+	refBehaviour := friendly.NPCReference.Schedule.GetIndividualNPCBehaviourByUltimaDate(*n.dateTime)
 
 	currentNpcMapTile := n.mapState.GetLayeredMapByCurrentLocation().GetTileTopMapOnlyTile(friendly.PosPtr())
-	if references.IsSpecificLadderOrStairs(currentNpcMapTile.Index,
-		references.GetLadderOfStairsType(friendly.Floor(), refBehaviour.Floor)) {
-		// we have arrived at the ladder, so we will change their position as well
-		// to make sure they "come down from" the correct spot as well
-		friendly.SetFloor(refBehaviour.Floor)
-		friendly.SetPos(refBehaviour.Position)
-		return true
+	_, hasLadder := n.slr.TryGetClosestLadder(friendly.Pos(), friendly.Floor(), refBehaviour.Floor)
+	onLadder := currentNpcMapTile != nil && references.IsSpecificLadderOrStairs(currentNpcMapTile.Index,
+		references.GetLadderOfStairsType(friendly.Floor(), refBehaviour.Floor))
+	if onLadder || !hasLadder {
+		n.popToScheduledPosition(friendly)
+		return
 	}
 
-	// // current floor matters - if they are coming to your floor - then teleport them
-	closestLadderPos := n.slr.GetClosestLadder(refBehaviour.Position, friendly.Floor(), refBehaviour.Floor) // n.gameState.Floor)
-	tile := n.mapState.GetLayeredMapByCurrentLocation().GetTopTile(&closestLadderPos)
-	if !tile.IsWalkingPassable() {
-		return false
-	}
+	n.moveTowardsScheduledPosition(friendly)
+}
 
-	// the ladder is not used, so let's build a path
-	if n.createFreshPathToScheduledLocation(friendly) {
-		return true
+// arriveOnCurrentFloorFromScheduledFloor has the NPC come onto the Avatar's floor by the ladder or stairs
+// closest to where they are going. If someone is standing on it they wait for the next turn.
+func (n *NPCAIControllerSmallMap) arriveOnCurrentFloorFromScheduledFloor(friendly *map_units.NPCFriendly) {
+	refBehaviour := friendly.NPCReference.Schedule.GetIndividualNPCBehaviourByUltimaDate(*n.dateTime)
+	currentFloor := n.mapState.PlayerLocation.Floor
+
+	arrivalPos, ok := n.slr.TryGetClosestLadder(refBehaviour.Position, currentFloor, friendly.Floor())
+	if !ok {
+		// with no way between the floors they simply turn up where they are going
+		arrivalPos = refBehaviour.Position
+	}
+	if n.mapState.PlayerLocation.Position.Equals(&arrivalPos) || !n.mapState.IsNPCPassable(&arrivalPos) {
+		return
 	}
 
-	return false
+	friendly.SetFloor(currentFloor)
+	friendly.SetPos(arrivalPos)
+	friendly.MapUnitDetails().SetCurrentPath(nil)
+}
+
+// popToScheduledPosition puts the NPC at its destination without any walking, for journeys the Avatar can't see
+func (n *NPCAIControllerSmallMap) popToScheduledPosition(friendly *map_units.NPCFriendly) {
+	refBehaviour := friendly.NPCReference.Schedule.GetIndividualNPCBehaviourByUltimaDate(*n.dateTime)
+	friendly.SetPositionByIndividualNPCBehaviour(refBehaviour)
+	friendly.MapUnitDetails().SetCurrentPath(nil)
 }
 
 func (n *NPCAIControllerSmallMap) performAiMovementOnAssignedPosition(friendly *map_units.NPCFriendly) bool {
@@ -392,6 +497,8 @@ func (n *NPCAIControllerSmallMap) moveNPCOnCalculatedPath(friendly *map_units.NP
 		friendly.SetPos(newPos)
 		return true
 	}
+	// something is in the way, so the rest of the path no longer follows on from where they stand
+	friendly.MapUnitDetails().SetCurrentPath(nil)
 	return false
 }
 
@@ -410,7 +517,10 @@ func (n *NPCAIControllerSmallMap) createFreshPathToScheduledLocation(friendly *m
 	var path []references.Position
 	if npcBehaviour.Floor != friendly.Floor() {
 		// we prefer to find the best ladder or stairs
-		closestFloorChangePosition := n.slr.GetClosestLadder(friendly.Pos(), friendly.Floor(), npcBehaviour.Floor)
+		closestFloorChangePosition, ok := n.slr.TryGetClosestLadder(friendly.Pos(), friendly.Floor(), npcBehaviour.Floor)
+		if !ok {
+			return false
+		}
 		path = aStarMap.AStar(closestFloorChangePosition)
 	} else {
 		path = aStarMap.AStar(npcBehaviour.Position)
@@ -425,8 +535,34 @@ func (n *NPCAIControllerSmallMap) createFreshPathToScheduledLocation(friendly *m
 	return muDetails.HasAPathAlreadyCalculated()
 }
 
+// pursueAvatar moves a guard one step along the shortest path to the Avatar. The path is worked out
+// afresh every turn as the Avatar will not stand still for it.
+func (n *NPCAIControllerSmallMap) pursueAvatar(friendly *map_units.NPCFriendly) bool {
+	if friendly.Floor() != n.mapState.PlayerLocation.Floor {
+		return false
+	}
+	avatarPos := n.mapState.PlayerLocation.Position
+	if friendly.PosPtr().IsNextTo(avatarPos) {
+		// close enough to make the arrest
+		return false
+	}
+
+	aStarMap := astar.NewAStarMap()
+	aStarMap.InitializeByLayeredMap(friendly, n.mapState.GetLayeredMapByCurrentLocation(), []references.Position{})
+	path := aStarMap.AStar(avatarPos)
+
+	// the first position is where the guard stands, and the last is the Avatar
+	if len(path) < 3 || !n.mapState.IsNPCPassable(&path[1]) {
+		return false
+	}
+	friendly.SetPos(path[1])
+	return true
+}
+
+// wanderOneTileWithinN makes up to four tries at a random step that keeps the NPC within N tiles of anchorPos.
+// Each try picks its direction afresh, so the same direction can come up more than once.
 func (n *NPCAIControllerSmallMap) wanderOneTileWithinN(friendly *map_units.NPCFriendly, anchorPos references.Position, withinN int) bool {
-	// Use deterministic RNG instead of time.Now() seeding
+	const wanderTries = 4
 
 	// Define possible moves: up, down, left, right
 	directions := []references.Position{
@@ -436,13 +572,10 @@ func (n *NPCAIControllerSmallMap) wanderOneTileWithinN(friendly *map_units.NPCFr
 		{X: 1, Y: 0},  // Right
 	}
 
-	// Shuffle the directions using deterministic RNG
-	n.rngProvider.ShuffleDirections(directions)
-
 	muDetails := friendly.MapUnitDetails()
 
-	// Try each direction to find a valid move
-	for _, move := range directions {
+	for try := 0; try < wanderTries; try++ {
+		move := directions[n.rngProvider.RandomIntInRange(0, len(directions)-1)]
 
 		newPos := references.Position{
 			X: muDetails.Position.X + move.X,
@@ -477,7 +610,8 @@ func (n *NPCAIControllerSmallMap) wanderOneTileWithinN(friendly *map_units.NPCFr
 func (n *NPCAIControllerSmallMap) getWanderDistanceByAiType(aiType references.AiType) int {
 	switch aiType {
 	case references.HorseWander:
-		return 4
+		// horses keep close to their stable
+		return 2
 	case references.Wander:
 		return 2
 	case references.BigWander, references.BlackthornGuardWander, references.MerchantBuyingSellingCustom, references.MerchantBuyingSellingWander:
@@ -0,0 +1,100 @@
+package datetime
+
+// MoonPhase is the phase of one of Britannia's two moons. The order matches the moonstones
+// and the eight moongates - see Moongates.md.
+type MoonPhase int
+
+const (
+	NewMoon MoonPhase = iota
+	CrescentWaxing
+	FirstQuarter
+	GibbousWaxing
+	FullMoon
+	GibbousWaning
+	LastQuarter
+	CrescentWaning
+)
+
+const NumberOfMoonPhases = 8
+
+const (
+	// Trammel, the slow moon, passes through all of its phases once a month (3.5 days per phase)
+	trammelPhasesPerMonth = NumberOfMoonPhases
+
+	// the gate to the Shrine of Spirituality only opens in the first ten minutes after midnight
+	spiritualityGateMinutes = 10
+)
+
+// Moon is one of the two moons of Britannia
+type Moon int
+
+const (
+	Trammel Moon = iota
+	Felucca
+)
+
+func (p MoonPhase) String() string {
+	switch p {
+	case NewMoon:
+		return "New Moon"
+	case CrescentWaxing:
+		return "Crescent Waxing"
+	case FirstQuarter:
+		return "First Quarter"
+	case GibbousWaxing:
+		return "Gibbous Waxing"
+	case FullMoon:
+		return "Full Moon"
+	case GibbousWaning:
+		return "Gibbous Waning"
+	case LastQuarter:
+		return "Last Quarter"
+	case CrescentWaning:
+		return "Crescent Waning"
+	}
+	return "Unknown"
+}
+
+// getDaysSinceEpoch returns the number of whole days since 1-1-0
+func (d *UltimaDate) getDaysSinceEpoch() int {
+	return (int(d.Year)*MonthsPerYear+int(d.Month)-1)*DaysInMonth + int(d.Day) - 1
+}
+
+// GetTrammelPhase returns the phase of Trammel on the current day
+func (d *UltimaDate) GetTrammelPhase() MoonPhase {
+	dayOfMonth := d.getDaysSinceEpoch() % DaysInMonth
+	return MoonPhase(dayOfMonth * trammelPhasesPerMonth / DaysInMonth)
+}
+
+// GetFeluccaPhase returns the phase of Felucca on the current day - the fast moon moves on a phase every day
+func (d *UltimaDate) GetFeluccaPhase() MoonPhase {
+	return MoonPhase(d.getDaysSinceEpoch() % NumberOfMoonPhases)
+}
+
+// GetMoonPhases returns the phases of Trammel and Felucca
+func (d *UltimaDate) GetMoonPhases() (trammel, felucca MoonPhase) {
+	return d.GetTrammelPhase(), d.GetFeluccaPhase()
+}
+
+// GetMoongateMoon returns the moon that decides where a moongate leads - Trammel in the
+// morning and Felucca from noon onwards
+func (d *UltimaDate) GetMoongateMoon() Moon {
+	if d.Hour < 12 {
+		return Trammel
+	}
+	return Felucca
+}
+
+// GetMoongatePhase returns the phase of the moon that currently decides where a moongate leads
+func (d *UltimaDate) GetMoongatePhase() MoonPhase {
+	if d.GetMoongateMoon() == Trammel {
+		return d.GetTrammelPhase()
+	}
+	return d.GetFeluccaPhase()
+}
+
+// IsShrineOfSpiritualityGateOpen is true in the short window after midnight when every
+// moongate leads to the Shrine of Spirituality
+func (d *UltimaDate) IsShrineOfSpiritualityGateOpen() bool {
+	return d.Hour == 0 && d.Minute < spiritualityGateMinutes
+}
@@ -0,0 +1,67 @@
+package datetime
+
+import "testing"
+
+func TestUltimaDate_MoonPhases(t *testing.T) {
+	tests := []struct {
+		name            string
+		date            UltimaDate
+		expectedTrammel MoonPhase
+		expectedFelucca MoonPhase
+	}{
+		{"first day of the first month", UltimaDate{Year: 0, Month: 1, Day: 1}, NewMoon, NewMoon},
+		{"second day", UltimaDate{Year: 0, Month: 1, Day: 2}, NewMoon, CrescentWaxing},
+		{"Trammel moves on after three and a half days", UltimaDate{Year: 0, Month: 1, Day: 5}, CrescentWaxing, FullMoon},
+		{"Trammel reaches its first quarter on the eighth day", UltimaDate{Year: 0, Month: 1, Day: 8}, FirstQuarter, CrescentWaning},
+		{"Felucca starts over on the ninth day", UltimaDate{Year: 0, Month: 1, Day: 9}, FirstQuarter, NewMoon},
+		{"Trammel is full mid month", UltimaDate{Year: 0, Month: 1, Day: 15}, FullMoon, LastQuarter},
+		{"last day of the month", UltimaDate{Year: 0, Month: 1, Day: 28}, CrescentWaning, GibbousWaxing},
+		{"Trammel starts over each month", UltimaDate{Year: 0, Month: 2, Day: 1}, NewMoon, FullMoon},
+		{"Felucca carries over between years", UltimaDate{Year: 139, Month: 4, Day: 6}, CrescentWaxing, CrescentWaxing},
+		{"the hour does not change the phase", UltimaDate{Year: 139, Month: 4, Day: 6, Hour: 23, Minute: 59}, CrescentWaxing, CrescentWaxing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trammel, felucca := tt.date.GetMoonPhases()
+			if trammel != tt.expectedTrammel {
+				t.Errorf("Expected Trammel %s, got %s", tt.expectedTrammel, trammel)
+			}
+			if felucca != tt.expectedFelucca {
+				t.Errorf("Expected Felucca %s, got %s", tt.expectedFelucca, felucca)
+			}
+		})
+	}
+}
+
+func TestUltimaDate_MoongatePhase(t *testing.T) {
+	// 1-5-0 has Trammel as Crescent Waxing and Felucca as Full Moon
+	tests := []struct {
+		name                 string
+		hour, minute         byte
+		expectedMoon         Moon
+		expectedPhase        MoonPhase
+		expectedSpirituality bool
+	}{
+		{"just after midnight leads to the Shrine of Spirituality", 0, 0, Trammel, CrescentWaxing, true},
+		{"the shrine window closes after ten minutes", 0, 10, Trammel, CrescentWaxing, false},
+		{"Trammel rules the morning", 11, 59, Trammel, CrescentWaxing, false},
+		{"Felucca rules from noon", 12, 0, Felucca, FullMoon, false},
+		{"Felucca rules the night", 23, 59, Felucca, FullMoon, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date := UltimaDate{Year: 0, Month: 1, Day: 5, Hour: tt.hour, Minute: tt.minute}
+			if moon := date.GetMoongateMoon(); moon != tt.expectedMoon {
+				t.Errorf("Expected moon %d, got %d", tt.expectedMoon, moon)
+			}
+			if phase := date.GetMoongatePhase(); phase != tt.expectedPhase {
+				t.Errorf("Expected phase %s, got %s", tt.expectedPhase, phase)
+			}
+			if open := date.IsShrineOfSpiritualityGateOpen(); open != tt.expectedSpirituality {
+				t.Errorf("Expected the Shrine of Spirituality gate open=%t, got %t", tt.expectedSpirituality, open)
+			}
+		})
+	}
+}
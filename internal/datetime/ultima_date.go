@@ -71,11 +71,11 @@ func (d *UltimaDate) Advance(nMinutes int) {
 
 	// Check if adding minutes moves to a new hour
 	if int(d.Minute)+nMinutes > MinutesPerHour-1 {
-		nHours := byte(nMinutes / MinutesPerHour)
-		nExtraMinutes := nMinutes % MinutesPerHour
+		nTotalMinutes := int(d.Minute) + nMinutes
+		nHours := byte(nTotalMinutes / MinutesPerHour)
 
-		newHour := d.Hour + nHours + 1
-		d.Minute = byte((int(d.Minute) + nExtraMinutes) % MinutesPerHour)
+		newHour := d.Hour + nHours
+		d.Minute = byte(nTotalMinutes % MinutesPerHour)
 
 		// Check if advancing hours moves to a new day
 		if newHour <= HoursPerDay-1 {
@@ -128,6 +128,29 @@ func (d *UltimaDate) IsNight() bool {
 	return !d.IsDayLight()
 }
 
+func (d *UltimaDate) IsSunrise() bool {
+	return d.Hour == hourOfSunrise
+}
+
+func (d *UltimaDate) IsSunset() bool {
+	return d.Hour == hourOfSunset
+}
+
+// GetSkyArcFraction returns how far across the sky the sun (by day) or the moons (by night) have
+// travelled - 0 as they rise and approaching 1 as they set
+func (d *UltimaDate) GetSkyArcFraction() float64 {
+	const minutesPerDay = HoursPerDay * MinutesPerHour
+
+	minutesSinceMidnight := int(d.Hour)*MinutesPerHour + int(d.Minute)
+	if d.IsDayLight() {
+		return float64(minutesSinceMidnight-hourOfSunrise*MinutesPerHour) /
+			float64((hourOfSunset-hourOfSunrise)*MinutesPerHour)
+	}
+
+	minutesSinceSunset := (minutesSinceMidnight - hourOfSunset*MinutesPerHour + minutesPerDay) % minutesPerDay
+	return float64(minutesSinceSunset) / float64(minutesPerDay-(hourOfSunset-hourOfSunrise)*MinutesPerHour)
+}
+
 // GetVisibilityFactorWithoutTorch returns a 0–1 visibility factor
 // (despite the word “Percent” in the name).
 func (d *UltimaDate) GetVisibilityFactorWithoutTorch(baselineMin float32) float32 {
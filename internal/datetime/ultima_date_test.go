@@ -0,0 +1,56 @@
+package datetime
+
+import "testing"
+
+func TestUltimaDate_GetSkyArcFraction(t *testing.T) {
+	tests := []struct {
+		name         string
+		hour, minute byte
+		expected     float64
+	}{
+		{"the sun rises", 5, 0, 0},
+		{"the sun is halfway across at half past noon", 12, 30, 0.5},
+		{"the sun is about to set", 19, 30, 29.0 / 30.0},
+		{"the moons rise at sunset", 20, 0, 0},
+		{"the moons are nearly halfway across at midnight", 0, 0, 4.0 / 9.0},
+		{"the moons are about to set", 4, 30, 17.0 / 18.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date := UltimaDate{Year: 139, Month: 4, Day: 6, Hour: tt.hour, Minute: tt.minute}
+			if got := date.GetSkyArcFraction(); got != tt.expected {
+				t.Errorf("Expected %f, got %f", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestUltimaDate_Advance(t *testing.T) {
+	tests := []struct {
+		name                          string
+		day, hour, minute             byte
+		nMinutes                      int
+		expectedDay, expectedHour     byte
+		expectedMinute, expectedMonth byte
+	}{
+		{"a minute passes", 6, 12, 0, 1, 6, 12, 1, 4},
+		{"an hour passes on the hour", 6, 12, 0, 60, 6, 13, 0, 4},
+		{"the minutes carry into the next hour", 6, 12, 50, 20, 6, 13, 10, 4},
+		{"nine hours pass", 6, 12, 0, 540, 6, 21, 0, 4},
+		{"the night passes into the next day", 6, 23, 30, 540, 7, 8, 30, 4},
+		{"the month ends", 28, 23, 0, 120, 1, 1, 0, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date := UltimaDate{Year: 139, Month: 4, Day: tt.day, Hour: tt.hour, Minute: tt.minute}
+			date.Advance(tt.nMinutes)
+			if date.Day != tt.expectedDay || date.Hour != tt.expectedHour || date.Minute != tt.expectedMinute || date.Month != tt.expectedMonth {
+				t.Errorf("Expected month %d day %d %d:%02d, got month %d day %d %d:%02d",
+					tt.expectedMonth, tt.expectedDay, tt.expectedHour, tt.expectedMinute,
+					date.Month, date.Day, date.Hour, date.Minute)
+			}
+		})
+	}
+}
@@ -21,8 +21,15 @@ const (
 	PoisonSwamp
 	LavaBurn
 	FireplaceBurn
+	RoughSeas
+	WaterfallFall
+	UnderworldEarthquake
+	WhirlpoolPull
 )
 
+// the underworld shakes when random(0, 255) rolls 0x69
+const underworldEarthquakeRoll = 0x69
+
 type EnvironmentalHazards struct {
 	rng       *rand.Rand
 	callbacks SystemCallbacks
@@ -132,22 +139,7 @@ func (h *EnvironmentalHazards) checkBurningHazard(party *party_state.PartyState,
 	result := HazardResult{Type: hazardType}
 
 	// Original: damage_party_on_land() - each living member takes 1..8
-	totalDamage := 0
-	for i, member := range party.Characters {
-		if member.Status != party_state.Dead && member.PartyStatus == party_state.InTheParty {
-			damage := h.rng.Intn(8) + 1 // 1..8 range
-			newHP := helpers.Max(0, int(member.CurrentHp)-damage)
-			party.Characters[i].CurrentHp = uint16(newHP)
-			totalDamage += damage
-			result.Affected = append(result.Affected, i)
-
-			if party.Characters[i].CurrentHp <= 0 {
-				party.Characters[i].Status = party_state.Dead
-			}
-		}
-	}
-
-	result.DamageDealt = totalDamage
+	result.Affected, result.DamageDealt = h.damageParty(party)
 
 	// Show burning message with character names
 	if len(result.Affected) > 0 {
@@ -187,6 +179,43 @@ func (h *EnvironmentalHazards) checkBurningHazard(party *party_state.PartyState,
 	return result
 }
 
+// CheckUnderworldEarthquake gives the underworld a small chance of shaking the whole party each turn
+func (h *EnvironmentalHazards) CheckUnderworldEarthquake(party *party_state.PartyState) HazardResult {
+	if h.rng.Intn(256) != underworldEarthquakeRoll {
+		return HazardResult{Type: NoHazard}
+	}
+
+	result := HazardResult{Type: UnderworldEarthquake}
+	h.callbacks.AddRowStr("EARTHQUAKE!")
+	result.MessageShown = true
+	result.Affected, result.DamageDealt = h.damageParty(party)
+	return result
+}
+
+// damageParty is damageparty() - each living member of the party takes 1..8 damage
+func (h *EnvironmentalHazards) damageParty(party *party_state.PartyState) ([]int, int) {
+	affected := make([]int, 0, party_state.NPlayers)
+	totalDamage := 0
+	for i, member := range party.Characters {
+		if member.Status == party_state.Dead || member.PartyStatus != party_state.InTheParty {
+			continue
+		}
+		damage := h.rng.Intn(8) + 1 // 1..8 range
+		h.damagePartyMember(party, i, damage)
+		totalDamage += damage
+		affected = append(affected, i)
+	}
+	return affected, totalDamage
+}
+
+func (h *EnvironmentalHazards) damagePartyMember(party *party_state.PartyState, memberIndex int, damage int) {
+	newHP := helpers.Max(0, int(party.Characters[memberIndex].CurrentHp)-damage)
+	party.Characters[memberIndex].CurrentHp = uint16(newHP)
+	if party.Characters[memberIndex].CurrentHp <= 0 {
+		party.Characters[memberIndex].Status = party_state.Dead
+	}
+}
+
 // joinNames creates a grammatically correct list of names
 func joinNames(names []string) string {
 	switch len(names) {
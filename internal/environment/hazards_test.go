@@ -248,3 +248,32 @@ func TestNoHazardForSafeTiles(t *testing.T) {
 	assert.Equal(t, 0, result.DamageDealt)
 	assert.Empty(t, mockCallbacks.Messages)
 }
+
+func TestUnderworldEarthquakeIsRareAndShakesTheWholeParty(t *testing.T) {
+	rng := rand.New(rand.NewSource(12345))
+	mockCallbacks := &MockSystemCallbacks{}
+	hazards := NewEnvironmentalHazards(rng, mockCallbacks)
+
+	party := createTestPartyWithStats([]TestCharacterStats{
+		{HitPoints: 200, Status: party_state.Good},
+		{HitPoints: 200, Status: party_state.Good},
+		{HitPoints: 0, Status: party_state.Dead},
+	})
+
+	const turns = 256 * 20
+	earthquakes := 0
+	for range turns {
+		result := hazards.CheckUnderworldEarthquake(party)
+		if result.Type == NoHazard {
+			continue
+		}
+		earthquakes++
+		assert.Equal(t, UnderworldEarthquake, result.Type)
+		assert.Subset(t, result.Affected, []int{0, 1})
+		assert.NotContains(t, result.Affected, 2, "The dead should not be shaken")
+	}
+
+	// about 1 turn in 256 shakes the underworld
+	assert.True(t, earthquakes > 5 && earthquakes < 50, "Expected about 20 earthquakes, got %d", earthquakes)
+	assert.Contains(t, mockCallbacks.Messages, "EARTHQUAKE!")
+}
@@ -0,0 +1,70 @@
+package environment
+
+import (
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+// Hazards that only threaten a party on (or over) the water - rough seas and waterfalls when they
+// move, and whirlpools as the turns pass. See Movement_Overworld.md Rough Seas and Waterfalls, and
+// Whirlpools and Pirate Ships.
+
+// CheckSeaHazards checks the tile the party has just moved onto for rough seas and waterfalls
+func (h *EnvironmentalHazards) CheckSeaHazards(tile *references.Tile, party *party_state.PartyState, vehicle references.VehicleType) HazardResult {
+	if tile == nil {
+		return HazardResult{Type: NoHazard}
+	}
+
+	switch {
+	case tile.Is(indexes.Waterfall):
+		return h.fallDownWaterfall(party)
+	case tile.Is(indexes.Water1) && (vehicle == references.SkiffVehicle || vehicle == references.CarpetVehicle):
+		// only deep water is too much for anything smaller than a frigate
+		result := HazardResult{Type: RoughSeas, MessageShown: true}
+		h.callbacks.AddRowStr("Rough seas!")
+		result.Affected, result.DamageDealt = h.damageParty(party)
+		return result
+	default:
+		return HazardResult{Type: NoHazard}
+	}
+}
+
+// fallDownWaterfall tumbles the party over the falls - the nimble are left unhurt. The party is
+// carried down the falls, and perhaps into the underworld, by the caller.
+func (h *EnvironmentalHazards) fallDownWaterfall(party *party_state.PartyState) HazardResult {
+	result := HazardResult{Type: WaterfallFall, MessageShown: true}
+	h.callbacks.AddRowStr("F-A-L-L-S!!!")
+
+	for i, member := range party.Characters {
+		if member.Status == party_state.Dead || member.PartyStatus != party_state.InTheParty {
+			continue
+		}
+		// Original: rolld30() >= dexterity
+		if h.rng.Intn(30)+1 >= int(member.Dexterity) {
+			h.damagePartyMember(party, i, 1)
+			result.DamageDealt++
+			result.Affected = append(result.Affected, i)
+		}
+	}
+	return result
+}
+
+// CheckWhirlpools is true for a ship that a whirlpool has caught up with - it is taken down to the
+// underworld by the caller
+func (h *EnvironmentalHazards) CheckWhirlpools(
+	partyPosition references.Position,
+	whirlpoolPositions []references.Position,
+	vehicle references.VehicleType,
+) HazardResult {
+	if vehicle != references.FrigateVehicle && vehicle != references.SkiffVehicle {
+		return HazardResult{Type: NoHazard}
+	}
+
+	for _, whirlpoolPosition := range whirlpoolPositions {
+		if whirlpoolPosition == partyPosition {
+			return HazardResult{Type: WhirlpoolPull}
+		}
+	}
+	return HazardResult{Type: NoHazard}
+}
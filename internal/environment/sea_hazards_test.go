@@ -0,0 +1,82 @@
+package environment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/exp/rand"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+func TestRoughSeasOnlyTroubleSmallCraft(t *testing.T) {
+	testCases := []struct {
+		name         string
+		tileIndex    indexes.SpriteIndex
+		vehicle      references.VehicleType
+		expectedType HazardType
+	}{
+		{"Skiff on deep water", indexes.Water1, references.SkiffVehicle, RoughSeas},
+		{"Carpet over deep water", indexes.Water1, references.CarpetVehicle, RoughSeas},
+		{"Skiff on calmer water", indexes.Water2, references.SkiffVehicle, NoHazard},
+		{"Frigate on deep water", indexes.Water1, references.FrigateVehicle, NoHazard},
+		{"Skiff in the shallows", indexes.WaterShallow, references.SkiffVehicle, NoHazard},
+		{"Skiff over a waterfall", indexes.Waterfall, references.SkiffVehicle, WaterfallFall},
+		{"Nil tile", 0, references.SkiffVehicle, NoHazard},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hazards := NewEnvironmentalHazards(rand.New(rand.NewSource(12345)), &MockSystemCallbacks{})
+			party := createTestPartyWithStats([]TestCharacterStats{
+				{Dexterity: 1, HitPoints: 20, Status: party_state.Good},
+			})
+
+			var tile *references.Tile
+			if tc.tileIndex != 0 {
+				tile = &references.Tile{Index: tc.tileIndex}
+			}
+
+			result := hazards.CheckSeaHazards(tile, party, tc.vehicle)
+			assert.Equal(t, tc.expectedType, result.Type)
+			if tc.expectedType != NoHazard {
+				assert.True(t, result.DamageDealt > 0)
+				assert.True(t, party.Characters[0].CurrentHp < 20)
+			}
+		})
+	}
+}
+
+func TestWaterfallSparesTheNimble(t *testing.T) {
+	mockCallbacks := &MockSystemCallbacks{}
+	hazards := NewEnvironmentalHazards(rand.New(rand.NewSource(12345)), mockCallbacks)
+	party := createTestPartyWithStats([]TestCharacterStats{
+		{Dexterity: 31, HitPoints: 20, Status: party_state.Good},
+		{Dexterity: 1, HitPoints: 20, Status: party_state.Good},
+	})
+
+	result := hazards.CheckSeaHazards(&references.Tile{Index: indexes.Waterfall}, party, references.SkiffVehicle)
+
+	assert.Equal(t, WaterfallFall, result.Type)
+	assert.NotContains(t, result.Affected, 0)
+	assert.Contains(t, result.Affected, 1)
+	assert.Equal(t, uint16(20), party.Characters[0].CurrentHp)
+	assert.Equal(t, uint16(19), party.Characters[1].CurrentHp)
+	assert.Contains(t, mockCallbacks.Messages, "F-A-L-L-S!!!")
+}
+
+func TestWhirlpoolCatchesShips(t *testing.T) {
+	hazards := NewEnvironmentalHazards(rand.New(rand.NewSource(12345)), &MockSystemCallbacks{})
+	party := references.Position{X: 50, Y: 50}
+
+	result := hazards.CheckWhirlpools(party, []references.Position{{X: 10, Y: 10}, {X: 50, Y: 50}}, references.FrigateVehicle)
+	assert.Equal(t, WhirlpoolPull, result.Type)
+
+	result = hazards.CheckWhirlpools(party, []references.Position{{X: 51, Y: 50}}, references.SkiffVehicle)
+	assert.Equal(t, NoHazard, result.Type, "A whirlpool alongside has not caught the ship yet")
+
+	result = hazards.CheckWhirlpools(party, []references.Position{{X: 50, Y: 50}}, references.CarpetVehicle)
+	assert.Equal(t, NoHazard, result.Type, "A carpet flies over whirlpools")
+}
@@ -1,18 +1,29 @@
 package game_state
 
 import (
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_units"
 	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
 )
 
 func (g *GameState) ActionAttackSmallMap(direction references.Direction) bool {
-	// TODO: Implement small map Attack command - see Commands.md Attack section
-	// Should handle:
+	// TODO: Finish small map Attack command - see Commands.md Attack section
+	// Still to handle:
 	// - Mirror breaking special case
-	// - NPC/monster targeting validation
-	// - Karma consequences (-5 for NPC attacks)
-	// - Guard activation on aggression
 	// - Stocks/manacles murder handling
 	// - Combat initiation for valid targets
+	attackPos := direction.GetNewPositionInDirection(&g.MapState.PlayerLocation.Position)
+	npc := g.CurrentNPCAIController.GetNpcs().GetMapUnitAtPositionOrNil(*attackPos)
+	if npc == nil {
+		g.SystemCallbacks.Message.AddRowStr("Nothing to attack!")
+		return false
+	}
+
+	if friendly, ok := (*npc).(*map_units.NPCFriendly); ok && friendly.NPCReference.GetNPCType() != references.Vehicle {
+		// Karma consequences and guard activation on aggression
+		g.assaultTownsperson()
+		g.SystemCallbacks.Flow.AdvanceTime(1)
+		return true
+	}
 
 	// Combat system not implemented yet
 	g.SystemCallbacks.Message.AddRowStr("Not here!")
@@ -38,10 +49,11 @@ func (g *GameState) ActionAttackCombatMap(direction references.Direction) bool {
 }
 
 func (g *GameState) ActionAttackDungeonMap(direction references.Direction) bool {
-	// TODO: Implement dungeon map Attack command - see Commands.md Attack section
-	// Dungeon map variant of attack command
+	// the monsters of a dungeon are only met in its rooms
+	if g.IsInDungeonRoom() {
+		return g.attackDungeonRoomMonster(direction)
+	}
 
-	// Combat system not implemented yet
 	g.SystemCallbacks.Message.AddRowStr("Not here!")
 	return false
 }
@@ -53,6 +53,10 @@ func (g *GameState) ActionBoard() bool {
 	// Attempt to board the vehicle
 	result := g.BoardVehicle(*vehicle)
 
+	if vehicleType == references.FrigateVehicle && vehicle.GetVehicleDetails().GetHullPoints() < badlyDamagedHullPoints {
+		g.SystemCallbacks.Message.AddRowStr("DANGER: SHIP BADLY DAMAGED!")
+	}
+
 	switch result {
 	case BoardVehicleResultSuccess:
 		// Success message already printed above
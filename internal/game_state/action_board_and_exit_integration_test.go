@@ -81,7 +81,7 @@ func TestVehicleBoardExitCycle_Integration_RealGameData(t *testing.T) {
 	audioCallbacks := NewAudioCallbacks(nil)
 	screenCallbacks := NewScreenCallbacks(nil, nil, nil, nil, nil)
 	flowCallbacks := NewFlowCallbacks(nil, nil, nil, nil, nil, nil)
-	talkCallbacks := NewTalkCallbacks(nil, nil)
+	talkCallbacks := NewTalkCallbacks(nil, nil, nil)
 
 	systemCallbacks, err := NewSystemCallbacks(messageCallbacks, visualCallbacks, audioCallbacks, screenCallbacks, flowCallbacks, talkCallbacks)
 	if err != nil {
@@ -1,6 +1,8 @@
 package game_state
 
 import (
+	"fmt"
+
 	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
 )
 
@@ -16,6 +18,9 @@ func (g *GameState) ActionEnterLargeMap() bool {
 		g.MapState.PlayerLocation.Position)
 
 	if newLocation != references.EmptyLocation {
+		if newLocation.GetMapType() == references.DungeonMapType {
+			return g.enterDungeonFromLargeMap(newLocation)
+		}
 		slr := g.GameReferences.LocationReferences.GetLocationReference(newLocation)
 		if g.ActionEnter(slr) {
 			g.SystemCallbacks.Message.AddRowStr(slr.EnteringText)
@@ -28,6 +33,19 @@ func (g *GameState) ActionEnterLargeMap() bool {
 	return false
 }
 
+func (g *GameState) enterDungeonFromLargeMap(location references.Location) bool {
+	if !g.OpenDungeons.IsOpen(location) {
+		g.SystemCallbacks.Message.AddRowStr("Sealed!")
+		return false
+	}
+	if !g.EnterDungeon(location) {
+		return false
+	}
+	g.SystemCallbacks.Message.AddRowStr(fmt.Sprintf("Enter Dungeon\n\n%s", location.String()))
+	g.SystemCallbacks.Flow.AdvanceTime(1)
+	return true
+}
+
 // ActionEnter handles entering buildings/locations - non-directional action
 func (g *GameState) ActionEnter(slr *references.SmallLocationReference) bool {
 	if slr.Location == references.EmptyLocation {
@@ -42,6 +60,8 @@ func (g *GameState) ActionEnter(slr *references.SmallLocationReference) bool {
 	}
 	g.MapState.PlayerLocation.Location = slr.Location
 	g.MapState.PlayerLocation.Floor = smallMapStartingPositionFloor
+	g.townGates = TownGates{}
+	g.guardAlarm = GuardAlarm{}
 	g.UpdateSmallMap(g.GameReferences.TileReferences, g.GameReferences.LocationReferences)
 	return true
 }
@@ -5,7 +5,8 @@ import (
 	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
 )
 
-func (g *GameState) DebugQuickExitSmallMap() {
+// ExitSmallMap puts the party back on the large map where they entered the towne
+func (g *GameState) ExitSmallMap() {
 	g.MapState.PlayerLocation.Location = references.Britannia_Underworld
 	g.MapState.PlayerLocation.Floor = g.LastLargeMapFloor
 	g.MapState.PlayerLocation.Position = g.LastLargeMapPosition
@@ -13,6 +14,14 @@ func (g *GameState) DebugQuickExitSmallMap() {
 	g.MapState.UpdateLargeMap()
 }
 
+func (g *GameState) DebugQuickExitSmallMap() {
+	g.ExitSmallMap()
+}
+
+func (g *GameState) DebugQuickExitDungeon() {
+	g.exitDungeon()
+}
+
 // ExitVehicle - exits the vehicle the player is currently in
 // returns the previously boarded vehicle - or nil if none was found
 func (g *GameState) ExitVehicle() *map_units.NPCFriendly {
@@ -33,6 +42,8 @@ func (g *GameState) ExitVehicle() *map_units.NPCFriendly {
 
 	if exittingVehicleType == references.FrigateVehicle {
 		frigate := g.PartyVehicle
+		// a ship left behind lies at anchor with its sails furled
+		frigate.GetVehicleDetails().FurlSails()
 
 		// if we have no skiffs, then we are on the boat without a paddle
 		if !g.PartyVehicle.GetVehicleDetails().HasAtLeastOneSkiff() {
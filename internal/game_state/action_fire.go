@@ -16,15 +16,13 @@ func (g *GameState) ActionFireSmallMap(direction references.Direction) bool {
 }
 
 func (g *GameState) ActionFireLargeMap(direction references.Direction) bool {
-	// Check if on a ship for broadside firing
-	if g.PartyVehicle.GetVehicleDetails().VehicleType == references.NoPartyVehicle {
+	// only a frigate carries cannons
+	if !g.IsPartyAboardFrigate() {
 		g.SystemCallbacks.Message.AddRowStr("What?")
 		return false
 	}
 
-	// TODO: Check vehicle type and implement broadside logic
-	g.SystemCallbacks.Message.AddRowStr("Fire broadsides only!")
-	return false
+	return g.fireShipBroadside(direction)
 }
 
 func (g *GameState) ActionFireCombatMap(direction references.Direction) bool {
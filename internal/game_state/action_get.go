@@ -24,19 +24,17 @@ func (g *GameState) ActionGetSmallMap(direction references.Direction) bool {
 		return true
 	}
 
+	if effect, ok := g.getFixtureEffect(getThingTile.Index); ok && g.getFixture(effect, getThingPos, mapLayers) {
+		return true
+	}
+
 	// Handle specific tile types
 	switch getThingTile.Index {
 	case indexes.WheatInField:
 		g.SystemCallbacks.Message.AddRowStr("Crops picked! Those aren't yours Avatar!")
 		mapLayers.SetTileByLayer(map_state.MapLayer, getThingPos, indexes.PlowedField)
 		g.PartyState.Karma.DecreaseKarma(1)
-		g.SystemCallbacks.Flow.AdvanceTime(1)
-		return true
-
-	case indexes.RightSconce, indexes.LeftScone:
-		g.SystemCallbacks.Message.AddRowStr("Borrowed!")
-		g.PartyState.Inventory.Provisions.Torches.IncrementByOne()
-		mapLayers.SetTileByLayer(map_state.MapLayer, getThingPos, indexes.BrickFloor)
+		g.reportTheft()
 		g.SystemCallbacks.Flow.AdvanceTime(1)
 		return true
 
@@ -45,6 +43,7 @@ func (g *GameState) ActionGetSmallMap(direction references.Direction) bool {
 			g.SystemCallbacks.Message.AddRowStr("Mmmmm...! But that food isn't yours!")
 			g.PartyState.Inventory.Provisions.Food.IncrementByOne()
 			g.PartyState.Karma.DecreaseKarma(1)
+			g.reportTheft()
 			g.SystemCallbacks.Flow.AdvanceTime(1)
 			return true
 		}
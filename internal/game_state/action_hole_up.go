@@ -12,10 +12,14 @@ func (g *GameState) ActionHoleUpSmallMap() bool {
 }
 
 func (g *GameState) ActionHoleUpLargeMap() bool {
+	// aboard a frigate, holing up patches the hull rather than making camp
+	if g.IsPartyAboardFrigate() {
+		return g.repairPartyShip()
+	}
+
 	// TODO: Implement large map Hole Up & Camp command - see Commands.md Hole Up & Camp section
 	// Should handle:
 	// - Overworld camping (outcamp)
-	// - Ship repair when anchored/furled
 	// - Time advancement (8 hours default)
 	// - Food/water consumption
 	// - Random encounter checks
@@ -6,7 +6,10 @@ package game_state
 import (
 	"testing"
 
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
 	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
 )
 
 // TestJimmyWorkflow_CompleteFlow tests the entire jimmy command workflow
@@ -267,3 +270,38 @@ func TestJimmyWorkflow_MultipleAttempts(t *testing.T) {
 
 	t.Logf("🔄 Multiple attempts test completed")
 }
+
+// TestJimmyWorkflow_MagicallyLockedDoorStaysShut tests that neither keys nor skull keys get through a magic lock
+func TestJimmyWorkflow_MagicallyLockedDoorStaysShut(t *testing.T) {
+	gs, mockCallbacks := NewIntegrationTestBuilder(t).
+		WithLocation(references.Britain).
+		WithPlayerAt(15, 15).
+		WithSystemCallbacks().
+		Build()
+
+	if gs == nil {
+		return
+	}
+
+	gs.PartyState.Inventory.Provisions.Keys.Set(5)
+	gs.PartyState.Inventory.Provisions.SkullKeys.Set(5)
+	gs.PartyState.Characters[0].Status = party_state.Good
+
+	doorPosition := references.Up.GetNewPositionInDirection(&gs.MapState.PlayerLocation.Position)
+	smallMap := gs.MapState.LayeredMaps.GetLayeredMap(references.SmallMapType, gs.MapState.PlayerLocation.Floor)
+	smallMap.SetTileByLayer(map_state.MapOverrideLayer, doorPosition, indexes.MagicLockDoor)
+
+	if gs.ActionJimmySmallMap(references.Up) {
+		t.Errorf("Expected a magically locked door not to be jimmied")
+	}
+	mockCallbacks.AssertLastMessage("Magically Locked!")
+	if keys := gs.PartyState.Inventory.Provisions.Keys.Get(); keys != 5 {
+		t.Errorf("Expected no keys to be used, got %d left", keys)
+	}
+	if skullKeys := gs.PartyState.Inventory.Provisions.SkullKeys.Get(); skullKeys != 5 {
+		t.Errorf("Expected no skull keys to be used, got %d left", skullKeys)
+	}
+	if tile := smallMap.GetTileTopMapOnlyTile(doorPosition); tile.Index != indexes.MagicLockDoor {
+		t.Errorf("Expected the door to stay magically locked, got %d", tile.Index)
+	}
+}
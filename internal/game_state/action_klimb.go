@@ -123,9 +123,8 @@ func (g *GameState) ActionKlimbLargeMap(direction references.Direction) bool {
 }
 
 func (g *GameState) ActionKlimbDungeonMap(direction references.Direction) bool {
-	// Dungeon/Combat map Klimb - handles ladders for level transitions
-	// Uses current position tile examination like original implementation
-	return g.klimbDungeonOrCombat(direction)
+	// Dungeon Klimb - the ladder underfoot decides which way, see dungeon.go
+	return g.klimbDungeonLadder()
 }
 
 func (g *GameState) ActionKlimbCombatMap(direction references.Direction) bool {
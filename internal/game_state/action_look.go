@@ -16,6 +16,7 @@ func (g *GameState) ActionLookSmallMap(direction references.Direction) bool {
 	case indexes.Clock1, indexes.Clock2:
 		g.SystemCallbacks.Message.AppendToCurrentRowStr(g.DateTime.GetTimeAsString())
 	}
+	g.lookAtFixture(topTile.Index)
 
 	g.SystemCallbacks.Flow.AdvanceTime(1) // Looking takes time
 	return true
@@ -32,6 +33,7 @@ func (g *GameState) ActionLookLargeMap(direction references.Direction) bool {
 	case indexes.Clock1, indexes.Clock2:
 		g.SystemCallbacks.Message.AppendToCurrentRowStr(g.DateTime.GetTimeAsString())
 	}
+	g.lookAtFixture(topTile.Index)
 
 	g.SystemCallbacks.Flow.AdvanceTime(1) // Looking takes time
 	return true
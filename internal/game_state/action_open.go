@@ -99,10 +99,7 @@ func (g *GameState) ActionOpenCombatMap(direction references.Direction) bool {
 }
 
 func (g *GameState) ActionOpenDungeonMap(direction references.Direction) bool {
-	// TODO: Implement dungeon map Open command - see Commands.md Open — Dungeon section
-	// Should handle:
-	// - Dungeon doors and underfoot chests
-	// - Light requirement for seeing targets
-	// - Integration with spells (An Sanct/In Ex Por)
-	return true
+	// TODO: Integration with spells (An Sanct/In Ex Por) - see Commands.md Open — Dungeon section
+	// Chests are opened underfoot, or beside the party in a room - see dungeon_chests.go
+	return g.openDungeonChest(direction)
 }
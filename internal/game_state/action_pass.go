@@ -1,8 +1,17 @@
 package game_state
 
+import (
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
 // ActionPass handles the Pass command - player chooses to do nothing this turn
 func (g *GameState) ActionPass() bool {
 	g.SystemCallbacks.Message.AddRowStr("Pass")
+	// passing under sail on the overworld brings the ship to a stop
+	if g.IsPartyUnderSail() && g.MapState.PlayerLocation.Location.GetMapType() == references.LargeMapType {
+		g.SystemCallbacks.Message.AddRowStr("Sheets in irons!")
+		g.PartyVehicle.GetVehicleDetails().FurlSails()
+	}
 	// Pass advances time as the player is choosing to do nothing this turn
 	g.SystemCallbacks.Flow.AdvanceTime(1)
 	return true
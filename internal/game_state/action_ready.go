@@ -1,32 +1,143 @@
 package game_state
 
-func (g *GameState) ActionReadySmallMap() bool {
-	// TODO: Implement small map Ready command - see Commands.md Ready section
-	// Should handle:
-	// - Equipment slot management (2 hands, 1 armor, 1 head, 3 finger rings, 1 amulet)
-	// - Weight limit enforcement
-	// - Ammo requirement checks for ranged weapons
-	// - Negative: "No armor change!" in combat
-	// - Negative: "Missing ammo" for bows/xbows without arrows/bolts
-	// - Character selection and inventory management
-	return true
+import (
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+const ringVanishOdds = 16
+
+func (g *GameState) ActionReadySmallMap(playerIndex int, item references.Equipment) bool {
+	return g.readyEquipment(playerIndex, item, false)
 }
 
-func (g *GameState) ActionReadyLargeMap() bool {
-	// TODO: Implement large map Ready command - see Commands.md Ready section
-	// Large map variant of ready command
-	return true
+func (g *GameState) ActionReadyLargeMap(playerIndex int, item references.Equipment) bool {
+	return g.readyEquipment(playerIndex, item, false)
 }
 
-func (g *GameState) ActionReadyCombatMap() bool {
+func (g *GameState) ActionReadyCombatMap(playerIndex int, item references.Equipment) bool {
 	// Armor cannot be changed during combat, but weapons can be readied
-	// TODO: Implement weapon readying logic
-	g.SystemCallbacks.Message.AddRowStr("Ready weapon...")
+	return g.readyEquipment(playerIndex, item, true)
+}
+
+func (g *GameState) ActionReadyDungeonMap(playerIndex int, item references.Equipment) bool {
+	return g.readyEquipment(playerIndex, item, false)
+}
+
+// readyEquipment readies the item from the shared inventory onto the character, or unreadies it
+// back into the inventory if it is already readied - see Commands.md Ready section.
+// As with the original try_to_ready, it only returns true when a freshly readied ring vanishes.
+func (g *GameState) readyEquipment(playerIndex int, item references.Equipment, bInCombat bool) bool {
+	if !g.PartyState.IsCharacterInParty(playerIndex) {
+		return false
+	}
+	character := &g.PartyState.Characters[playerIndex]
+	details := item.GetDetails()
+
+	// ammunition is never readied directly
+	if details.Usage == references.AmmoUsage || details.Usage == references.NoUsage {
+		return false
+	}
+
+	if item.IsArmour() && bInCombat {
+		g.SystemCallbacks.Message.AddRowStr("Thou canst not change armour in heated battle!")
+		return false
+	}
+
+	if slot, bReadied := character.GetSlotOfEquipped(item); bReadied {
+		character.SetEquipped(slot, references.NoEquipment)
+		g.PartyState.Inventory.Equipment.IncrementByOne(item)
+		if item == references.RingInvisibility && bInCombat {
+			g.PartyState.CombatEffects[playerIndex].Invisible = false
+		}
+		g.SystemCallbacks.Screen.MarkStatsChanged()
+		return false
+	}
+
+	// the Ready list only offers owned arms
+	if !g.PartyState.Inventory.Equipment.HasSome(item) {
+		return false
+	}
+
+	if item.RequiresAmmo() && !g.PartyState.Inventory.Equipment.HasSome(details.Ammo) {
+		g.SystemCallbacks.Message.AddRowStr("Thou hast no ammunition for that weapon!")
+		return false
+	}
+
+	if character.GetTotalRequiredStrength()+details.RequiredStrength > int(character.Strength) {
+		g.SystemCallbacks.Message.AddRowStr("Thou art not strong enough!")
+		return false
+	}
+
+	slot, bSlotFree := g.getFreeSlotForEquipment(character, details.Usage)
+	if !bSlotFree {
+		return false
+	}
+
+	character.SetEquipped(slot, item)
+	g.PartyState.Inventory.Equipment.DecrementByOne(item)
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+
+	if (item == references.RingInvisibility || item == references.RingRegeneration) && g.OneInXOdds(ringVanishOdds) {
+		g.SystemCallbacks.Message.AddRowStr("Ring vanishes!")
+		character.SetEquipped(slot, references.NoEquipment)
+		g.SystemCallbacks.Flow.DelayFx()
+		return true
+	}
+
+	if item == references.RingInvisibility && bInCombat {
+		g.PartyState.CombatEffects[playerIndex].Invisible = true
+	}
 	return false
 }
 
-func (g *GameState) ActionReadyDungeonMap() bool {
-	// TODO: Implement dungeon map Ready command - see Commands.md Ready section
-	// Dungeon map variant of ready command
-	return true
+// getFreeSlotForEquipment finds the slot the usage goes in, outputting the original message if it is occupied
+func (g *GameState) getFreeSlotForEquipment(character *party_state.PlayerCharacter, usage references.EquipmentUsage) (party_state.EquipmentSlot, bool) {
+	isFree := func(slot party_state.EquipmentSlot) bool {
+		return character.GetEquipped(slot) == references.NoEquipment
+	}
+
+	switch usage {
+	case references.HeadUsage:
+		if !isFree(party_state.HelmetSlot) {
+			g.SystemCallbacks.Message.AddRowStr("Remove first thy present helm!")
+			return 0, false
+		}
+		return party_state.HelmetSlot, true
+	case references.BodyUsage:
+		if !isFree(party_state.ArmourSlot) {
+			g.SystemCallbacks.Message.AddRowStr("Thou must first remove thine other armour!")
+			return 0, false
+		}
+		return party_state.ArmourSlot, true
+	case references.OneHandUsage:
+		if character.GetFreeHands() == 0 {
+			g.SystemCallbacks.Message.AddRowStr("Thou must free one of thy hands first!")
+			return 0, false
+		}
+		if isFree(party_state.LeftHandSlot) {
+			return party_state.LeftHandSlot, true
+		}
+		return party_state.RightHandSlot, true
+	case references.TwoHandUsage:
+		if character.GetFreeHands() != 2 {
+			g.SystemCallbacks.Message.AddRowStr("Both hands must be free before thou canst wield that!")
+			return 0, false
+		}
+		return party_state.LeftHandSlot, true
+	case references.NeckUsage:
+		if !isFree(party_state.AmuletSlot) {
+			g.SystemCallbacks.Message.AddRowStr("Thou must remove thine other amulet!")
+			return 0, false
+		}
+		return party_state.AmuletSlot, true
+	case references.RingUsage:
+		if !isFree(party_state.RingSlot) {
+			g.SystemCallbacks.Message.AddRowStr("Only one magic ring may be worn at a time!")
+			return 0, false
+		}
+		return party_state.RingSlot, true
+	default:
+		return 0, false
+	}
 }
@@ -0,0 +1,171 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+func newReadyTestGameState(t *testing.T) (*GameState, *MockSystemCallbacks) {
+	gs, mockCallbacks := newUnitTestGameState(t)
+	character := addUnitTestPartyMember(gs, 0, "Ava")
+	character.Strength = 30
+	for _, slot := range party_state.AllEquipmentSlots {
+		character.SetEquipped(slot, references.NoEquipment)
+	}
+	return gs, mockCallbacks
+}
+
+func TestReady_MovesItemBetweenInventoryAndCharacter(t *testing.T) {
+	gs, _ := newReadyTestGameState(t)
+	gs.PartyState.Inventory.Equipment.Set(references.LongSword, 1)
+	character := &gs.PartyState.Characters[0]
+	unarmedAttack := character.GetAttackValue()
+
+	gs.ActionReadySmallMap(0, references.LongSword)
+	if character.GetEquipped(party_state.LeftHandSlot) != references.LongSword {
+		t.Errorf("Expected long sword in hand, got %d", character.GetEquipped(party_state.LeftHandSlot))
+	}
+	if gs.PartyState.Inventory.Equipment.HasSome(references.LongSword) {
+		t.Errorf("Expected long sword to leave the inventory")
+	}
+	if character.GetAttackValue() <= unarmedAttack {
+		t.Errorf("Expected attack to increase from %d, got %d", unarmedAttack, character.GetAttackValue())
+	}
+
+	// readying again unreadies
+	gs.ActionReadySmallMap(0, references.LongSword)
+	if character.GetEquipped(party_state.LeftHandSlot) != references.NoEquipment {
+		t.Errorf("Expected empty hand")
+	}
+	if gs.PartyState.Inventory.Equipment.Get(references.LongSword) != 1 {
+		t.Errorf("Expected long sword back in the inventory")
+	}
+}
+
+func TestReady_DefenceIsDerivedFromArmour(t *testing.T) {
+	gs, _ := newReadyTestGameState(t)
+	gs.PartyState.Inventory.Equipment.Set(references.ChainMail, 1)
+	gs.PartyState.Inventory.Equipment.Set(references.IronHelm, 1)
+
+	gs.ActionReadyLargeMap(0, references.ChainMail)
+	gs.ActionReadyLargeMap(0, references.IronHelm)
+
+	expected := references.ChainMail.GetDetails().DefenceValue + references.IronHelm.GetDetails().DefenceValue
+	if gs.PartyState.Characters[0].GetDefenceValue() != expected {
+		t.Errorf("Expected defence %d, got %d", expected, gs.PartyState.Characters[0].GetDefenceValue())
+	}
+}
+
+func TestReady_TwoHandedWeapons(t *testing.T) {
+	gs, mockCallbacks := newReadyTestGameState(t)
+	gs.PartyState.Inventory.Equipment.Set(references.SmallShield, 1)
+	gs.PartyState.Inventory.Equipment.Set(references.TwoHSword, 1)
+	gs.PartyState.Inventory.Equipment.Set(references.Dagger, 1)
+
+	character := &gs.PartyState.Characters[0]
+
+	gs.ActionReadySmallMap(0, references.SmallShield)
+	gs.ActionReadySmallMap(0, references.TwoHSword)
+	if _, bReadied := character.GetSlotOfEquipped(references.TwoHSword); bReadied {
+		t.Errorf("Expected two handed sword to need both hands")
+	}
+	mockCallbacks.AssertLastMessage("Both hands must be free before thou canst wield that!")
+
+	gs.ActionReadySmallMap(0, references.SmallShield)
+	gs.ActionReadySmallMap(0, references.TwoHSword)
+	if _, bReadied := character.GetSlotOfEquipped(references.TwoHSword); !bReadied {
+		t.Fatalf("Expected two handed sword with free hands")
+	}
+	gs.ActionReadySmallMap(0, references.Dagger)
+	if _, bReadied := character.GetSlotOfEquipped(references.Dagger); bReadied {
+		t.Errorf("Expected no free hand for a dagger")
+	}
+	mockCallbacks.AssertLastMessage("Thou must free one of thy hands first!")
+}
+
+func TestReady_RangedWeaponsNeedAmmo(t *testing.T) {
+	gs, mockCallbacks := newReadyTestGameState(t)
+	gs.PartyState.Inventory.Equipment.Set(references.Crossbow, 1)
+
+	character := &gs.PartyState.Characters[0]
+
+	gs.ActionReadySmallMap(0, references.Crossbow)
+	if _, bReadied := character.GetSlotOfEquipped(references.Crossbow); bReadied {
+		t.Errorf("Expected crossbow to need quarrels")
+	}
+	mockCallbacks.AssertLastMessage("Thou hast no ammunition for that weapon!")
+
+	gs.PartyState.Inventory.Equipment.Set(references.Quarrels, 10)
+	gs.ActionReadySmallMap(0, references.Crossbow)
+	if _, bReadied := character.GetSlotOfEquipped(references.Crossbow); !bReadied {
+		t.Errorf("Expected crossbow with quarrels to be readied")
+	}
+}
+
+func TestReady_NoArmourChangeInCombat(t *testing.T) {
+	gs, mockCallbacks := newReadyTestGameState(t)
+	gs.PartyState.Inventory.Equipment.Set(references.LeatherArmour, 1)
+	gs.PartyState.Inventory.Equipment.Set(references.Mace, 1)
+
+	character := &gs.PartyState.Characters[0]
+
+	gs.ActionReadyCombatMap(0, references.LeatherArmour)
+	if _, bReadied := character.GetSlotOfEquipped(references.LeatherArmour); bReadied {
+		t.Errorf("Expected armour change to be refused in combat")
+	}
+	mockCallbacks.AssertLastMessage("Thou canst not change armour in heated battle!")
+
+	gs.ActionReadyCombatMap(0, references.Mace)
+	if _, bReadied := character.GetSlotOfEquipped(references.Mace); !bReadied {
+		t.Errorf("Expected weapons to be readied in combat")
+	}
+}
+
+func TestReady_StrengthAndSlotLimits(t *testing.T) {
+	gs, mockCallbacks := newReadyTestGameState(t)
+	gs.PartyState.Characters[0].Strength = 10
+	gs.PartyState.Inventory.Equipment.Set(references.PlateMail, 1)
+	gs.PartyState.Inventory.Equipment.Set(references.RingProtection, 1)
+	gs.PartyState.Inventory.Equipment.Set(references.RingInvisibility, 1)
+
+	character := &gs.PartyState.Characters[0]
+
+	gs.ActionReadySmallMap(0, references.PlateMail)
+	if _, bReadied := character.GetSlotOfEquipped(references.PlateMail); bReadied {
+		t.Errorf("Expected plate mail to be too heavy")
+	}
+	mockCallbacks.AssertLastMessage("Thou art not strong enough!")
+
+	gs.ActionReadySmallMap(0, references.RingProtection)
+	gs.ActionReadySmallMap(0, references.RingInvisibility)
+	if character.GetEquipped(party_state.RingSlot) != references.RingProtection {
+		t.Errorf("Expected only one ring at a time")
+	}
+	mockCallbacks.AssertLastMessage("Only one magic ring may be worn at a time!")
+}
+
+func TestReady_OnlyAVanishingRingReturnsTrue(t *testing.T) {
+	gs, mockCallbacks := newReadyTestGameState(t)
+	gs.PartyState.Inventory.Equipment.Set(references.RingRegeneration, 1)
+	character := &gs.PartyState.Characters[0]
+
+	// keep readying and unreadying until the 1 in 16 chance makes the ring vanish
+	for i := 0; i < 1000 && gs.PartyState.Inventory.Equipment.HasSome(references.RingRegeneration); i++ {
+		if gs.ActionReadySmallMap(0, references.RingRegeneration) {
+			mockCallbacks.AssertLastMessage("Ring vanishes!")
+			if character.GetEquipped(party_state.RingSlot) != references.NoEquipment {
+				t.Fatalf("Expected the vanished ring to leave the ring slot")
+			}
+			return
+		}
+		if character.GetEquipped(party_state.RingSlot) != references.RingRegeneration {
+			t.Fatalf("Expected the ring to be worn")
+		}
+		if gs.ActionReadySmallMap(0, references.RingRegeneration) {
+			t.Fatalf("Expected unreadying to return false")
+		}
+	}
+	t.Fatalf("Expected the ring to vanish eventually")
+}
@@ -13,6 +13,11 @@ func (g *GameState) ActionSearchSmallMap(direction references.Direction) bool {
 	// - Hidden objects (daily skull keys, castle keys, glass swords)
 	// - Integration with Secrets.md for location-specific spawns
 
+	searchPosition := direction.GetNewPositionInDirection(&g.MapState.PlayerLocation.Position)
+	if tile := g.GetLayeredMapByCurrentLocation().GetTileTopMapOnlyTile(searchPosition); tile != nil && g.searchFixture(tile.Index) {
+		return true
+	}
+
 	// For now, just return not found since search systems aren't implemented
 	g.SystemCallbacks.Message.AddRowStr("Not found!")
 	g.SystemCallbacks.Flow.AdvanceTime(1)
@@ -23,6 +28,11 @@ func (g *GameState) ActionSearchLargeMap(direction references.Direction) bool {
 	// TODO: Implement large map Search command - see Commands.md Search section
 	// Large map variant of search command
 
+	searchPosition := direction.GetNewPositionInDirection(&g.MapState.PlayerLocation.Position)
+	if tile := g.GetLayeredMapByCurrentLocation().GetTileTopMapOnlyTile(searchPosition); tile != nil && g.searchFixture(tile.Index) {
+		return true
+	}
+
 	// For now, just return not found since search systems aren't implemented
 	g.SystemCallbacks.Message.AddRowStr("Not found!")
 	g.SystemCallbacks.Flow.AdvanceTime(1)
@@ -39,22 +49,6 @@ func (g *GameState) ActionSearchCombatMap(direction references.Direction) bool {
 }
 
 func (g *GameState) ActionSearchDungeonMap(direction references.Direction) bool {
-	// TODO: Implement dungeon map Search command - see Commands.md Search — Dungeon section
-	// Should handle:
-	// - Ahead-of-avatar search for secret doors/passages
-	// - Light requirement for searching
-	// - Secret door discovery mechanics
-	// - Hidden passage detection
-	// - Time cost for searching
-
-	// Check if there's light (torch or magic light)
-	if !g.MapState.Lighting.HasTorchLit() {
-		g.SystemCallbacks.Message.AddRowStr("It's too dark!")
-		return false
-	}
-
-	// For now, just return not found since search systems aren't implemented
-	g.SystemCallbacks.Message.AddRowStr("Not found!")
-	g.SystemCallbacks.Flow.AdvanceTime(1)
-	return false
+	// Dungeons are searched ahead of the party - see dungeon_fixtures.go
+	return g.searchDungeonAhead()
 }
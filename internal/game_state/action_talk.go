@@ -10,6 +10,10 @@ func (g *GameState) ActionTalkSmallMap(direction references.Direction) bool {
 	npc := g.CurrentNPCAIController.GetNpcs().GetMapUnitAtPositionOrNil(*talkThingPos)
 
 	if npc == nil {
+		// shopkeepers are talked to across their counter
+		if g.talkAcrossCounter(direction) {
+			return true
+		}
 		g.SystemCallbacks.Message.AddRowStr("No-one to talk to!")
 		return false
 	}
@@ -17,6 +21,11 @@ func (g *GameState) ActionTalkSmallMap(direction references.Direction) bool {
 	if friendly, ok := (*npc).(*map_units.NPCFriendly); ok {
 		// TODO: Handle freed NPC acknowledgement (stocks/manacles with karma +2) here
 
+		// the guards are in no mood to chat - talking to one gives the party up
+		if g.IsGuardAlarmActive() && friendly.NPCReference.GetNPCType() == references.Guard {
+			return g.SurrenderToGuards()
+		}
+
 		// Create and push dialog using dependency injection
 		dialog := g.SystemCallbacks.Talk.CreateTalkDialog(friendly)
 		if dialog != nil {
@@ -7,12 +7,12 @@ import (
 func (g *GameState) ActionUseSmallMap(direction references.Direction) bool {
 	// TODO: Implement small map Use command - see Commands.md Use section
 	// Should handle:
-	// - Magic Carpet usage (boarding conditions, consumption)
-	// - Skull Key usage (directional In Ex Por semantics)
-	// - Amulet/Crown equipping (persistent effects)
-	// - Sceptre usage (clear chests/dispel fields)
-	// - Spyglass usage (astronomy at night)
 	// - Item validation and context gating
+	// (carpet, skull keys and spyglass are handled in action_use_special_item.go)
+
+	if g.useFixtureInDirection(direction) {
+		return true
+	}
 
 	// Special items not implemented yet
 	g.SystemCallbacks.Message.AddRowStr("Nothing happens.")
@@ -24,6 +24,10 @@ func (g *GameState) ActionUseLargeMap(direction references.Direction) bool {
 	// TODO: Implement large map Use command - see Commands.md Use section
 	// Large map variant of use command
 
+	if g.useFixtureInDirection(direction) {
+		return true
+	}
+
 	// Special items not implemented yet
 	g.SystemCallbacks.Message.AddRowStr("Nothing happens.")
 	g.SystemCallbacks.Flow.AdvanceTime(1)
@@ -42,7 +46,11 @@ func (g *GameState) ActionUseCombatMap(direction references.Direction) bool {
 func (g *GameState) ActionUseDungeonMap(direction references.Direction) bool {
 	// TODO: Implement dungeon map Use command - see Commands.md Use section
 	// Dungeon map variant of use command
-	// Should handle special dungeon items and fixtures
+	// Should handle special dungeon items
+
+	if g.useDungeonFixture() {
+		return true
+	}
 
 	// Special items not implemented yet
 	g.SystemCallbacks.Message.AddRowStr("Nothing happens.")
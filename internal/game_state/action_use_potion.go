@@ -0,0 +1,111 @@
+package game_state
+
+import (
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+const (
+	potionOddsOfForcedSleep  = 0 // roll of 0 on d16 - potion acts as Orange
+	potionOddsOfRandomColour = 1 // roll of 1 on d16 - potion acts as a random colour
+	potionVarianceDieSides   = 16
+	yellowPotionMinHeal      = 1
+	yellowPotionMaxHeal      = 30
+)
+
+// ActionUsePotion drinks a potion on behalf of the given party member - see Potions.md
+// The potion is always consumed, even if it ends up having no effect.
+func (g *GameState) ActionUsePotion(potion references.Potion, playerIndex int) bool {
+	if !g.PartyState.Inventory.Potions.HasSome(potion) {
+		g.SystemCallbacks.Message.AddRowStr("None owned!")
+		return false
+	}
+
+	if !g.PartyState.IsCharacterInParty(playerIndex) {
+		return false
+	}
+
+	g.PartyState.Inventory.Potions.DecrementByOne(potion)
+	g.SystemCallbacks.Message.AddRowStr("Potion")
+
+	bSuccess := g.applyPotionEffect(g.rollPotionEffect(potion), playerIndex)
+	g.SystemCallbacks.Flow.AdvanceTime(1)
+	return bSuccess
+}
+
+// rollPotionEffect applies the original 1/16 chance of Sleep and 1/16 chance of a random colour
+func (g *GameState) rollPotionEffect(potion references.Potion) references.Potion {
+	switch g.RandomIntInRange(0, potionVarianceDieSides-1) {
+	case potionOddsOfForcedSleep:
+		return references.Orange
+	case potionOddsOfRandomColour:
+		return references.Potion(g.RandomIntInRange(int(references.Blue), int(references.White)))
+	default:
+		return potion
+	}
+}
+
+func (g *GameState) applyPotionEffect(effect references.Potion, playerIndex int) bool {
+	character := &g.PartyState.Characters[playerIndex]
+	mapType := g.MapState.PlayerLocation.Location.GetMapType()
+	bInCombat := mapType == references.CombatMapType
+
+	switch effect {
+	case references.Blue:
+		if character.Status != party_state.Sleep {
+			g.SystemCallbacks.Message.AddRowStr("No effect!")
+			return false
+		}
+		character.Status = party_state.Good
+		g.SystemCallbacks.Message.AddRowStr("Awake!")
+	case references.Yellow:
+		if !character.Heal(uint16(g.RandomIntInRange(yellowPotionMinHeal, yellowPotionMaxHeal))) {
+			return false
+		}
+		g.SystemCallbacks.Message.AddRowStr("Healed!")
+	case references.Red:
+		if character.Status != party_state.Poisoned {
+			return false
+		}
+		character.Status = party_state.Good
+		g.SystemCallbacks.Message.AddRowStr("Poison cured!")
+	case references.Green:
+		if character.Status != party_state.Good {
+			return false
+		}
+		character.Status = party_state.Poisoned
+		g.SystemCallbacks.Message.AddRowStr("POISONED!")
+	case references.Orange:
+		if character.Status != party_state.Good {
+			return false
+		}
+		character.Status = party_state.Sleep
+		g.SystemCallbacks.Message.AddRowStr("Slept!")
+	case references.Purple:
+		if !bInCombat {
+			g.SystemCallbacks.Message.AddRowStr("No noticeable effect now!")
+			return true
+		}
+		g.PartyState.CombatEffects[playerIndex].PolymorphedToRat = true
+		g.SystemCallbacks.Message.AddRowStr("Poof!")
+	case references.Black:
+		if !bInCombat {
+			g.SystemCallbacks.Message.AddRowStr("No noticeable effect now!")
+			return true
+		}
+		g.PartyState.CombatEffects[playerIndex].Invisible = true
+		g.SystemCallbacks.Message.AddRowStr("Invisible!")
+	case references.White:
+		if mapType == references.DungeonMapType {
+			g.SystemCallbacks.Message.AddRowStr("No noticeable effect now!")
+			return true
+		}
+		g.MapState.Lighting.StartXRayVision()
+		g.SystemCallbacks.Message.AddRowStr("X-ray!")
+	default:
+		return false
+	}
+
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	return true
+}
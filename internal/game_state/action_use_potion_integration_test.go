@@ -0,0 +1,36 @@
+// Integration tests for drinking potions with real game data
+// These validate that a potion's effect outlasts the end of the turn it is drunk on.
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// TestUsePotion_WhiteXRayOutlastsTheTurnItIsDrunk tests that the White potion still shows through walls
+// once the turn it was drunk on is finished
+func TestUsePotion_WhiteXRayOutlastsTheTurnItIsDrunk(t *testing.T) {
+	gs, _ := NewIntegrationTestBuilder(t).
+		WithLocation(references.Britain).
+		WithPlayerAt(15, 15).
+		WithSystemCallbacks().
+		Build()
+
+	if gs == nil {
+		return
+	}
+
+	gs.PartyState.Characters[0].Status = party_state.Good
+	gs.applyPotionEffect(references.White, 0)
+	gs.FinishTurn()
+	if !gs.MapState.Lighting.HasXRayVision() {
+		t.Fatalf("Expected X-ray vision for the turn after drinking")
+	}
+
+	gs.FinishTurn()
+	if gs.MapState.Lighting.HasXRayVision() {
+		t.Errorf("Expected X-ray vision to be a flash of time")
+	}
+}
@@ -0,0 +1,170 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+func newPotionTestGameState(t *testing.T) (*GameState, *MockSystemCallbacks) {
+	gs, mockCallbacks := newUnitTestGameState(t)
+	character := addUnitTestPartyMember(gs, 0, "Ava")
+	character.CurrentHp = 10
+	character.MaxHp = 100
+	return gs, mockCallbacks
+}
+
+func TestApplyPotionEffect_StatusPotions(t *testing.T) {
+	tests := []struct {
+		name           string
+		potion         references.Potion
+		startStatus    party_state.CharacterStatus
+		expectedStatus party_state.CharacterStatus
+		expectedResult bool
+		expectedMsg    string
+	}{
+		{"Blue wakes sleeper", references.Blue, party_state.Sleep, party_state.Good, true, "Awake!"},
+		{"Blue on awake character", references.Blue, party_state.Good, party_state.Good, false, "No effect!"},
+		{"Red cures poison", references.Red, party_state.Poisoned, party_state.Good, true, "Poison cured!"},
+		{"Red on healthy character", references.Red, party_state.Good, party_state.Good, false, ""},
+		{"Green poisons", references.Green, party_state.Good, party_state.Poisoned, true, "POISONED!"},
+		{"Green on sleeping character", references.Green, party_state.Sleep, party_state.Sleep, false, ""},
+		{"Orange sleeps", references.Orange, party_state.Good, party_state.Sleep, true, "Slept!"},
+		{"Orange on poisoned character", references.Orange, party_state.Poisoned, party_state.Poisoned, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs, mockCallbacks := newPotionTestGameState(t)
+			gs.PartyState.Characters[0].Status = tt.startStatus
+
+			result := gs.applyPotionEffect(tt.potion, 0)
+
+			if result != tt.expectedResult {
+				t.Errorf("Expected result %v, got %v", tt.expectedResult, result)
+			}
+			if gs.PartyState.Characters[0].Status != tt.expectedStatus {
+				t.Errorf("Expected status %c, got %c", tt.expectedStatus, gs.PartyState.Characters[0].Status)
+			}
+			if tt.expectedMsg == "" {
+				mockCallbacks.AssertNoMessages()
+			} else {
+				mockCallbacks.AssertLastMessage(tt.expectedMsg)
+			}
+		})
+	}
+}
+
+func TestApplyPotionEffect_YellowHealsUpToMax(t *testing.T) {
+	gs, mockCallbacks := newPotionTestGameState(t)
+	gs.PartyState.Characters[0].CurrentHp = 95
+
+	if !gs.applyPotionEffect(references.Yellow, 0) {
+		t.Fatalf("Expected yellow potion to heal")
+	}
+	mockCallbacks.AssertLastMessage("Healed!")
+	hp := gs.PartyState.Characters[0].CurrentHp
+	if hp <= 95 || hp > 100 {
+		t.Errorf("Expected HP in (95, 100], got %d", hp)
+	}
+
+	gs.PartyState.Characters[0].CurrentHp = 100
+	if gs.applyPotionEffect(references.Yellow, 0) {
+		t.Errorf("Expected yellow potion to fail at full health")
+	}
+}
+
+func TestApplyPotionEffect_CombatOnlyPotionsOutsideCombat(t *testing.T) {
+	for _, potion := range []references.Potion{references.Purple, references.Black} {
+		gs, mockCallbacks := newPotionTestGameState(t)
+		gs.MapState.PlayerLocation.Location = references.Britannia_Underworld
+
+		gs.applyPotionEffect(potion, 0)
+
+		mockCallbacks.AssertLastMessage("No noticeable effect now!")
+		if gs.PartyState.CombatEffects[0] != (party_state.CombatEffects{}) {
+			t.Errorf("Expected no combat effects outside of combat for potion %d", potion)
+		}
+	}
+}
+
+func TestApplyPotionEffect_CombatOnlyPotionsInCombat(t *testing.T) {
+	gs, mockCallbacks := newPotionTestGameState(t)
+	gs.MapState.PlayerLocation.Location = references.Combat_resting_shrine
+
+	gs.applyPotionEffect(references.Purple, 0)
+	mockCallbacks.AssertLastMessage("Poof!")
+	gs.applyPotionEffect(references.Black, 0)
+	mockCallbacks.AssertLastMessage("Invisible!")
+
+	if !gs.PartyState.CombatEffects[0].PolymorphedToRat || !gs.PartyState.CombatEffects[0].Invisible {
+		t.Errorf("Expected polymorph and invisibility, got %+v", gs.PartyState.CombatEffects[0])
+	}
+}
+
+func TestApplyPotionEffect_WhiteXRay(t *testing.T) {
+	gs, mockCallbacks := newPotionTestGameState(t)
+	gs.MapState.PlayerLocation.Location = references.Britain
+
+	gs.applyPotionEffect(references.White, 0)
+	if !gs.MapState.Lighting.HasXRayVision() {
+		t.Errorf("Expected X-ray vision on the surface")
+	}
+	// the end of the turn it was drunk on doesn't take it away
+	gs.MapState.Lighting.AdvanceTurn()
+	if !gs.MapState.Lighting.HasXRayVision() {
+		t.Errorf("Expected X-ray vision to last through the turn after drinking")
+	}
+
+	gs, mockCallbacks = newPotionTestGameState(t)
+	gs.MapState.PlayerLocation.Location = references.Deceit
+	gs.applyPotionEffect(references.White, 0)
+	mockCallbacks.AssertLastMessage("No noticeable effect now!")
+	if gs.MapState.Lighting.HasXRayVision() {
+		t.Errorf("Expected no X-ray vision in a dungeon")
+	}
+}
+
+func TestActionUsePotion_ConsumesPotion(t *testing.T) {
+	gs, mockCallbacks := newPotionTestGameState(t)
+	gs.PartyState.Inventory.Potions.Set(references.Blue, 2)
+
+	gs.ActionUsePotion(references.Blue, 0)
+
+	if gs.PartyState.Inventory.Potions.Get(references.Blue) != 1 {
+		t.Errorf("Expected one blue potion left, got %d", gs.PartyState.Inventory.Potions.Get(references.Blue))
+	}
+	mockCallbacks.AssertMessageContains("Potion")
+	mockCallbacks.AssertTimeAdvanced(1)
+}
+
+func TestActionUsePotion_NoneOwned(t *testing.T) {
+	gs, mockCallbacks := newPotionTestGameState(t)
+
+	if gs.ActionUsePotion(references.Red, 0) {
+		t.Errorf("Expected failure without potions")
+	}
+	mockCallbacks.AssertLastMessage("None owned!")
+}
+
+func TestRollPotionEffect_VarianceIsRare(t *testing.T) {
+	gs, _ := newPotionTestGameState(t)
+	const nRolls = 1600
+
+	nUnchanged := 0
+	for i := 0; i < nRolls; i++ {
+		effect := gs.rollPotionEffect(references.Red)
+		if effect < references.Blue || effect > references.White {
+			t.Fatalf("Rolled invalid potion effect %d", effect)
+		}
+		if effect == references.Red {
+			nUnchanged++
+		}
+	}
+
+	// expect ~14/16 unchanged plus a few random rolls landing on Red
+	if nUnchanged < nRolls*12/16 {
+		t.Errorf("Expected most potions to keep their colour, got %d of %d", nUnchanged, nRolls)
+	}
+}
@@ -0,0 +1,104 @@
+package game_state
+
+import (
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+// ActionUseQuestItem uses one of the artifacts of Lord British - see Commands.md Use section.
+// The Amulet and Crown are put on, leaving the inventory for a permanent effect, while the
+// Sceptre is wielded and kept.
+func (g *GameState) ActionUseQuestItem(item references.QuestItem) bool {
+	if !g.PartyState.Inventory.QuestItems.HasSome(item) {
+		g.SystemCallbacks.Message.AddRowStr("None owned!")
+		return false
+	}
+
+	var bSuccess bool
+	switch item {
+	case references.Amulet:
+		g.SystemCallbacks.Message.AddRowStr("Amulet")
+		bSuccess = g.wearLordBritishArtifact(item, DurationLBAmulet, "Wearing the Amulet of LB")
+	case references.Crown:
+		g.SystemCallbacks.Message.AddRowStr("Crown")
+		bSuccess = g.wearLordBritishArtifact(item, DurationCrown, "Thou dost don the Crown of LB")
+	case references.Sceptre:
+		g.SystemCallbacks.Message.AddRowStr("Sceptre")
+		bSuccess = g.wieldSceptre()
+	default:
+		g.SystemCallbacks.Message.AddRowStr("What?")
+		return false
+	}
+
+	g.SystemCallbacks.Flow.AdvanceTime(1)
+	return bSuccess
+}
+
+// IsWearingCrown returns true while the Crown of Lord British is worn
+func (g *GameState) IsWearingCrown() bool {
+	return g.IsDurationSpellActive(DurationCrown)
+}
+
+func (g *GameState) wearLordBritishArtifact(item references.QuestItem, spell DurationSpell, message string) bool {
+	g.PartyState.Inventory.QuestItems.DecrementByOne(item)
+	g.SystemCallbacks.Message.AddRowStr(message)
+	g.startDurationSpell(spell, permanentSpellTurns)
+	g.SystemCallbacks.Audio.PlaySoundEffect(SoundSpellCast)
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	return true
+}
+
+// wieldSceptre first breaks the Shadowlords' hold on any neighbouring chests on the surface,
+// otherwise it dispels a neighbouring energy field as An Grav would. Its power only reaches
+// the surface and the dungeons; elsewhere nothing more comes of it.
+func (g *GameState) wieldSceptre() bool {
+	g.SystemCallbacks.Message.AddRowStr("Wielding the Sceptre of LB")
+	g.SystemCallbacks.Audio.PlaySoundEffect(SoundSpellCast)
+
+	mapType := g.MapState.PlayerLocation.Location.GetMapType()
+	if mapType != references.LargeMapType && mapType != references.DungeonMapType {
+		return false
+	}
+
+	if g.clearAdjacentShadowlordChests() {
+		return true
+	}
+
+	for _, pos := range g.getAdjacentPositions() {
+		if g.GetLayeredMapByCurrentLocation().GetTileTopMapOnlyTile(&pos).Index.IsField() {
+			return g.castAnGrav(&pos)
+		}
+	}
+
+	g.SystemCallbacks.Message.AddRowStr("No effect!")
+	return false
+}
+
+// clearAdjacentShadowlordChests dissolves the protected chests next to the party on the overworld
+func (g *GameState) clearAdjacentShadowlordChests() bool {
+	if g.MapState.PlayerLocation.Location.GetMapType() != references.LargeMapType {
+		return false
+	}
+
+	layeredMap := g.GetLayeredMapByCurrentLocation()
+	bCleared := false
+	for _, pos := range g.getAdjacentPositions() {
+		if layeredMap.GetTileTopMapOnlyTile(&pos).Index != indexes.Chest {
+			continue
+		}
+		layeredMap.SetTileByLayer(map_state.MapOverrideLayer, &pos, indexes.Grass)
+		bCleared = true
+	}
+	return bCleared
+}
+
+func (g *GameState) getAdjacentPositions() []references.Position {
+	positions := g.MapState.PlayerLocation.Position.Neighbors()
+	if g.MapState.PlayerLocation.Location.GetMapType() == references.LargeMapType {
+		for i := range positions {
+			positions[i] = *positions[i].GetWrapped(references.XLargeMapTiles, references.YLargeMapTiles)
+		}
+	}
+	return positions
+}
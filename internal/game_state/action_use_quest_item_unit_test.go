@@ -0,0 +1,88 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+func newQuestItemTestGameState(t *testing.T) (*GameState, *MockSystemCallbacks) {
+	return newUnitTestGameState(t)
+}
+
+func TestUseQuestItem_NoneOwned(t *testing.T) {
+	gs, mockCallbacks := newQuestItemTestGameState(t)
+
+	if gs.ActionUseQuestItem(references.Crown) {
+		t.Errorf("Expected crown use to fail when not owned")
+	}
+	mockCallbacks.AssertLastMessage("None owned!")
+}
+
+func TestUseQuestItem_AmuletIsWornAndGivesLight(t *testing.T) {
+	gs, mockCallbacks := newQuestItemTestGameState(t)
+	gs.PartyState.Inventory.QuestItems.Set(references.Amulet, 1)
+
+	if !gs.ActionUseQuestItem(references.Amulet) {
+		t.Fatalf("Expected amulet to be worn")
+	}
+	mockCallbacks.AssertLastMessage("Wearing the Amulet of LB")
+
+	if gs.PartyState.Inventory.QuestItems.HasSome(references.Amulet) {
+		t.Errorf("Expected the worn amulet to leave the inventory")
+	}
+	if !gs.MapState.Lighting.HasAvatarLight() {
+		t.Errorf("Expected the worn amulet to light the avatar's surroundings")
+	}
+
+	// the effect is permanent
+	for i := 0; i < 1000; i++ {
+		gs.advanceActiveSpell()
+	}
+	if !gs.IsDurationSpellActive(DurationLBAmulet) {
+		t.Errorf("Expected the amulet to still be worn")
+	}
+}
+
+func TestUseQuestItem_CrownLiftsBlackthornsAbsorption(t *testing.T) {
+	gs, mockCallbacks := newQuestItemTestGameState(t)
+	gs.MapState.PlayerLocation.Location = references.Palace_of_Blackthorn
+	gs.PartyState.Inventory.QuestItems.Set(references.Crown, 1)
+
+	if gs.canCastSpellHere(references.InLor) {
+		t.Errorf("Expected magic to be absorbed in Blackthorn's palace without the crown")
+	}
+	mockCallbacks.AssertLastMessage("Absorbed!")
+
+	if !gs.ActionUseQuestItem(references.Crown) {
+		t.Fatalf("Expected crown to be worn")
+	}
+	mockCallbacks.AssertLastMessage("Thou dost don the Crown of LB")
+
+	if !gs.IsWearingCrown() {
+		t.Errorf("Expected the crown to be worn")
+	}
+	if !gs.canCastSpellHere(references.InLor) {
+		t.Errorf("Expected the crown to allow magic in Blackthorn's palace")
+	}
+
+	// Stonegate absorbs magic regardless
+	gs.MapState.PlayerLocation.Location = references.Stonegate
+	if gs.canCastSpellHere(references.InLor) {
+		t.Errorf("Expected magic to be absorbed in Stonegate even with the crown")
+	}
+}
+
+func TestUseQuestItem_SceptreHasNoPowerInTowne(t *testing.T) {
+	gs, mockCallbacks := newQuestItemTestGameState(t)
+	gs.PartyState.Inventory.QuestItems.Set(references.Sceptre, 1)
+	gs.MapState.PlayerLocation.Location = references.Britain
+
+	if gs.ActionUseQuestItem(references.Sceptre) {
+		t.Errorf("Expected the sceptre to do nothing in a towne")
+	}
+	mockCallbacks.AssertLastMessage("Wielding the Sceptre of LB")
+	if !gs.PartyState.Inventory.QuestItems.HasSome(references.Sceptre) {
+		t.Errorf("Expected the sceptre to be kept")
+	}
+}
@@ -0,0 +1,106 @@
+package game_state
+
+import (
+	"strings"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+const (
+	scrollLightTurns       = 240
+	scrollProtectionTurns  = 100
+	scrollNegateMagicTurns = 20
+)
+
+// scrollSpells maps each readable scroll to the spell it casts. Summon Daemon is left out until there are
+// combat map units for the daemon to join.
+var scrollSpells = map[references.Scroll]references.Spell{
+	references.ScrollVasLor:     references.VasLor,
+	references.ScrollRelHur:     references.RelHur,
+	references.ScrollInSanct:    references.InSanct,
+	references.ScrollInAn:       references.InAn,
+	references.ScrollInQuasWis:  references.InQuasWis,
+	references.ScrollInManiCorp: references.InManiCorp,
+	references.ScrollAnTym:      references.AnTym,
+}
+
+// ScrollUseInput holds the extra choices some scrolls require before they can be read
+type ScrollUseInput struct {
+	Direction   references.Direction
+	PlayerIndex int
+}
+
+// IsScrollReadable returns true if reading the scroll does something in this implementation
+func IsScrollReadable(scroll references.Scroll) bool {
+	_, ok := scrollSpells[scroll]
+	return ok
+}
+
+// ScrollNeedsDirection returns true if the UI must ask for a direction before reading the scroll
+func ScrollNeedsDirection(scroll references.Scroll) bool {
+	return scroll == references.ScrollRelHur
+}
+
+// ScrollNeedsPartyMember returns true if the UI must ask for a target party member before reading the scroll
+func ScrollNeedsPartyMember(scroll references.Scroll) bool {
+	return scroll == references.ScrollInManiCorp
+}
+
+// ActionUseScroll reads a scroll, casting its spell without reagents or MP.
+// The spell's context rules still apply, and the scroll is consumed either way.
+func (g *GameState) ActionUseScroll(scroll references.Scroll, input ScrollUseInput) bool {
+	if !g.PartyState.Inventory.Scrolls.HasSome(scroll) {
+		g.SystemCallbacks.Message.AddRowStr("None owned!")
+		return false
+	}
+
+	spell, ok := scrollSpells[scroll]
+	if !ok {
+		g.SystemCallbacks.Message.AddRowStr("No effect!")
+		return false
+	}
+	g.PartyState.Inventory.Scrolls.DecrementByOne(scroll)
+	g.SystemCallbacks.Message.AddRowStr("Scroll: " +
+		strings.ReplaceAll(g.GameReferences.InventoryItemReferences.Scroll[scroll].ItemName, "_", " "))
+
+	// the legacy Use checks Negate Time against Doom and Stonegate before any other magic rule
+	if scroll == references.ScrollAnTym && g.isTimeNegationBlockedHere() {
+		g.SystemCallbacks.Message.AddRowStr("No effect!")
+		return false
+	}
+
+	if !g.canCastSpellHere(spell) {
+		return false
+	}
+
+	bSuccess := g.castScrollSpell(scroll, input)
+	g.SystemCallbacks.Flow.AdvanceTime(1)
+	return bSuccess
+}
+
+func (g *GameState) castScrollSpell(scroll references.Scroll, input ScrollUseInput) bool {
+	switch scroll {
+	case references.ScrollVasLor:
+		return g.castLight(scrollLightTurns)
+	case references.ScrollRelHur:
+		return g.castRelHur(input.Direction)
+	case references.ScrollInSanct:
+		return g.castInSanct(scrollProtectionTurns)
+	case references.ScrollInAn:
+		return g.castInAn(scrollNegateMagicTurns)
+	case references.ScrollInQuasWis:
+		return g.castInQuasWis()
+	case references.ScrollInManiCorp:
+		return g.castInManiCorp(input.PlayerIndex)
+	case references.ScrollAnTym:
+		return g.castAnTym()
+	default:
+		return false
+	}
+}
+
+// isTimeNegationBlockedHere is true in Doom and Stonegate, where the Negate Time scroll has no power
+func (g *GameState) isTimeNegationBlockedHere() bool {
+	location := g.MapState.PlayerLocation.Location
+	return location == references.Doom || location == references.Stonegate
+}
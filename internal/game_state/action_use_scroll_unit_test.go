@@ -0,0 +1,137 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+func newScrollTestGameState(t *testing.T, location references.Location) (*GameState, *MockSystemCallbacks) {
+	gs, mockCallbacks := newUnitTestGameState(t)
+	gs.GameReferences = &references.GameReferences{InventoryItemReferences: references.NewInventoryItemsReferences()}
+	addUnitTestPartyMember(gs, 0, "Ava")
+	gs.MapState.PlayerLocation.Location = location
+	return gs, mockCallbacks
+}
+
+func TestActionUseScroll_ConsumesScroll(t *testing.T) {
+	gs, mockCallbacks := newScrollTestGameState(t, references.Britannia_Underworld)
+	gs.PartyState.Inventory.Scrolls.Set(references.ScrollVasLor, 2)
+
+	if !gs.ActionUseScroll(references.ScrollVasLor, ScrollUseInput{}) {
+		t.Fatalf("Expected light scroll to succeed")
+	}
+
+	if gs.PartyState.Inventory.Scrolls.Get(references.ScrollVasLor) != 1 {
+		t.Errorf("Expected one scroll left, got %d", gs.PartyState.Inventory.Scrolls.Get(references.ScrollVasLor))
+	}
+	if !gs.MapState.Lighting.HasMagicLight() {
+		t.Errorf("Expected magic light after reading the light scroll")
+	}
+	mockCallbacks.AssertMessageContains("Vas Lor")
+	mockCallbacks.AssertSoundEffectPlayed(SoundSpellCast)
+	mockCallbacks.AssertTimeAdvanced(1)
+}
+
+func TestActionUseScroll_NoneOwned(t *testing.T) {
+	gs, mockCallbacks := newScrollTestGameState(t, references.Britannia_Underworld)
+
+	if gs.ActionUseScroll(references.ScrollInAn, ScrollUseInput{}) {
+		t.Errorf("Expected failure without scrolls")
+	}
+	mockCallbacks.AssertLastMessage("None owned!")
+}
+
+func TestActionUseScroll_ContextRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		scroll      references.Scroll
+		location    references.Location
+		expectedOk  bool
+		expectedMsg string
+	}{
+		{"Wind change on overworld", references.ScrollRelHur, references.Britannia_Underworld, true, "Wind change!"},
+		{"Wind change in town", references.ScrollRelHur, references.Britain, false, "Not here!"},
+		{"Protection anywhere", references.ScrollInSanct, references.Deceit, true, "Protection!"},
+		{"View in combat", references.ScrollInQuasWis, references.Combat_resting_shrine, false, "Not here!"},
+		{"Resurrect in combat", references.ScrollInManiCorp, references.Combat_resting_shrine, false, "Not here!"},
+		{"Negate time in Doom", references.ScrollAnTym, references.Doom, false, "No effect!"},
+		{"Negate time in Stonegate", references.ScrollAnTym, references.Stonegate, false, "No effect!"},
+		{"Anything in Stonegate", references.ScrollInSanct, references.Stonegate, false, "Absorbed!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs, mockCallbacks := newScrollTestGameState(t, tt.location)
+			gs.PartyState.Inventory.Scrolls.Set(tt.scroll, 1)
+
+			result := gs.ActionUseScroll(tt.scroll, ScrollUseInput{Direction: references.Up})
+
+			if result != tt.expectedOk {
+				t.Errorf("Expected %v, got %v", tt.expectedOk, result)
+			}
+			mockCallbacks.AssertMessageContains(tt.expectedMsg)
+			if gs.PartyState.Inventory.Scrolls.HasSome(tt.scroll) {
+				t.Errorf("Expected scroll to be consumed")
+			}
+		})
+	}
+}
+
+func TestActionUseScroll_DurationSpells(t *testing.T) {
+	gs, _ := newScrollTestGameState(t, references.Britain)
+	gs.PartyState.Inventory.Scrolls.Set(references.ScrollAnTym, 1)
+	gs.PartyState.Inventory.Scrolls.Set(references.ScrollInAn, 1)
+
+	gs.ActionUseScroll(references.ScrollAnTym, ScrollUseInput{})
+	if !gs.IsDurationSpellActive(DurationTimeStop) {
+		t.Fatalf("Expected time stop to be active")
+	}
+
+	for i := 0; i < negateTimeTurns; i++ {
+		gs.advanceActiveSpell()
+	}
+	if gs.IsDurationSpellActive(DurationTimeStop) {
+		t.Errorf("Expected time stop to expire after %d turns", negateTimeTurns)
+	}
+
+	gs.ActionUseScroll(references.ScrollInAn, ScrollUseInput{})
+	if gs.ActiveSpell.Spell != DurationNegateMagic || gs.ActiveSpell.TurnsRemaining != scrollNegateMagicTurns {
+		t.Errorf("Expected negate magic for %d turns, got %+v", scrollNegateMagicTurns, gs.ActiveSpell)
+	}
+}
+
+func TestActionUseScroll_Resurrection(t *testing.T) {
+	gs, mockCallbacks := newScrollTestGameState(t, references.Britain)
+	gs.PartyState.Inventory.Scrolls.Set(references.ScrollInManiCorp, 2)
+
+	gs.ActionUseScroll(references.ScrollInManiCorp, ScrollUseInput{PlayerIndex: 0})
+	mockCallbacks.AssertLastMessage("No effect!")
+
+	gs.PartyState.Characters[0].Status = party_state.Dead
+	gs.PartyState.Characters[0].CurrentHp = 0
+	if !gs.ActionUseScroll(references.ScrollInManiCorp, ScrollUseInput{PlayerIndex: 0}) {
+		t.Fatalf("Expected resurrection to succeed")
+	}
+	if gs.PartyState.Characters[0].Status != party_state.Good || gs.PartyState.Characters[0].CurrentHp == 0 {
+		t.Errorf("Expected character to be alive, got status %c HP %d",
+			gs.PartyState.Characters[0].Status, gs.PartyState.Characters[0].CurrentHp)
+	}
+}
+
+func TestActionUseScroll_SummonDaemonNotYetReadable(t *testing.T) {
+	gs, mockCallbacks := newScrollTestGameState(t, references.Britain)
+	gs.PartyState.Inventory.Scrolls.Set(references.ScrollKalXenCorp, 1)
+
+	if IsScrollReadable(references.ScrollKalXenCorp) {
+		t.Fatalf("Expected Summon Daemon to wait for combat map units")
+	}
+	if gs.ActionUseScroll(references.ScrollKalXenCorp, ScrollUseInput{}) {
+		t.Errorf("Expected the scroll not to be read")
+	}
+	mockCallbacks.AssertLastMessage("No effect!")
+	if !gs.PartyState.Inventory.Scrolls.HasSome(references.ScrollKalXenCorp) {
+		t.Errorf("Expected the scroll to be kept")
+	}
+}
@@ -0,0 +1,125 @@
+package game_state
+
+import (
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_units"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+// TelescopeViewTiles is how far the telescope pans across the overworld before the view stops
+const TelescopeViewTiles = 32
+
+// ActionUseCarpet lays out a magic carpet and boards it - see Commands.md Use section.
+// The carpet becomes the party vehicle and is left behind as a regular vehicle when exited.
+func (g *GameState) ActionUseCarpet() bool {
+	if !g.PartyState.Inventory.SpecialItems.HasSome(references.Carpet) {
+		g.SystemCallbacks.Message.AddRowStr("None owned!")
+		return false
+	}
+	g.SystemCallbacks.Message.AddRowStr("Carpet")
+
+	if g.MapState.PlayerLocation.Location.GetMapType() == references.LargeMapType &&
+		g.GetLayeredMapByCurrentLocation().GetTileTopMapOnlyTile(&g.MapState.PlayerLocation.Position).IsMountain() {
+		g.SystemCallbacks.Message.AddRowStr("Not here!")
+		return false
+	}
+
+	switch g.PartyVehicle.GetVehicleDetails().VehicleType {
+	case references.NoPartyVehicle:
+	case references.FrigateVehicle:
+		g.SystemCallbacks.Message.AddRowStr("X-it ship first!")
+		return false
+	default:
+		g.SystemCallbacks.Message.AddRowStr("Only on foot!")
+		return false
+	}
+
+	carpet := map_units.NewNPCFriendlyVehiceNewRef(references.CarpetVehicle,
+		g.MapState.PlayerLocation.Position,
+		g.MapState.PlayerLocation.Floor)
+	carpet.SetPos(g.MapState.PlayerLocation.Position)
+	carpet.NPCReference.Schedule.OverrideAllPositions(
+		byte(g.MapState.PlayerLocation.Position.X), byte(g.MapState.PlayerLocation.Position.Y))
+
+	// the carpet is laid out facing a random direction
+	if g.OneInXOdds(2) {
+		carpet.GetVehicleDetails().SetPartyVehicleDirection(references.Left)
+	} else {
+		carpet.GetVehicleDetails().SetPartyVehicleDirection(references.Right)
+	}
+	g.PartyVehicle = *carpet
+
+	g.PartyState.Inventory.SpecialItems.DecrementByOne(references.Carpet)
+	g.SystemCallbacks.Message.AddRowStr("Boarded!")
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	g.SystemCallbacks.Flow.AdvanceTime(1)
+	return true
+}
+
+// ActionUseSkullKey unlocks a magically locked door in the given direction, as In Ex Por would.
+// A skull key is consumed whether or not there was a door to unlock - see Objects.md Skull Key.
+func (g *GameState) ActionUseSkullKey(direction references.Direction) bool {
+	if g.PartyState.Inventory.Provisions.SkullKeys.Get() <= 0 {
+		g.SystemCallbacks.Message.AddRowStr("None owned!")
+		return false
+	}
+	g.PartyState.Inventory.Provisions.SkullKeys.DecrementByOne()
+	g.SystemCallbacks.Message.AddRowStr("Skull Key")
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	g.SystemCallbacks.Flow.AdvanceTime(1)
+
+	// only on the overworld and in dungeons - towns and combat refuse it
+	mapType := g.MapState.PlayerLocation.Location.GetMapType()
+	if mapType != references.LargeMapType && mapType != references.DungeonMapType {
+		g.SystemCallbacks.Message.AddRowStr("Not here!")
+		return false
+	}
+
+	targetPosition := direction.GetNewPositionInDirection(&g.MapState.PlayerLocation.Position)
+	if !g.MapState.MagicUnlockDoor(targetPosition) {
+		g.SystemCallbacks.Message.AddRowStr("No effect!")
+		return false
+	}
+
+	g.SystemCallbacks.Message.AddRowStr("Unlocked!")
+	g.SystemCallbacks.Audio.PlaySoundEffect(SoundUnlock)
+	return true
+}
+
+// ActionUseSpyglass studies the sky - see Commands.md Use section. It returns true when the
+// moons and stars can be seen and the sky view should be shown.
+func (g *GameState) ActionUseSpyglass() bool {
+	if !g.PartyState.Inventory.SpecialItems.HasSome(references.Spyglass) {
+		g.SystemCallbacks.Message.AddRowStr("None owned!")
+		return false
+	}
+	g.SystemCallbacks.Message.AddRowStr("Spyglass")
+	g.SystemCallbacks.Flow.AdvanceTime(1)
+
+	if g.MapState.PlayerLocation.Location.GetMapType() == references.LargeMapType && g.DateTime.IsDayLight() {
+		g.SystemCallbacks.Message.AddRowStr("No stars!")
+		return false
+	}
+
+	g.SystemCallbacks.Message.AddRowStr("Looking...")
+	return true
+}
+
+// IsTelescopeInDirection returns true if the party is looking at a town telescope
+func (g *GameState) IsTelescopeInDirection(direction references.Direction) bool {
+	if g.MapState.PlayerLocation.Location.GetMapType() != references.SmallMapType {
+		return false
+	}
+	pos := direction.GetNewPositionInDirection(&g.MapState.PlayerLocation.Position)
+	return g.GetLayeredMapByCurrentLocation().GetTopTile(pos).Index == indexes.Telescope
+}
+
+// GetTelescopeViewPosition returns the overworld position at the centre of the telescope's view
+// after panning nTilesPanned tiles away from the town in the given direction - see Fixtures.md Telescope
+func (g *GameState) GetTelescopeViewPosition(direction references.Direction, nTilesPanned int) references.Position {
+	pos := g.LastLargeMapPosition
+	for range min(nTilesPanned, TelescopeViewTiles) {
+		pos = *direction.GetNewPositionInDirection(&pos).GetWrapped(references.XLargeMapTiles, references.YLargeMapTiles)
+	}
+	return pos
+}
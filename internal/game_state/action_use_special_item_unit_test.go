@@ -0,0 +1,133 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/datetime"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_units"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+func newSpecialItemTestGameState(t *testing.T) (*GameState, *MockSystemCallbacks) {
+	gs, mockCallbacks := newUnitTestGameState(t)
+	gs.MapState.PlayerLocation.Location = references.Britain
+	gs.MapState.PlayerLocation.Position = references.Position{X: 10, Y: 12}
+	return gs, mockCallbacks
+}
+
+func TestUseCarpet_BoardsOnFoot(t *testing.T) {
+	gs, mockCallbacks := newSpecialItemTestGameState(t)
+	gs.PartyState.Inventory.SpecialItems.Set(references.Carpet, 2)
+
+	if !gs.ActionUseCarpet() {
+		t.Fatalf("Expected the carpet to be boarded")
+	}
+	mockCallbacks.AssertLastMessage("Boarded!")
+	mockCallbacks.AssertTimeAdvanced(1)
+
+	vehicleDetails := gs.PartyVehicle.GetVehicleDetails()
+	if vehicleDetails.VehicleType != references.CarpetVehicle {
+		t.Errorf("Expected the party to be riding a carpet, got %d", vehicleDetails.VehicleType)
+	}
+	if carpetPos := gs.PartyVehicle.Pos(); !carpetPos.Equals(&gs.MapState.PlayerLocation.Position) {
+		t.Errorf("Expected the carpet to be placed under the party")
+	}
+	if gs.PartyState.Inventory.SpecialItems.Get(references.Carpet) != 1 {
+		t.Errorf("Expected one carpet to be used up")
+	}
+}
+
+func TestUseCarpet_RefusedWhenNotOnFoot(t *testing.T) {
+	gs, mockCallbacks := newSpecialItemTestGameState(t)
+	gs.PartyState.Inventory.SpecialItems.Set(references.Carpet, 1)
+
+	gs.PartyVehicle = *map_units.NewNPCFriendlyVehiceNewRef(references.FrigateVehicle, gs.MapState.PlayerLocation.Position, 0)
+	if gs.ActionUseCarpet() {
+		t.Errorf("Expected the carpet to be refused on a frigate")
+	}
+	mockCallbacks.AssertLastMessage("X-it ship first!")
+
+	gs.PartyVehicle = *map_units.NewNPCFriendlyVehiceNewRef(references.HorseVehicle, gs.MapState.PlayerLocation.Position, 0)
+	if gs.ActionUseCarpet() {
+		t.Errorf("Expected the carpet to be refused on horseback")
+	}
+	mockCallbacks.AssertLastMessage("Only on foot!")
+
+	if gs.PartyState.Inventory.SpecialItems.Get(references.Carpet) != 1 {
+		t.Errorf("Expected the carpet to be kept")
+	}
+}
+
+func TestUseSkullKey_AlwaysConsumed(t *testing.T) {
+	gs, mockCallbacks := newSpecialItemTestGameState(t)
+
+	if gs.ActionUseSkullKey(references.Up) {
+		t.Errorf("Expected skull key use to fail when none are owned")
+	}
+	mockCallbacks.AssertLastMessage("None owned!")
+
+	gs.PartyState.Inventory.Provisions.SkullKeys.Set(3)
+	if gs.ActionUseSkullKey(references.Up) {
+		t.Errorf("Expected skull keys to be refused in town")
+	}
+	mockCallbacks.AssertLastMessage("Not here!")
+
+	gs.MapState.PlayerLocation.Location = references.Combat_resting_shrine
+	if gs.ActionUseSkullKey(references.Up) {
+		t.Errorf("Expected skull keys to be refused in combat")
+	}
+	mockCallbacks.AssertLastMessage("Not here!")
+
+	gs.MapState.PlayerLocation.Location = references.Britannia_Underworld
+	if gs.ActionUseSkullKey(references.Up) {
+		t.Errorf("Expected no effect without a magically locked door")
+	}
+	mockCallbacks.AssertLastMessage("No effect!")
+
+	if gs.PartyState.Inventory.Provisions.SkullKeys.Get() != 0 {
+		t.Errorf("Expected every skull key to be consumed")
+	}
+}
+
+func TestUseSpyglass_OnlyShowsStarsAtNightOutdoors(t *testing.T) {
+	gs, mockCallbacks := newSpecialItemTestGameState(t)
+	gs.PartyState.Inventory.SpecialItems.Set(references.Spyglass, 1)
+	gs.MapState.PlayerLocation.Location = references.Britannia_Underworld
+
+	gs.DateTime.SetTimeOfDay(datetime.Noon)
+	if gs.ActionUseSpyglass() {
+		t.Errorf("Expected no stars in daylight on the overworld")
+	}
+	mockCallbacks.AssertLastMessage("No stars!")
+
+	gs.DateTime.SetTimeOfDay(datetime.Midnight)
+	if !gs.ActionUseSpyglass() {
+		t.Errorf("Expected the sky to be visible at night")
+	}
+	mockCallbacks.AssertLastMessage("Looking...")
+
+	if gs.PartyState.Inventory.SpecialItems.Get(references.Spyglass) != 1 {
+		t.Errorf("Expected the spyglass to be kept")
+	}
+}
+
+func TestTelescope_PansAcrossTheOverworld(t *testing.T) {
+	gs, _ := newSpecialItemTestGameState(t)
+	gs.LastLargeMapPosition = references.Position{X: 2, Y: 100}
+
+	if pos := gs.GetTelescopeViewPosition(references.Left, 0); !pos.Equals(&gs.LastLargeMapPosition) {
+		t.Errorf("Expected the view to start over the town, got %v", pos)
+	}
+
+	pos := gs.GetTelescopeViewPosition(references.Left, 5)
+	expected := references.Position{X: references.XLargeMapTiles - 3, Y: 100}
+	if !pos.Equals(&expected) {
+		t.Errorf("Expected the view to wrap to %v, got %v", expected, pos)
+	}
+
+	pos = gs.GetTelescopeViewPosition(references.Down, TelescopeViewTiles+10)
+	expected = references.Position{X: 2, Y: 100 + TelescopeViewTiles}
+	if !pos.Equals(&expected) {
+		t.Errorf("Expected the view to stop panning at %v, got %v", expected, pos)
+	}
+}
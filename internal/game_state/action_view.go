@@ -1,5 +1,15 @@
 package game_state
 
+import (
+	"image"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// The View actions consume a gem - the front end then shows the map returned by GetGemView.
+// See Commands.md View (Gem Map).
+
 func (g *GameState) ActionViewSmallMap() bool {
 	if !g.PartyState.Inventory.Provisions.Gems.HasSome() {
 		g.SystemCallbacks.Message.AddRowStr("You have none!")
@@ -7,8 +17,8 @@ func (g *GameState) ActionViewSmallMap() bool {
 	}
 
 	g.PartyState.Inventory.Provisions.Gems.DecrementByOne()
-	// TODO: Implement small map overhead view display
 	g.SystemCallbacks.Message.AddRowStr("View area!")
+	g.SystemCallbacks.Screen.MarkStatsChanged()
 	g.SystemCallbacks.Flow.AdvanceTime(1)
 	return true
 }
@@ -20,8 +30,8 @@ func (g *GameState) ActionViewLargeMap() bool {
 	}
 
 	g.PartyState.Inventory.Provisions.Gems.DecrementByOne()
-	// TODO: Implement overworld area view display
 	g.SystemCallbacks.Message.AddRowStr("View area!")
+	g.SystemCallbacks.Screen.MarkStatsChanged()
 	g.SystemCallbacks.Flow.AdvanceTime(1)
 	return true
 }
@@ -33,8 +43,8 @@ func (g *GameState) ActionViewCombatMap() bool {
 	}
 
 	g.PartyState.Inventory.Provisions.Gems.DecrementByOne()
-	// TODO: Implement combat map tactical overview display
 	g.SystemCallbacks.Message.AddRowStr("View area!")
+	g.SystemCallbacks.Screen.MarkStatsChanged()
 	g.SystemCallbacks.Flow.AdvanceTime(1)
 	return true
 }
@@ -46,8 +56,43 @@ func (g *GameState) ActionViewDungeonMap() bool {
 	}
 
 	g.PartyState.Inventory.Provisions.Gems.DecrementByOne()
-	// TODO: Implement dungeon level cell layout rendering
 	g.SystemCallbacks.Message.AddRowStr("View dungeon!")
+	g.SystemCallbacks.Screen.MarkStatsChanged()
 	g.SystemCallbacks.Flow.AdvanceTime(1)
 	return true
 }
+
+// GetGemView returns the gem view of the map (or dungeon level) the party is on,
+// or nil if there is no map to show
+func (g *GameState) GetGemView() *map_state.GemView {
+	// a dungeon shows the whole of the level the party is on, even from inside one of its rooms
+	if g.MapState.PlayerLocation.Location.GetMapType() == references.DungeonMapType {
+		gemView := map_state.NewGemView(references.DungeonMapType,
+			references.XDungeonTiles, references.YDungeonTiles,
+			g.MapState.PlayerLocation.Position)
+		return &gemView
+	}
+
+	layeredMap := g.GetLayeredMapByCurrentLocation()
+	if layeredMap == nil {
+		return nil
+	}
+
+	gemView := map_state.NewGemView(g.MapState.PlayerLocation.Location.GetMapType(),
+		layeredMap.XMaxTilesPerMap, layeredMap.YMaxTilesPerMap,
+		g.MapState.PlayerLocation.Position)
+	return &gemView
+}
+
+// RenderGemView draws the gem view of the map (or dungeon level) the party is on, or nil if there is
+// no map to show
+func (g *GameState) RenderGemView() *image.RGBA {
+	gemView := g.GetGemView()
+	if gemView == nil {
+		return nil
+	}
+	if g.MapState.PlayerLocation.Location.GetMapType() == references.DungeonMapType {
+		return gemView.RenderDungeonLevel(g.GetCurrentDungeonLevel())
+	}
+	return gemView.Render(g.GetLayeredMapByCurrentLocation())
+}
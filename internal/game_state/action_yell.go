@@ -5,25 +5,30 @@ import (
 )
 
 func (g *GameState) ActionYellSmallMap(direction references.Direction) bool {
+	if g.ActionYellSails() {
+		return true
+	}
+
 	// TODO: Implement small map Yell command - see Commands.md Yell section
 	// Should handle:
-	// - Ship sail control (hoist/furl on small maps)
 	// - Town shadowlord summoning (castle-specific words)
 	// - Overworld Words of Power (dungeon unsealing/shrine restoration)
 	// - Context validation (town vs overworld vs ship)
 	// - Word recognition and effect triggering
 
-	// Shadowlords, sails, and dungeon seal systems not implemented yet
+	// Shadowlords and dungeon seal systems not implemented yet
 	g.SystemCallbacks.Message.AddRowStr("Not yet!")
 	return false
 }
 
 func (g *GameState) ActionYellLargeMap(direction references.Direction) bool {
-	// TODO: Implement large map Yell command - see Commands.md Yell section
-	// Large map variant of yell command - primarily overworld Words of Power
+	if g.ActionYellSails() {
+		return true
+	}
 
-	// Words of Power, dungeon seals, and shrine restoration not implemented yet
-	g.SystemCallbacks.Message.AddRowStr("Not yet!")
+	// Words of Power are yelled with ActionYellWordOfPower once the word has been given - without
+	// one there is nothing to yell
+	g.SystemCallbacks.Message.AddRowStr("Nothing")
 	return false
 }
 
@@ -1,31 +1,223 @@
 package game_state
 
-func (g *GameState) ActionZtatsSmallMap() bool {
-	// TODO: Implement small map Ztats command - see Commands.md Ztats (Party Member Stats) section
-	// Should handle:
-	// - Character selection (1-6 or by name)
-	// - Display full character statistics
-	// - HP/MP current and max values
-	// - Equipment readied in each slot
-	// - Experience and level information
-	// - Status effects (poisoned, sleeping, dead, etc.)
-	return true
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/game_state/util"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// ZtatsPage is one page of the Ztats screen - the selected character followed by one page
+// per category of the shared party inventory
+type ZtatsPage int
+
+const (
+	ZtatsCharacterPage ZtatsPage = iota
+	ZtatsWeaponsPage
+	ZtatsArmourPage
+	ZtatsRingsPage
+	ZtatsReagentsPage
+	ZtatsSpellsPage
+	ZtatsScrollsPage
+	ZtatsPotionsPage
+	ZtatsSpecialItemsPage
+	NZtatsPages
+)
+
+var ztatsPageTitles = map[ZtatsPage]string{
+	ZtatsCharacterPage:    "Character",
+	ZtatsWeaponsPage:      "Weapons",
+	ZtatsArmourPage:       "Armour",
+	ZtatsRingsPage:        "Rings & Amulets",
+	ZtatsReagentsPage:     "Reagents",
+	ZtatsSpellsPage:       "Spells",
+	ZtatsScrollsPage:      "Scrolls",
+	ZtatsPotionsPage:      "Potions",
+	ZtatsSpecialItemsPage: "Special Items",
 }
 
-func (g *GameState) ActionZtatsLargeMap() bool {
-	// TODO: Implement large map Ztats command - see Commands.md Ztats (Party Member Stats) section
-	// Large map variant of ztats command
-	return true
+func (z ZtatsPage) GetTitle() string {
+	return ztatsPageTitles[z]
 }
 
-func (g *GameState) ActionZtatsCombatMap() bool {
-	// TODO: Implement combat map Ztats command - see Commands.md Ztats (Party Member Stats) section
-	// Combat map variant - quick stats check during battle
-	return true
+// GetNext returns the following page, wrapping around to the character page
+func (z ZtatsPage) GetNext() ZtatsPage {
+	return (z + 1) % NZtatsPages
+}
+
+// GetPrevious returns the preceding page, wrapping around to the last inventory page
+func (z ZtatsPage) GetPrevious() ZtatsPage {
+	return (z + NZtatsPages - 1) % NZtatsPages
 }
 
-func (g *GameState) ActionZtatsDungeonMap() bool {
-	// TODO: Implement dungeon map Ztats command - see Commands.md Ztats (Party Member Stats) section
-	// Dungeon map variant of ztats command
+func (g *GameState) ActionZtatsSmallMap(playerIndex int) bool {
+	return g.selectZtatsCharacter(playerIndex)
+}
+
+func (g *GameState) ActionZtatsLargeMap(playerIndex int) bool {
+	return g.selectZtatsCharacter(playerIndex)
+}
+
+func (g *GameState) ActionZtatsCombatMap(playerIndex int) bool {
+	// the character page reflects the combatant's current HP/MP and conditions
+	return g.selectZtatsCharacter(playerIndex)
+}
+
+func (g *GameState) ActionZtatsDungeonMap(playerIndex int) bool {
+	return g.selectZtatsCharacter(playerIndex)
+}
+
+// selectZtatsCharacter validates the chosen party member - see Commands.md Ztats section.
+// Ztats is informational only and never uses a turn.
+func (g *GameState) selectZtatsCharacter(playerIndex int) bool {
+	if !g.PartyState.IsCharacterInParty(playerIndex) {
+		g.SystemCallbacks.Message.AddRowStr("nobody!")
+		return false
+	}
+	g.SystemCallbacks.Message.AddRowStr(g.PartyState.Characters[playerIndex].GetNameAsString())
 	return true
 }
+
+// GetZtatsPageLines returns the text of a Ztats page. The character page describes the chosen
+// party member, every other page lists the party inventory for its category.
+func (g *GameState) GetZtatsPageLines(playerIndex int, page ZtatsPage) []string {
+	if page == ZtatsCharacterPage {
+		return g.getZtatsCharacterLines(playerIndex)
+	}
+
+	inventory := &g.PartyState.Inventory
+	itemRefs := g.GameReferences.InventoryItemReferences
+	var lines []string
+	addLine := func(name string, quantity uint16) {
+		if quantity == 0 {
+			return
+		}
+		lines = append(lines, fmt.Sprintf("%-18s %3d", ztatsItemName(name), quantity))
+	}
+
+	switch page {
+	case ZtatsWeaponsPage, ZtatsArmourPage, ZtatsRingsPage:
+		for item := references.LeatherHelm; item <= references.Ankh; item++ {
+			if getZtatsPageOfEquipment(item) == page {
+				addLine(itemRefs.Equipment[item].ItemName, inventory.Equipment.Get(item))
+			}
+		}
+	case ZtatsReagentsPage:
+		for reagent := references.SulfurAsh; reagent <= references.MandrakeRoot; reagent++ {
+			addLine(itemRefs.Reagent[reagent].ItemName, inventory.Reagent.Get(reagent))
+		}
+	case ZtatsSpellsPage:
+		for spell := references.InLor; spell <= references.AnTym; spell++ {
+			addLine(itemRefs.Spell[spell].ItemName, inventory.Spells.Get(spell))
+		}
+	case ZtatsScrollsPage:
+		for scroll := references.ScrollVasLor; scroll <= references.ScrollAnTym; scroll++ {
+			addLine(itemRefs.Scroll[scroll].ItemName, inventory.Scrolls.Get(scroll))
+		}
+	case ZtatsPotionsPage:
+		for potion := references.Blue; potion <= references.White; potion++ {
+			addLine(itemRefs.Potion[potion].ItemName, inventory.Potions.Get(potion))
+		}
+	case ZtatsSpecialItemsPage:
+		for special := references.Carpet; special <= references.Sextant; special++ {
+			addLine(itemRefs.Special[special].ItemName, inventory.SpecialItems.Get(special))
+		}
+		for quest := references.Amulet; quest <= references.Sceptre; quest++ {
+			addLine(itemRefs.QuestItem[quest].ItemName, inventory.QuestItems.Get(quest))
+		}
+		for shard := references.Falsehood; shard <= references.Cowardice; shard++ {
+			addLine(itemRefs.Shard[shard].ItemName, inventory.Shards.Get(shard))
+		}
+	default:
+	}
+
+	if len(lines) == 0 {
+		return []string{"None"}
+	}
+	return lines
+}
+
+func (g *GameState) getZtatsCharacterLines(playerIndex int) []string {
+	character := &g.PartyState.Characters[playerIndex]
+
+	lines := []string{
+		fmt.Sprintf("%s %s %s",
+			character.GetNameAsString(),
+			getFriendlyName(party_state.CharacterClasses, character.Class),
+			getFriendlyName(party_state.CharacterGenders, character.Gender)),
+		getFriendlyName(party_state.CharacterStatuses, character.Status),
+		fmt.Sprintf("Level: %d", character.Level),
+		fmt.Sprintf("Exp: %d", character.Exp),
+		fmt.Sprintf("HP: %d/%d", character.CurrentHp, character.MaxHp),
+		fmt.Sprintf("MP: %d/%d", character.CurrentMp, character.GetMaxMp()),
+		fmt.Sprintf("STR: %d", character.Strength),
+		fmt.Sprintf("DEX: %d", character.Dexterity),
+		fmt.Sprintf("INT: %d", character.Intelligence),
+	}
+
+	slotLabels := []struct {
+		label string
+		slot  party_state.EquipmentSlot
+	}{
+		{"Weapon", party_state.LeftHandSlot},
+		{"Shield", party_state.RightHandSlot},
+		{"Armour", party_state.ArmourSlot},
+		{"Helm", party_state.HelmetSlot},
+		{"Ring", party_state.RingSlot},
+		{"Amulet", party_state.AmuletSlot},
+	}
+	for _, slotLabel := range slotLabels {
+		name := "None"
+		if equipment := character.GetEquipped(slotLabel.slot); equipment != references.NoEquipment {
+			name = ztatsItemName(g.GameReferences.InventoryItemReferences.Equipment[equipment].ItemName)
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", slotLabel.label, name))
+	}
+
+	if tags := g.getZtatsEquipmentTags(playerIndex); len(tags) > 0 {
+		lines = append(lines, strings.Join(tags, " "))
+	}
+	return lines
+}
+
+// getZtatsEquipmentTags lists the passive effects of the character's readied rings -
+// see Combat_Effects.md Equipment Resistances & Effects
+func (g *GameState) getZtatsEquipmentTags(playerIndex int) []string {
+	var tags []string
+	switch g.PartyState.Characters[playerIndex].GetEquipped(party_state.RingSlot) {
+	case references.RingProtection:
+		tags = append(tags, "Protection")
+	case references.RingRegeneration:
+		tags = append(tags, "Regen")
+	case references.RingInvisibility:
+		if g.PartyState.CombatEffects[playerIndex].Invisible {
+			tags = append(tags, "Invisible")
+		}
+	default:
+	}
+	return tags
+}
+
+func getZtatsPageOfEquipment(item references.Equipment) ZtatsPage {
+	switch usage := item.GetDetails().Usage; {
+	case item.IsArmour():
+		return ZtatsArmourPage
+	case usage == references.RingUsage || usage == references.NeckUsage:
+		return ZtatsRingsPage
+	default:
+		return ZtatsWeaponsPage
+	}
+}
+
+func getFriendlyName[T comparable](mapping util.OrderedMapping[T], id T) string {
+	if idToString := mapping.GetById(id); idToString != nil {
+		return idToString.FriendlyName
+	}
+	return ""
+}
+
+func ztatsItemName(name string) string {
+	return strings.ReplaceAll(name, "_", " ")
+}
@@ -0,0 +1,110 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+func newZtatsTestGameState(t *testing.T) (*GameState, *MockSystemCallbacks) {
+	gs, mockCallbacks := newUnitTestGameState(t)
+	gs.GameReferences = &references.GameReferences{
+		InventoryItemReferences: references.NewInventoryItemsReferences(),
+	}
+	character := addUnitTestPartyMember(gs, 0, "Ava")
+	character.Class = party_state.Avatar
+	character.Gender = party_state.Female
+	character.Level = 3
+	character.Exp = 450
+	character.CurrentHp = 80
+	character.MaxHp = 90
+	character.Intelligence = 20
+	character.CurrentMp = 15
+	for _, slot := range party_state.AllEquipmentSlots {
+		character.SetEquipped(slot, references.NoEquipment)
+	}
+	return gs, mockCallbacks
+}
+
+func containsLine(lines []string, expected string) bool {
+	for _, line := range lines {
+		if line == expected {
+			return true
+		}
+	}
+	return false
+}
+
+func TestZtats_NobodySelected(t *testing.T) {
+	gs, mockCallbacks := newZtatsTestGameState(t)
+
+	if gs.ActionZtatsSmallMap(3) {
+		t.Errorf("Expected empty party slot to be refused")
+	}
+	mockCallbacks.AssertLastMessage("nobody!")
+
+	if !gs.ActionZtatsLargeMap(0) {
+		t.Errorf("Expected Ava to be selected")
+	}
+	mockCallbacks.AssertTimeAdvanced(0)
+}
+
+func TestZtats_CharacterPage(t *testing.T) {
+	gs, _ := newZtatsTestGameState(t)
+	character := &gs.PartyState.Characters[0]
+	character.SetEquipped(party_state.LeftHandSlot, references.LongSword)
+	character.SetEquipped(party_state.RingSlot, references.RingProtection)
+
+	lines := gs.GetZtatsPageLines(0, ZtatsCharacterPage)
+
+	for _, expected := range []string{
+		"Ava Avatar Female",
+		"Good",
+		"Level: 3",
+		"Exp: 450",
+		"HP: 80/90",
+		"MP: 15/20",
+		"Weapon: " + ztatsItemName(gs.GameReferences.InventoryItemReferences.Equipment[references.LongSword].ItemName),
+		"Shield: None",
+		"Helm: None",
+		"Protection",
+	} {
+		if !containsLine(lines, expected) {
+			t.Errorf("Expected line %q in %v", expected, lines)
+		}
+	}
+}
+
+func TestZtats_InventoryPagesByCategory(t *testing.T) {
+	gs, _ := newZtatsTestGameState(t)
+	gs.PartyState.Inventory.Equipment.Set(references.Dagger, 2)
+	gs.PartyState.Inventory.Equipment.Set(references.ChainMail, 1)
+	gs.PartyState.Inventory.Equipment.Set(references.AmuletOfTurning, 1)
+	gs.PartyState.Inventory.Reagent.Set(references.Garlic, 7)
+
+	expectedCounts := map[ZtatsPage]int{
+		ZtatsWeaponsPage:  1,
+		ZtatsArmourPage:   1,
+		ZtatsRingsPage:    1,
+		ZtatsReagentsPage: 1,
+	}
+	for page, nExpected := range expectedCounts {
+		if lines := gs.GetZtatsPageLines(0, page); len(lines) != nExpected || lines[0] == "None" {
+			t.Errorf("Expected %d items on the %s page, got %v", nExpected, page.GetTitle(), lines)
+		}
+	}
+
+	if lines := gs.GetZtatsPageLines(0, ZtatsPotionsPage); !containsLine(lines, "None") {
+		t.Errorf("Expected no potions, got %v", lines)
+	}
+}
+
+func TestZtats_PagesWrap(t *testing.T) {
+	if ZtatsCharacterPage.GetPrevious() != ZtatsSpecialItemsPage {
+		t.Errorf("Expected the character page to wrap back to special items")
+	}
+	if ZtatsSpecialItemsPage.GetNext() != ZtatsCharacterPage {
+		t.Errorf("Expected special items to wrap forward to the character page")
+	}
+}
@@ -0,0 +1,155 @@
+package game_state
+
+import (
+	"fmt"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/datetime"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+// Trolls lurk under the bridges of Britannia. A party crossing on foot may be spotted, and then each
+// member tries to sneak past - the first one caught is asked for a toll, and the trolls attack if it
+// isn't paid.
+// See Special_BridgeTrolls.md.
+
+const (
+	bridgeTrollOdds = 8
+	// a member sneaks past if they roll no higher than their dexterity
+	bridgeTrollSneakRoll = 30
+	// the toll is cheaper for a stronger member
+	bridgeTrollBaseToll        = 99
+	bridgeTrollTollPerStrength = 3
+)
+
+// bridgeTrollsByEra is how many trolls climb out from under the bridge
+// TODO: TBD placeholder counts - how many trolls the original sends is not documented
+var bridgeTrollsByEra = map[datetime.Era]int{
+	datetime.EarlyEra:  2,
+	datetime.MiddleEra: 3,
+	datetime.LateEra:   4,
+}
+
+func isTrollBridge(tile *references.Tile) bool {
+	return tile != nil && (tile.Index == indexes.TrollBridgeHoriz || tile.Index == indexes.TrollBridgeVert)
+}
+
+// EncounterBridgeTrollsIfPresent is called after the party steps onto a new tile of the overworld
+func (g *GameState) EncounterBridgeTrollsIfPresent() bool {
+	if !g.DebugOptions.MonsterGen || g.MapState.PlayerLocation.Location.GetMapType() != references.LargeMapType {
+		return false
+	}
+	if g.PartyVehicle.GetVehicleDetails().VehicleType != references.NoPartyVehicle {
+		return false
+	}
+	if !isTrollBridge(g.GetCurrentLayeredMapAvatarTopTile()) {
+		return false
+	}
+	if !g.OneInXOdds(bridgeTrollOdds) {
+		return false
+	}
+
+	layeredMap := g.GetCurrentLayeredMap()
+	return g.sneakPastBridgeTrolls(func(position references.Position) bool {
+		tile := layeredMap.GetTopTile(&position)
+		return tile != nil && tile.IsWalkingPassable()
+	})
+}
+
+// sneakPastBridgeTrolls has the party try to slip by trolls that have already spotted them. It is
+// true if the trolls caught anyone.
+func (g *GameState) sneakPastBridgeTrolls(canTrollStandOn func(references.Position) bool) bool {
+	g.SystemCallbacks.Message.AddRowStr("Thou spieth trolls under the bridge!")
+
+	for i := range g.PartyState.Characters {
+		if !g.PartyState.IsCharacterInParty(i) {
+			continue
+		}
+		character := &g.PartyState.Characters[i]
+		if character.Status == party_state.Dead || character.Status == party_state.Sleep {
+			continue
+		}
+
+		g.SystemCallbacks.Message.AddRowStr(fmt.Sprintf("%s sneaks across...", character.GetNameAsString()))
+		if g.RandomIntInRange(1, bridgeTrollSneakRoll) > int(character.Dexterity) {
+			g.payBridgeTrollToll(character, canTrollStandOn)
+			return true
+		}
+	}
+
+	g.SystemCallbacks.Message.AddRowStr("Trolls evaded!")
+	return false
+}
+
+// payBridgeTrollToll asks the party to buy their way past. Refusing, or not having the gold, means a fight.
+func (g *GameState) payBridgeTrollToll(caught *party_state.PlayerCharacter, canTrollStandOn func(references.Position) bool) {
+	toll := max(bridgeTrollBaseToll-bridgeTrollTollPerStrength*int(caught.Strength), 0)
+
+	g.SystemCallbacks.Message.AddRowStr("Caught!")
+	g.SystemCallbacks.Message.AddRowStr(fmt.Sprintf("The trolls demand a %d gp toll!", toll))
+	if g.SystemCallbacks.Screen.PromptYesNo("Dost thou pay?") && int(g.PartyState.Inventory.Gold.Get()) >= toll {
+		g.PartyState.Inventory.Gold.DecrementBy(uint16(toll))
+		g.SystemCallbacks.Screen.MarkStatsChanged()
+		return
+	}
+
+	g.spawnBridgeTrolls(canTrollStandOn)
+}
+
+// spawnBridgeTrolls surrounds the party with trolls, as many as the era calls for and as will fit
+func (g *GameState) spawnBridgeTrolls(canTrollStandOn func(references.Position) bool) int {
+	trollReference := g.getTrollReference()
+	if trollReference == nil || g.CurrentNPCAIController == nil {
+		return 0
+	}
+
+	npcs := g.CurrentNPCAIController.GetNpcs()
+	nTrolls := 0
+	for _, position := range g.getSurroundingPositions() {
+		if nTrolls >= bridgeTrollsByEra[g.DateTime.GetEra()] {
+			break
+		}
+		if !canTrollStandOn(position) || npcs.GetMapUnitAtPositionOrNil(position) != nil {
+			continue
+		}
+		if npcs.AddEnemy(*trollReference, position, g.MapState.PlayerLocation.Floor) {
+			nTrolls++
+		}
+	}
+
+	if nTrolls > 0 {
+		g.SystemCallbacks.Message.AddRowStr("The trolls attack!")
+	}
+	return nTrolls
+}
+
+func (g *GameState) getTrollReference() *references.EnemyReference {
+	if g.GameReferences == nil || g.GameReferences.EnemyReferences == nil {
+		return nil
+	}
+	for i := range *g.GameReferences.EnemyReferences {
+		enemy := &(*g.GameReferences.EnemyReferences)[i]
+		if enemy.KeyFrameTile != nil && enemy.KeyFrameTile.Index == indexes.Troll_KeyIndex {
+			return enemy
+		}
+	}
+	return nil
+}
+
+// getSurroundingPositions is the eight tiles around the party, the ones alongside first
+func (g *GameState) getSurroundingPositions() []references.Position {
+	partyPosition := g.MapState.PlayerLocation.Position
+	positions := append(g.getAdjacentPositions(),
+		references.Position{X: partyPosition.X - 1, Y: partyPosition.Y - 1},
+		references.Position{X: partyPosition.X + 1, Y: partyPosition.Y - 1},
+		references.Position{X: partyPosition.X - 1, Y: partyPosition.Y + 1},
+		references.Position{X: partyPosition.X + 1, Y: partyPosition.Y + 1},
+	)
+	if g.MapState.PlayerLocation.Location.GetMapType() == references.LargeMapType {
+		for i := range positions {
+			positions[i] = *positions[i].GetWrapped(references.XLargeMapTiles, references.YLargeMapTiles)
+		}
+	}
+	return positions
+}
@@ -0,0 +1,117 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/ai"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/datetime"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+func newBridgeTrollsTestGameState(t *testing.T, dexterity byte) (*GameState, *MockSystemCallbacks) {
+	gs, mockCallbacks := newSailingTestGameState(t, references.NoPartyVehicle)
+	gs.CurrentNPCAIController = ai.NewNPCAIControllerLargeMap(ai.NewNPCAIControllerLargeMapInput{})
+	gs.DebugOptions.MonsterGen = true
+	gs.GameReferences = &references.GameReferences{
+		EnemyReferences: &references.EnemyReferences{
+			{KeyFrameTile: &references.Tile{Index: indexes.Troll_KeyIndex}},
+		},
+	}
+
+	avatar := addUnitTestPartyMember(gs, 0, "Avatar")
+	avatar.Dexterity = dexterity
+	avatar.Strength = 20
+	return gs, mockCallbacks
+}
+
+func anywhere(references.Position) bool { return true }
+
+func TestBridgeTrolls_NoneWithoutMonsterGeneration(t *testing.T) {
+	gs, mockCallbacks := newBridgeTrollsTestGameState(t, 0)
+	gs.DebugOptions.MonsterGen = false
+
+	if gs.EncounterBridgeTrollsIfPresent() {
+		t.Errorf("Expected no trolls with monster generation turned off")
+	}
+	mockCallbacks.AssertNoMessages()
+}
+
+func TestBridgeTrolls_NimblePartyEvadesThem(t *testing.T) {
+	gs, mockCallbacks := newBridgeTrollsTestGameState(t, bridgeTrollSneakRoll)
+
+	if gs.sneakPastBridgeTrolls(anywhere) {
+		t.Errorf("Expected a member as nimble as the roll to always sneak past")
+	}
+	mockCallbacks.AssertMessageContains("Avatar sneaks across...")
+	mockCallbacks.AssertLastMessage("Trolls evaded!")
+}
+
+func TestBridgeTrolls_SleepingMembersAreCarried(t *testing.T) {
+	gs, mockCallbacks := newBridgeTrollsTestGameState(t, 0)
+	gs.PartyState.Characters[0].Status = party_state.Sleep
+
+	if gs.sneakPastBridgeTrolls(anywhere) {
+		t.Errorf("Expected a sleeping member not to be caught")
+	}
+	mockCallbacks.AssertLastMessage("Trolls evaded!")
+}
+
+func TestBridgeTrolls_PayingTheTollAvoidsAFight(t *testing.T) {
+	gs, mockCallbacks := newBridgeTrollsTestGameState(t, 0)
+	gs.PartyState.Inventory.Gold.Set(100)
+
+	if !gs.sneakPastBridgeTrolls(anywhere) {
+		t.Fatalf("Expected a clumsy member to be caught")
+	}
+	mockCallbacks.AssertMessageContains("The trolls demand a 39 gp toll!")
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != 61 {
+		t.Errorf("Expected the toll to leave 61 gold, got %d", gold)
+	}
+	if len(*gs.CurrentNPCAIController.GetNpcs()) != 0 {
+		t.Errorf("Expected no trolls once the toll was paid")
+	}
+}
+
+func TestBridgeTrolls_RefusingMeansAFightThatGrowsWithTheEra(t *testing.T) {
+	testCases := []struct {
+		turn    uint32
+		nTrolls int
+	}{
+		{0, 2},
+		{15000, 3},
+		{40000, 4},
+	}
+
+	for _, tc := range testCases {
+		gs, mockCallbacks := newBridgeTrollsTestGameState(t, 0)
+		gs.PartyState.Inventory.Gold.Set(1000)
+		gs.DateTime = datetime.UltimaDate{Turn: tc.turn}
+		mockCallbacks.YesNoPromptResponse = false
+
+		gs.sneakPastBridgeTrolls(anywhere)
+		mockCallbacks.AssertLastMessage("The trolls attack!")
+		if nTrolls := len(*gs.CurrentNPCAIController.GetNpcs()); nTrolls != tc.nTrolls {
+			t.Errorf("On turn %d expected %d trolls, got %d", tc.turn, tc.nTrolls, nTrolls)
+		}
+		if gold := gs.PartyState.Inventory.Gold.Get(); gold != 1000 {
+			t.Errorf("Expected no gold to change hands in a fight, got %d", gold)
+		}
+	}
+}
+
+func TestBridgeTrolls_TooPoorToPay(t *testing.T) {
+	gs, mockCallbacks := newBridgeTrollsTestGameState(t, 0)
+	gs.PartyState.Inventory.Gold.Set(10)
+
+	gs.sneakPastBridgeTrolls(func(position references.Position) bool {
+		return position.Y == gs.MapState.PlayerLocation.Position.Y
+	})
+	mockCallbacks.AssertLastMessage("The trolls attack!")
+	for _, troll := range *gs.CurrentNPCAIController.GetNpcs() {
+		if troll.Pos().Y != gs.MapState.PlayerLocation.Position.Y {
+			t.Errorf("Expected trolls only where they can stand, got one at %v", troll.Pos())
+		}
+	}
+}
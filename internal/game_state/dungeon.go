@@ -0,0 +1,176 @@
+package game_state
+
+import (
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// Dungeons are walked in the first person on a stack of 8x8 levels read from DUNGEON.DAT. The party
+// faces one of the four compass directions - forward and back move a tile, left and right turn on the
+// spot. The levels are copied on the way in so that what the party changes (opened chests, sprung
+// traps) stays changed until they leave.
+// See Dungeon.md.
+
+// DungeonState is the dungeon the party is in, as the party has left it
+type DungeonState struct {
+	Levels [references.DungeonLevels]references.DungeonLevel
+	// Facing is the compass direction the party is looking down
+	Facing references.Direction
+	// Room is the room the party is in, nil while they walk the corridors
+	Room *DungeonRoomState
+}
+
+// EnterDungeon takes the party down the entrance ladder of a dungeon. It is false if there is no
+// such dungeon.
+func (g *GameState) EnterDungeon(location references.Location) bool {
+	dungeonRef := g.GameReferences.DungeonReferences.GetDungeon(location)
+	if dungeonRef == nil {
+		return false
+	}
+
+	g.LastLargeMapPosition = g.MapState.PlayerLocation.Position
+	g.LastLargeMapFloor = g.MapState.PlayerLocation.Floor
+
+	g.Dungeon = DungeonState{Levels: dungeonRef.Levels, Facing: references.Up}
+	g.MapState.PlayerLocation.Location = location
+	g.MapState.PlayerLocation.Floor = 0
+	g.MapState.PlayerLocation.Position = getDungeonEntrancePosition(&g.Dungeon.Levels[0])
+	return true
+}
+
+// getDungeonEntrancePosition is the ladder that leads out of the first level
+func getDungeonEntrancePosition(level *references.DungeonLevel) references.Position {
+	if position, ok := level.FindTile(references.DungeonLadderUp); ok {
+		return position
+	}
+	position, _ := level.FindTile(references.DungeonLadderUpDown)
+	return position
+}
+
+// exitDungeon climbs back out to where the party went in
+func (g *GameState) exitDungeon() {
+	g.MapState.PlayerLocation.Location = references.Britannia_Underworld
+	g.MapState.PlayerLocation.Floor = g.LastLargeMapFloor
+	g.MapState.PlayerLocation.Position = g.LastLargeMapPosition
+	g.Dungeon = DungeonState{}
+	g.CurrentNPCAIController = g.GetCurrentLargeMapNPCAIController()
+	g.MapState.UpdateLargeMap()
+}
+
+// GetCurrentDungeonLevel is the level the party is on
+func (g *GameState) GetCurrentDungeonLevel() *references.DungeonLevel {
+	return &g.Dungeon.Levels[g.MapState.PlayerLocation.Floor]
+}
+
+// GetDungeonTileAhead is the tile distance steps in front of the party
+func (g *GameState) GetDungeonTileAhead(distance int) references.DungeonTile {
+	return g.GetCurrentDungeonLevel().GetTile(g.getDungeonPositionAhead(distance))
+}
+
+func (g *GameState) getDungeonPositionAhead(distance int) references.Position {
+	position := g.MapState.PlayerLocation.Position
+	for range distance {
+		position = *g.Dungeon.Facing.GetNewPositionInDirection(&position)
+	}
+	return *position.GetWrapped(references.XDungeonTiles, references.YDungeonTiles)
+}
+
+// ActionMoveDungeonMap is an arrow key in a dungeon - Up advances, Down retreats and Left and Right
+// turn the party. In a room the arrows walk the party the way they point. It is false if a wall is
+// in the way.
+func (g *GameState) ActionMoveDungeonMap(direction references.Direction) bool {
+	if g.IsInDungeonRoom() {
+		return g.moveInDungeonRoom(direction)
+	}
+
+	switch direction {
+	case references.Left:
+		g.Dungeon.Facing = turnLeft(g.Dungeon.Facing)
+		g.SystemCallbacks.Message.AddRowStr("Turn Left")
+		return true
+	case references.Right:
+		g.Dungeon.Facing = turnRight(g.Dungeon.Facing)
+		g.SystemCallbacks.Message.AddRowStr("Turn Right")
+		return true
+	case references.Up:
+		g.SystemCallbacks.Message.AddRowStr("Advance")
+		return g.stepInDungeon(g.Dungeon.Facing)
+	case references.Down:
+		g.SystemCallbacks.Message.AddRowStr("Retreat")
+		return g.stepInDungeon(g.Dungeon.Facing.GetOppositeDirection())
+	}
+	return false
+}
+
+func (g *GameState) stepInDungeon(direction references.Direction) bool {
+	newPosition := direction.GetNewPositionInDirection(&g.MapState.PlayerLocation.Position).
+		GetWrapped(references.XDungeonTiles, references.YDungeonTiles)
+	if !g.GetCurrentDungeonLevel().GetTile(*newPosition).IsPassable() {
+		g.SystemCallbacks.Message.AddRowStr("Blocked!")
+		return false
+	}
+	g.MapState.PlayerLocation.Position = *newPosition
+	if g.GetCurrentDungeonLevel().GetTile(*newPosition).Type() == references.DungeonRoom {
+		g.enterDungeonRoom(direction)
+	}
+	return true
+}
+
+func turnLeft(facing references.Direction) references.Direction {
+	switch facing {
+	case references.Up:
+		return references.Left
+	case references.Left:
+		return references.Down
+	case references.Down:
+		return references.Right
+	default:
+		return references.Up
+	}
+}
+
+func turnRight(facing references.Direction) references.Direction {
+	return turnLeft(facing).GetOppositeDirection()
+}
+
+// klimbDungeonLadder climbs whichever way the ladder underfoot goes - a ladder up out of the first
+// level leaves the dungeon
+func (g *GameState) klimbDungeonLadder() bool {
+	if g.IsInDungeonRoom() {
+		g.SystemCallbacks.Message.AddRowStr("Not here!")
+		return false
+	}
+	tile := g.GetCurrentDungeonLevel().GetTile(g.MapState.PlayerLocation.Position)
+
+	switch {
+	case tile.IsLadderUp():
+		if g.MapState.PlayerLocation.Floor == 0 {
+			g.exitDungeon()
+		} else {
+			g.MapState.PlayerLocation.Floor--
+		}
+		g.SystemCallbacks.Message.AddRowStr("Klimb-Up!")
+	case tile.IsLadderDown():
+		if int(g.MapState.PlayerLocation.Floor) >= references.DungeonLevels-1 {
+			g.SystemCallbacks.Message.AddRowStr("Can't go lower!")
+			return false
+		}
+		g.MapState.PlayerLocation.Floor++
+		g.SystemCallbacks.Message.AddRowStr("Klimb-Down!")
+	default:
+		g.SystemCallbacks.Message.AddRowStr("Nowhere to klimbe.")
+		return false
+	}
+
+	g.SystemCallbacks.Flow.AdvanceTime(1)
+	return true
+}
+
+func (g *GameState) dungeonProcessEndOfTurn() {
+	g.DateTime.Advance(DefaultSmallMapMinutesPerTurn)
+}
+
+// GetDungeonView is the first-person view from where the party stands, the given size in pixels
+func (g *GameState) GetDungeonView(width, height int) map_state.DungeonView {
+	return map_state.NewDungeonView(width, height, g.MapState.PlayerLocation.Position, g.Dungeon.Facing)
+}
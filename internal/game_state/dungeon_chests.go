@@ -0,0 +1,163 @@
+package game_state
+
+import (
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// Dungeon chests are opened from on top of them. A trapped chest springs one of four traps on
+// whoever opens it, unless their dexterity lets them disarm it first - the deeper the level, the
+// harder that is. Monsters beaten in a room may leave a chest behind, trapped more often the more
+// treasure they carry.
+// See Dungeon.md.
+
+// ChestTrap is what a trapped chest does to the party
+type ChestTrap int
+
+const (
+	// AcidChestTrap burns whoever opened the chest for 1d30
+	AcidChestTrap ChestTrap = iota
+	// PoisonChestTrap poisons whoever opened the chest
+	PoisonChestTrap
+	// BombChestTrap hurts the whole party for 1d8 each
+	BombChestTrap
+	// GasChestTrap poisons the whole party
+	GasChestTrap
+)
+
+const (
+	chestTreasureDie = 30
+	bombDamageMax    = 8
+)
+
+// rollChestTrap picks a trap - acid 3 in 8, poison 2 in 8, bomb 2 in 8 and gas 1 in 8
+func (g *GameState) rollChestTrap() ChestTrap {
+	switch roll := g.RandomIntInRange(0, 7); {
+	case roll <= 2:
+		return AcidChestTrap
+	case roll <= 4:
+		return PoisonChestTrap
+	case roll <= 6:
+		return BombChestTrap
+	default:
+		return GasChestTrap
+	}
+}
+
+// springChestTrap sets a trap off on the party member who opened the chest
+func (g *GameState) springChestTrap(trap ChestTrap, opener *party_state.PlayerCharacter) {
+	g.SystemCallbacks.Audio.PlaySoundEffect(SoundTrapTrigger)
+
+	switch trap {
+	case AcidChestTrap:
+		g.SystemCallbacks.Message.AddRowStr("ACID!")
+		opener.Damage(uint16(g.RandomIntInRange(1, chestTreasureDie)))
+	case PoisonChestTrap:
+		g.SystemCallbacks.Message.AddRowStr("POISON!")
+		opener.Poison()
+	case BombChestTrap:
+		g.SystemCallbacks.Message.AddRowStr("BOMB!")
+		g.forEachActivePartyMember(func(member *party_state.PlayerCharacter) {
+			member.Damage(uint16(g.RandomIntInRange(1, bombDamageMax)))
+		})
+	case GasChestTrap:
+		g.SystemCallbacks.Message.AddRowStr("GAS!")
+		g.forEachActivePartyMember(func(member *party_state.PlayerCharacter) {
+			member.Poison()
+		})
+	}
+
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+}
+
+func (g *GameState) forEachActivePartyMember(action func(member *party_state.PlayerCharacter)) {
+	for i := range g.PartyState.Characters {
+		if g.PartyState.IsCharacterInParty(i) && g.PartyState.Characters[i].Status != party_state.Dead {
+			action(&g.PartyState.Characters[i])
+		}
+	}
+}
+
+// disarmChestTrap is the opener's dexterity against the depth of the level - it is true when
+// random(1, 30) beats (30 + 2*level - dex) / 2
+func (g *GameState) disarmChestTrap(opener *party_state.PlayerCharacter) bool {
+	difficulty := (chestTreasureDie + 2*int(g.MapState.PlayerLocation.Floor) - int(opener.Dexterity)) / 2
+	return g.RandomIntInRange(1, chestTreasureDie) > difficulty
+}
+
+// openTrappedChest gives the opener a chance to disarm the trap before it goes off
+func (g *GameState) openTrappedChest(opener *party_state.PlayerCharacter) {
+	if g.disarmChestTrap(opener) {
+		g.SystemCallbacks.Message.AddRowStr("Trap disarmed!")
+		return
+	}
+	g.springChestTrap(g.rollChestTrap(), opener)
+}
+
+// rollMonsterChest decides whether a beaten monster leaves a chest, and whether it is trapped - it
+// drops if 1d30 <= treasure value, and is trapped if another 1d30 < treasure value
+func (g *GameState) rollMonsterChest(treasureValue int) (dropped bool, trapped bool) {
+	if g.RandomIntInRange(1, chestTreasureDie) > treasureValue {
+		return false, false
+	}
+	return true, g.RandomIntInRange(1, chestTreasureDie) < treasureValue
+}
+
+// openDungeonChest opens the chest underfoot in the corridors, or next to the party in a room. The
+// Avatar is the one who opens it.
+func (g *GameState) openDungeonChest(direction references.Direction) bool {
+	avatar := &g.PartyState.Characters[0]
+
+	if g.IsInDungeonRoom() {
+		return g.openDungeonRoomChest(direction, avatar)
+	}
+
+	level := g.GetCurrentDungeonLevel()
+	position := g.MapState.PlayerLocation.Position
+	tile := level.GetTile(position)
+	switch {
+	case tile.IsChest():
+		if tile.Detail()&dungeonChestTrapFlags != 0 {
+			g.openTrappedChest(avatar)
+		}
+		level.SetTile(position, references.NewDungeonTile(references.DungeonOpenChest, tile.Detail()&^dungeonChestTrapFlags))
+		g.SystemCallbacks.Message.AddRowStr("Chest opened!")
+	case tile.Type() == references.DungeonOpenChest:
+		g.SystemCallbacks.Message.AddRowStr("Already open!")
+	default:
+		return false
+	}
+
+	g.SystemCallbacks.Flow.AdvanceTime(1)
+	return true
+}
+
+// dungeonChestTrapFlags are the detail bits that mark a dungeon chest as trapped
+const dungeonChestTrapFlags = references.DungeonChestTrapped1 | references.DungeonChestTrapped2 | references.DungeonChestPoisoned
+
+func (g *GameState) openDungeonRoomChest(direction references.Direction, opener *party_state.PlayerCharacter) bool {
+	room := g.Dungeon.Room
+	position := *direction.GetNewPositionInDirection(&room.PartyPosition)
+	trapped, ok := room.Chests[position]
+	if !ok {
+		return false
+	}
+
+	if trapped {
+		g.openTrappedChest(opener)
+	}
+	delete(room.Chests, position)
+	g.SystemCallbacks.Message.AddRowStr("Chest opened!")
+	g.SystemCallbacks.Flow.AdvanceTime(1)
+	return true
+}
+
+// dropDungeonRoomChest leaves a chest where a beaten monster stood, if its treasure roll says so
+func (g *GameState) dropDungeonRoomChest(monster references.CombatMapMonster) {
+	if dropped, trapped := g.rollMonsterChest(g.getDungeonRoomEnemyReference(monster).TreasureNumber); dropped {
+		if g.Dungeon.Room.Chests == nil {
+			g.Dungeon.Room.Chests = make(map[references.Position]bool)
+		}
+		g.Dungeon.Room.Chests[monster.Position] = trapped
+	}
+}
@@ -0,0 +1,175 @@
+package game_state
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+// newDungeonChestTestGameState is the test dungeon with the party of two part way up the corridor
+func newDungeonChestTestGameState(t *testing.T) (*GameState, *MockSystemCallbacks) {
+	gs, mockCallbacks := newDungeonTestGameState(t)
+	for i, name := range []string{"Avatar", "Shamino"} {
+		member := addUnitTestPartyMember(gs, i, name)
+		member.CurrentHp = 100
+		member.MaxHp = 100
+	}
+	gs.EnterDungeon(references.Deceit)
+	gs.MapState.PlayerLocation.Position = references.Position{X: 3, Y: 4}
+	return gs, mockCallbacks
+}
+
+func TestDungeonChests_TreasureDropAndTrapMatrix(t *testing.T) {
+	const nRolls = 6000
+	for _, treasureValue := range []int{0, 5, 10, 15, 20, 25, 30} {
+		gs, _ := newDungeonChestTestGameState(t)
+		gs.SetRandomSeed(uint64(treasureValue) + 1)
+
+		nDropped, nTrapped := 0, 0
+		for range nRolls {
+			dropped, trapped := gs.rollMonsterChest(treasureValue)
+			if dropped {
+				nDropped++
+			}
+			if trapped {
+				nTrapped++
+				if !dropped {
+					t.Fatalf("Treasure %d: a chest that didn't drop can't be trapped", treasureValue)
+				}
+			}
+		}
+
+		if wantDrop := float64(treasureValue) / 30; math.Abs(float64(nDropped)/nRolls-wantDrop) > 0.03 {
+			t.Errorf("Treasure %d: expected to drop %.3f of the time, got %.3f", treasureValue, wantDrop, float64(nDropped)/nRolls)
+		}
+		if nDropped == 0 {
+			continue
+		}
+		if wantTrap := float64(treasureValue-1) / 30; math.Abs(float64(nTrapped)/float64(nDropped)-wantTrap) > 0.04 {
+			t.Errorf("Treasure %d: expected dropped chests to be trapped %.3f of the time, got %.3f",
+				treasureValue, wantTrap, float64(nTrapped)/float64(nDropped))
+		}
+	}
+}
+
+func TestDungeonChests_TrapsAreChosenInEighths(t *testing.T) {
+	gs, _ := newDungeonChestTestGameState(t)
+	const nRolls = 8000
+	counts := make(map[ChestTrap]int)
+	for range nRolls {
+		counts[gs.rollChestTrap()]++
+	}
+
+	for trap, eighths := range map[ChestTrap]int{AcidChestTrap: 3, PoisonChestTrap: 2, BombChestTrap: 2, GasChestTrap: 1} {
+		if got, want := float64(counts[trap])/nRolls, float64(eighths)/8; math.Abs(got-want) > 0.02 {
+			t.Errorf("Expected trap %d %.3f of the time, got %.3f", trap, want, got)
+		}
+	}
+}
+
+func TestDungeonChests_EachTrapHurtsTheRightPeople(t *testing.T) {
+	gs, mockCallbacks := newDungeonChestTestGameState(t)
+	avatar, shamino := &gs.PartyState.Characters[0], &gs.PartyState.Characters[1]
+
+	gs.springChestTrap(AcidChestTrap, avatar)
+	mockCallbacks.AssertLastMessage("ACID!")
+	mockCallbacks.AssertSoundEffectPlayed(SoundTrapTrigger)
+	if avatar.CurrentHp >= 100 || shamino.CurrentHp != 100 {
+		t.Errorf("Expected acid to burn only the opener, got %d and %d", avatar.CurrentHp, shamino.CurrentHp)
+	}
+
+	gs.springChestTrap(PoisonChestTrap, avatar)
+	mockCallbacks.AssertLastMessage("POISON!")
+	if avatar.Status != party_state.Poisoned || shamino.Status != party_state.Good {
+		t.Errorf("Expected poison to reach only the opener")
+	}
+
+	avatarHp := avatar.CurrentHp
+	gs.springChestTrap(BombChestTrap, avatar)
+	mockCallbacks.AssertLastMessage("BOMB!")
+	if avatar.CurrentHp >= avatarHp || shamino.CurrentHp >= 100 || shamino.CurrentHp < 100-bombDamageMax {
+		t.Errorf("Expected the bomb to hurt everyone for up to %d, got %d and %d", bombDamageMax, avatar.CurrentHp, shamino.CurrentHp)
+	}
+
+	gs.springChestTrap(GasChestTrap, avatar)
+	mockCallbacks.AssertLastMessage("GAS!")
+	if shamino.Status != party_state.Poisoned {
+		t.Errorf("Expected the gas to poison the whole party")
+	}
+}
+
+func TestDungeonChests_DexterousOpenersDisarmTraps(t *testing.T) {
+	gs, mockCallbacks := newDungeonChestTestGameState(t)
+	gs.PartyState.Characters[0].Dexterity = 50
+	chest := references.NewDungeonTile(references.DungeonChest, references.DungeonChestTrapped1|references.DungeonChestPoisoned)
+	gs.GetCurrentDungeonLevel().SetTile(gs.MapState.PlayerLocation.Position, chest)
+
+	if !gs.ActionOpenDungeonMap(references.NoneDirection) {
+		t.Fatalf("Expected to open the chest underfoot")
+	}
+	mockCallbacks.AssertMessageContains("Trap disarmed!")
+	mockCallbacks.AssertLastMessage("Chest opened!")
+	mockCallbacks.AssertTimeAdvanced(1)
+	if tile := gs.GetCurrentDungeonLevel().GetTile(gs.MapState.PlayerLocation.Position); tile.Type() != references.DungeonOpenChest || tile.Detail() != 0 {
+		t.Errorf("Expected an open, untrapped chest, got %v", tile)
+	}
+
+	gs.ActionOpenDungeonMap(references.NoneDirection)
+	mockCallbacks.AssertLastMessage("Already open!")
+
+	gs.MapState.PlayerLocation.Position = references.Position{X: 3, Y: 5}
+	if gs.ActionOpenDungeonMap(references.NoneDirection) {
+		t.Errorf("Expected nothing to open in the bare corridor")
+	}
+}
+
+func TestDungeonChests_DeepLevelsAreHarderToDisarm(t *testing.T) {
+	gs, _ := newDungeonChestTestGameState(t)
+	opener := &gs.PartyState.Characters[0]
+	opener.Dexterity = 20
+
+	countDisarmed := func() int {
+		nDisarmed := 0
+		for range 3000 {
+			if gs.disarmChestTrap(opener) {
+				nDisarmed++
+			}
+		}
+		return nDisarmed
+	}
+	shallow := countDisarmed()
+	gs.MapState.PlayerLocation.Floor = references.DungeonLevels - 1
+	deep := countDisarmed()
+
+	// (30 + 2*level - 20) / 2 is 5 on the first level and 12 on the last, out of 30
+	if shallow <= deep || math.Abs(float64(shallow)/3000-25.0/30) > 0.03 || math.Abs(float64(deep)/3000-18.0/30) > 0.03 {
+		t.Errorf("Expected to disarm about 25 in 30 up top and 18 in 30 at the bottom, got %d and %d of 3000", shallow, deep)
+	}
+}
+
+func TestDungeonChests_BeatenMonstersLeaveChestsInRooms(t *testing.T) {
+	gs, mockCallbacks := newDungeonRoomTestGameState(t)
+	enemyRefs := make(references.EnemyReferences, 21)
+	// the rat's key frame is the 21st enemy's - and this one is rich
+	enemyRefs[20].TreasureNumber = 30
+	gs.GameReferences.EnemyReferences = &enemyRefs
+	gs.PartyState.Characters[0].Dexterity = 50
+	gs.ActionMoveDungeonMap(references.Up)
+
+	gs.DefeatDungeonRoomMonster(references.Position{X: 1, Y: 1})
+	if gs.GetDungeonRoomTile(references.Position{X: 1, Y: 1}) != indexes.Chest {
+		t.Fatalf("Expected the rat to leave a chest")
+	}
+
+	gs.Dungeon.Room.PartyPosition = references.Position{X: 1, Y: 2}
+	if !gs.ActionOpenDungeonMap(references.Up) {
+		t.Fatalf("Expected to open the chest north of the party")
+	}
+	mockCallbacks.AssertLastMessage("Chest opened!")
+	if gs.GetDungeonRoomTile(references.Position{X: 1, Y: 1}) != indexes.BrickFloor {
+		t.Errorf("Expected the opened chest to be gone")
+	}
+}
@@ -0,0 +1,100 @@
+package game_state
+
+import (
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// The fixtures of a dungeon level are its ladders, fountains and traps (the pits). Searching the tile
+// ahead needs light, turns up secret doors and invisible traps, and judges how hard a trap looks.
+// Fountains are drunk from with Use and share the fixture effects of fountains on the surface.
+// See Dungeon.md.
+
+// dungeonFixtureSearches is what searching a fixture with nothing hidden in it finds
+var dungeonFixtureSearches = map[references.DungeonTileType]string{
+	references.DungeonLadderUp:     "Nothing hidden on the ladder.",
+	references.DungeonLadderDown:   "Nothing hidden on the ladder.",
+	references.DungeonLadderUpDown: "Nothing hidden on the ladder.",
+	references.DungeonFountain:     "Nothing hidden on the fountain.",
+}
+
+// dungeonFountainEffects is what drinking from each kind of dungeon fountain does
+var dungeonFountainEffects = map[references.DungeonFountainType]FixtureEffect{
+	references.CurePoisonDungeonFountain: {Use: CurePoisonFixtureEffect},
+	references.HealDungeonFountain:       {Use: HealFixtureEffect, AmountMin: 5, AmountMax: 15},
+	references.PoisonDungeonFountain:     {Use: PoisonFixtureEffect},
+	references.BadTasteDungeonFountain:   {Use: DamageFixtureEffect, AmountMin: 1, AmountMax: 5},
+}
+
+// searchDungeonAhead searches the tile in front of the party - dungeons are always searched ahead,
+// whichever way was asked
+func (g *GameState) searchDungeonAhead() bool {
+	if g.IsInDungeonRoom() {
+		g.SystemCallbacks.Message.AddRowStr("Not now!")
+		return false
+	}
+	g.SystemCallbacks.Message.AddRowStr("You find:")
+	if !g.MapState.Lighting.HasAvatarLight() {
+		g.SystemCallbacks.Message.AddRowStr("darkness.")
+		return false
+	}
+
+	level := g.GetCurrentDungeonLevel()
+	position := g.getDungeonPositionAhead(1)
+	tile := level.GetTile(position)
+	switch tile.Type() {
+	case references.DungeonSecretDoor:
+		level.SetTile(position, references.NewDungeonTile(references.DungeonDoor, 0))
+		g.SystemCallbacks.Message.AddRowStr("A secret door!")
+	case references.DungeonTrap:
+		if tile.GetTrapType() == references.InvisibleDungeonTrap {
+			level.SetTile(position, references.NewDungeonTile(references.DungeonTrap, byte(references.LowerVisibleDungeonTrap)))
+		}
+		g.SystemCallbacks.Message.AddRowStr(g.judgeDungeonTrap())
+	default:
+		if found, ok := dungeonFixtureSearches[tile.Type()]; ok {
+			g.SystemCallbacks.Message.AddRowStr(found)
+		} else {
+			g.SystemCallbacks.Message.AddRowStr("Nothing of note.")
+		}
+	}
+
+	g.SystemCallbacks.Flow.AdvanceTime(1)
+	return true
+}
+
+// judgeDungeonTrap is how hard the Avatar reckons a trap is. A dexterous eye reads the level's own
+// difficulty, anyone else guesses.
+func (g *GameState) judgeDungeonTrap() string {
+	floor := int(g.MapState.PlayerLocation.Floor)
+	difficulty := (chestTreasureDie + 2*floor - int(g.PartyState.Characters[0].Dexterity)) / 2
+
+	trapLevel := floor
+	if g.RandomIntInRange(1, chestTreasureDie) <= difficulty {
+		trapLevel = g.RandomIntInRange(1, 8)
+	}
+
+	switch {
+	case trapLevel < 4:
+		return "A simple trap"
+	case trapLevel >= 7:
+		return "A complex trap"
+	default:
+		return "A trap"
+	}
+}
+
+// useDungeonFixture drinks from a fountain ahead of the party. It is false if there isn't one.
+func (g *GameState) useDungeonFixture() bool {
+	if g.IsInDungeonRoom() {
+		return false
+	}
+	tile := g.GetDungeonTileAhead(1)
+	if tile.Type() != references.DungeonFountain {
+		return false
+	}
+	effect, ok := dungeonFountainEffects[tile.GetFountainType()]
+	if !ok {
+		return false
+	}
+	return g.useFixture(effect, nil, nil)
+}
@@ -0,0 +1,82 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+func TestDungeonFixtures_SearchingNeedsLight(t *testing.T) {
+	gs, mockCallbacks := newDungeonChestTestGameState(t)
+
+	if gs.ActionSearchDungeonMap(references.Up) {
+		t.Errorf("Expected to find nothing in the dark")
+	}
+	mockCallbacks.AssertLastMessage("darkness.")
+}
+
+func TestDungeonFixtures_SearchRevealsHiddenFeatures(t *testing.T) {
+	gs, mockCallbacks := newDungeonChestTestGameState(t)
+	gs.MapState.Lighting.LightTorch()
+	level := gs.GetCurrentDungeonLevel()
+	ahead := references.Position{X: 3, Y: 3}
+
+	level.SetTile(ahead, references.NewDungeonTile(references.DungeonSecretDoor, 0))
+	if !gs.ActionSearchDungeonMap(references.Up) {
+		t.Fatalf("Expected to find the secret door")
+	}
+	mockCallbacks.AssertLastMessage("A secret door!")
+	mockCallbacks.AssertTimeAdvanced(1)
+	if level.GetTile(ahead).Type() != references.DungeonDoor {
+		t.Errorf("Expected the secret door to become a door")
+	}
+
+	level.SetTile(ahead, references.NewDungeonTile(references.DungeonTrap, byte(references.InvisibleDungeonTrap)))
+	gs.ActionSearchDungeonMap(references.Up)
+	mockCallbacks.AssertLastMessage("A simple trap")
+	if level.GetTile(ahead).GetTrapType() != references.LowerVisibleDungeonTrap {
+		t.Errorf("Expected the invisible trap to be seen")
+	}
+
+	level.SetTile(ahead, references.NewDungeonTile(references.DungeonLadderDown, 0))
+	gs.ActionSearchDungeonMap(references.Up)
+	mockCallbacks.AssertLastMessage("Nothing hidden on the ladder.")
+
+	level.SetTile(ahead, references.NewDungeonTile(references.DungeonNothing, 0))
+	gs.ActionSearchDungeonMap(references.Up)
+	mockCallbacks.AssertLastMessage("Nothing of note.")
+}
+
+func TestDungeonFixtures_ADeftEyeJudgesTrapsByTheirLevel(t *testing.T) {
+	gs, mockCallbacks := newDungeonChestTestGameState(t)
+	gs.MapState.Lighting.LightTorch()
+	gs.PartyState.Characters[0].Dexterity = 99
+	gs.MapState.PlayerLocation.Floor = references.DungeonLevels - 1
+	gs.GetCurrentDungeonLevel().SetTile(references.Position{X: 3, Y: 3}, references.NewDungeonTile(references.DungeonTrap, 0))
+
+	gs.ActionSearchDungeonMap(references.Up)
+	mockCallbacks.AssertLastMessage("A complex trap")
+}
+
+func TestDungeonFixtures_DrinkingFromFountains(t *testing.T) {
+	gs, mockCallbacks := newDungeonChestTestGameState(t)
+	level := gs.GetCurrentDungeonLevel()
+	ahead := references.Position{X: 3, Y: 3}
+
+	level.SetTile(ahead, references.NewDungeonTile(references.DungeonFountain, byte(references.BadTasteDungeonFountain)))
+	if !gs.ActionUseDungeonMap(references.Up) {
+		t.Fatalf("Expected to drink from the fountain")
+	}
+	mockCallbacks.AssertLastMessage("Bad taste!")
+	if hp := gs.PartyState.Characters[0].CurrentHp; hp < 95 || hp > 99 {
+		t.Errorf("Expected the bad water to cost 1 to 5 hit points, got to %d", hp)
+	}
+
+	level.SetTile(ahead, references.NewDungeonTile(references.DungeonFountain, byte(references.HealDungeonFountain)))
+	gs.ActionUseDungeonMap(references.Up)
+	mockCallbacks.AssertLastMessage("Refreshed!")
+
+	level.SetTile(ahead, references.NewDungeonTile(references.DungeonNothing, 0))
+	gs.ActionUseDungeonMap(references.Up)
+	mockCallbacks.AssertLastMessage("Nothing happens.")
+}
@@ -0,0 +1,268 @@
+package game_state
+
+import (
+	"slices"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+// Stepping onto a room tile drops the party out of the first-person view and into that room's 11x11
+// map from DUNGEON.CBT, lined up on the side they came in from. Walking off any edge of the room
+// leaves it, out onto the dungeon tile on that side and facing the way they walked. A room left with
+// none of its monsters standing stays cleared for good - SAVED.GAM keeps a bit for each of the rooms.
+// See Dungeon.md.
+
+// lbClearedDungeonRooms is a bit for each of DUNGEON.CBT's 112 rooms, sixteen to a dungeon
+const lbClearedDungeonRooms = 0x33A
+
+const clearedDungeonRoomsSize = 14
+
+// ClearedDungeonRooms has a bit for each dungeon room, in DUNGEON.CBT order, set once it is beaten
+type ClearedDungeonRooms [clearedDungeonRoomsSize]byte
+
+func getClearedDungeonRoomBit(location references.Location, nRoom int) (nByte int, mask byte, ok bool) {
+	nDungeon := slices.Index(references.GetListOfAllDungeons(), location)
+	nBit := nDungeon*references.DungeonRoomsPerDungeon + nRoom
+	if nDungeon < 0 || nRoom < 0 || nRoom >= references.DungeonRoomsPerDungeon || nBit/8 >= clearedDungeonRoomsSize {
+		return 0, 0, false
+	}
+	return nBit / 8, 1 << (nBit % 8), true
+}
+
+// IsCleared is true when the dungeon's room has been beaten
+func (c *ClearedDungeonRooms) IsCleared(location references.Location, nRoom int) bool {
+	nByte, mask, ok := getClearedDungeonRoomBit(location, nRoom)
+	return ok && c[nByte]&mask != 0
+}
+
+func (c *ClearedDungeonRooms) setCleared(location references.Location, nRoom int) {
+	if nByte, mask, ok := getClearedDungeonRoomBit(location, nRoom); ok {
+		c[nByte] |= mask
+	}
+}
+
+// DungeonRoomState is the room the party is in, as the party has left it
+type DungeonRoomState struct {
+	Number int
+	// Map is a copy of the room so that the triggers stay sprung until the party leaves it
+	Map           references.CombatMapReference
+	Monsters      []references.CombatMapMonster
+	PartyPosition references.Position
+	// Chests are the chests beaten monsters left behind, and whether each is trapped
+	Chests map[references.Position]bool
+
+	// monsterHitPoints are what the wounded monsters have left, by where they stand
+	monsterHitPoints map[references.Position]int
+	sprungTriggers   [references.MaxCombatMapTriggers]bool
+}
+
+// IsInDungeonRoom is true when the party is in one of a dungeon's rooms rather than its corridors
+func (g *GameState) IsInDungeonRoom() bool {
+	return g.Dungeon.Room != nil
+}
+
+// IsDungeonRoomCleared is true when the given room of the dungeon the party is in has already been beaten
+func (g *GameState) IsDungeonRoomCleared(nRoom int) bool {
+	return g.ClearedDungeonRooms.IsCleared(g.MapState.PlayerLocation.Location, nRoom)
+}
+
+// enterDungeonRoom puts the party in the room under them, coming in from the side they were
+// travelling away from. It is false if the room has already been cleared.
+func (g *GameState) enterDungeonRoom(travelling references.Direction) bool {
+	nRoom := g.GetCurrentDungeonLevel().GetTile(g.MapState.PlayerLocation.Position).GetRoomNumber()
+	if g.IsDungeonRoomCleared(nRoom) {
+		return false
+	}
+	roomRef := g.GameReferences.DungeonReferences.GetDungeon(g.MapState.PlayerLocation.Location).GetRoom(nRoom)
+	if roomRef == nil {
+		return false
+	}
+
+	g.Dungeon.Room = &DungeonRoomState{
+		Number:        nRoom,
+		Map:           *roomRef,
+		Monsters:      slices.Clone(roomRef.Monsters),
+		PartyPosition: roomRef.GetPartyPosition(references.GetCombatMapEntryDirection(travelling), 0),
+	}
+	return true
+}
+
+// moveInDungeonRoom walks the party a tile in the room - off the edge of the room takes them back
+// out to the corridors
+func (g *GameState) moveInDungeonRoom(direction references.Direction) bool {
+	room := g.Dungeon.Room
+	g.SystemCallbacks.Message.AddRowStr(direction.GetDirectionCompassName())
+
+	newPosition := *direction.GetNewPositionInDirection(&room.PartyPosition)
+	if !room.Map.IsInBounds(newPosition) {
+		g.leaveDungeonRoom(direction)
+		return true
+	}
+
+	if !g.isDungeonRoomPositionPassable(newPosition) {
+		g.SystemCallbacks.Message.AddRowStr("Blocked!")
+		return false
+	}
+
+	room.PartyPosition = newPosition
+	g.springDungeonRoomTriggers()
+	return true
+}
+
+func (g *GameState) isDungeonRoomPositionPassable(position references.Position) bool {
+	if g.GetDungeonRoomMonsterAt(position) != nil {
+		return false
+	}
+	tile := g.GameReferences.TileReferences.GetTile(g.Dungeon.Room.Map.GetTile(position))
+	return tile != nil && tile.IsWalkingPassable()
+}
+
+// GetDungeonRoomMonsterAt is the monster standing on the given room position, nil if there is none
+func (g *GameState) GetDungeonRoomMonsterAt(position references.Position) *references.CombatMapMonster {
+	for i := range g.Dungeon.Room.Monsters {
+		if g.Dungeon.Room.Monsters[i].Position == position {
+			return &g.Dungeon.Room.Monsters[i]
+		}
+	}
+	return nil
+}
+
+// DefeatDungeonRoomMonster takes a beaten monster out of the room, perhaps leaving a chest where it
+// stood. It is false if nothing was standing there.
+func (g *GameState) DefeatDungeonRoomMonster(position references.Position) bool {
+	monster := g.GetDungeonRoomMonsterAt(position)
+	if monster == nil {
+		return false
+	}
+	beaten := *monster
+
+	g.Dungeon.Room.Monsters = slices.DeleteFunc(g.Dungeon.Room.Monsters, func(standing references.CombatMapMonster) bool {
+		return standing.Position == position
+	})
+	g.dropDungeonRoomChest(beaten)
+	return true
+}
+
+// getDungeonRoomEnemyReference is the monster's entry in the enemy references - a monster without one
+// has nothing to fight with and nothing to leave behind
+func (g *GameState) getDungeonRoomEnemyReference(monster references.CombatMapMonster) references.EnemyReference {
+	if g.GameReferences.EnemyReferences != nil {
+		if enemyRef := g.GameReferences.EnemyReferences.GetEnemyReferenceByKeyFrameTile(monster.Tile); enemyRef != nil {
+			return *enemyRef
+		}
+	}
+	return references.EnemyReference{}
+}
+
+// attackDungeonRoomMonster has the Avatar swing at the monster beside them. The blow lands on a d30
+// against the difference in dexterity, and the monster's armour soaks up some of the damage. A
+// monster out of hit points is beaten. See Combat_Core.md.
+func (g *GameState) attackDungeonRoomMonster(direction references.Direction) bool {
+	room := g.Dungeon.Room
+	position := *direction.GetNewPositionInDirection(&room.PartyPosition)
+	monster := g.GetDungeonRoomMonsterAt(position)
+	if monster == nil {
+		return false
+	}
+	g.SystemCallbacks.Flow.AdvanceTime(1)
+
+	enemyRef := g.getDungeonRoomEnemyReference(*monster)
+	avatar := &g.PartyState.Characters[0]
+	if g.RandomIntInRange(1, 30) < (30+enemyRef.Dexterity-int(avatar.Dexterity))/2 {
+		g.SystemCallbacks.Message.AddRowStr("Missed!")
+		return true
+	}
+
+	damage := g.RandomIntInRange(1, avatar.GetAttackValue())
+	if enemyRef.Armour > 0 {
+		damage -= g.RandomIntInRange(1, enemyRef.Armour)
+	}
+	if damage <= 0 {
+		g.SystemCallbacks.Message.AddRowStr("Grazed!")
+		return true
+	}
+
+	hitPoints, wounded := room.monsterHitPoints[position]
+	if !wounded {
+		hitPoints = enemyRef.HitPoints
+	}
+	hitPoints -= damage
+	if hitPoints <= 0 {
+		delete(room.monsterHitPoints, position)
+		g.SystemCallbacks.Message.AddRowStr("Killed!")
+		g.DefeatDungeonRoomMonster(position)
+		return true
+	}
+
+	if room.monsterHitPoints == nil {
+		room.monsterHitPoints = make(map[references.Position]int)
+	}
+	room.monsterHitPoints[position] = hitPoints
+	g.SystemCallbacks.Message.AddRowStr(getMonsterWoundMessage(hitPoints, enemyRef.HitPoints))
+	return true
+}
+
+// getMonsterWoundMessage describes a wounded monster by the quarter of its hit points it has left
+func getMonsterWoundMessage(hitPoints, maxHitPoints int) string {
+	switch nQuarters := (hitPoints*4 + maxHitPoints - 1) / maxHitPoints; {
+	case nQuarters >= 4:
+		return "Barely wounded!"
+	case nQuarters == 3:
+		return "Lightly wounded!"
+	case nQuarters == 2:
+		return "Heavily wounded!"
+	default:
+		return "Critical!"
+	}
+}
+
+// springDungeonRoomTriggers changes the tiles of any trigger the party has just stepped on - each
+// trigger only goes off once a visit
+func (g *GameState) springDungeonRoomTriggers() {
+	room := g.Dungeon.Room
+	for nTrigger, trigger := range room.Map.Triggers {
+		if room.sprungTriggers[nTrigger] || trigger.Position != room.PartyPosition {
+			continue
+		}
+		room.sprungTriggers[nTrigger] = true
+		for _, position := range trigger.ChangedPositions {
+			if room.Map.IsInBounds(position) {
+				room.Map.Tiles[position.Y][position.X] = trigger.NewTile
+			}
+		}
+	}
+}
+
+// leaveDungeonRoom returns the party to the first-person view facing the way they walked out, a tile
+// along from the room if the way is open
+func (g *GameState) leaveDungeonRoom(direction references.Direction) {
+	if len(g.Dungeon.Room.Monsters) == 0 {
+		g.ClearedDungeonRooms.setCleared(g.MapState.PlayerLocation.Location, g.Dungeon.Room.Number)
+		copy(g.RawSave[lbClearedDungeonRooms:], g.ClearedDungeonRooms[:])
+	}
+	g.Dungeon.Room = nil
+	g.Dungeon.Facing = direction
+
+	outside := direction.GetNewPositionInDirection(&g.MapState.PlayerLocation.Position).
+		GetWrapped(references.XDungeonTiles, references.YDungeonTiles)
+	if g.GetCurrentDungeonLevel().GetTile(*outside).IsPassable() {
+		g.MapState.PlayerLocation.Position = *outside
+	}
+}
+
+// GetDungeonRoomTile is the sprite to draw at a position in the room - the party and the monsters
+// stand on top of the map
+func (g *GameState) GetDungeonRoomTile(position references.Position) indexes.SpriteIndex {
+	room := g.Dungeon.Room
+	if position == room.PartyPosition {
+		return indexes.Avatar_KeyIndex
+	}
+	if monster := g.GetDungeonRoomMonsterAt(position); monster != nil {
+		return monster.Tile
+	}
+	if _, ok := room.Chests[position]; ok {
+		return indexes.Chest
+	}
+	return room.Map.GetTile(position)
+}
@@ -0,0 +1,169 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+// newDungeonRoomTestGameState is the test dungeon with a room halfway up the corridor at (3, 4). The
+// room is brick floor with a rat in the corner, and a trigger just inside the south side that walls
+// off the way north.
+func newDungeonRoomTestGameState(t *testing.T) (*GameState, *MockSystemCallbacks) {
+	gs, mockCallbacks := newDungeonTestGameState(t)
+	gs.GameReferences.TileReferences = references.NewTileReferences()
+
+	const rowSize = 32
+	rawRoomData := make([]byte, rowSize*int(references.YCombatMapTiles)*references.DungeonRoomsPerDungeon)
+	at := func(nRow, nColumn int) *byte {
+		return &rawRoomData[nRow*rowSize+nColumn]
+	}
+	for y := 0; y < int(references.YCombatMapTiles); y++ {
+		for x := 0; x < int(references.XCombatMapTiles); x++ {
+			*at(y, x) = byte(indexes.BrickFloor)
+		}
+	}
+	// coming in from the south the Avatar starts at (5, 10)
+	*at(1+int(references.SouthCombatMapEntry), 11), *at(1+int(references.SouthCombatMapEntry), 17) = 5, 10
+	// a rat at (1, 1)
+	*at(5, 11), *at(6, 11), *at(7, 11) = byte(indexes.Rat_KeyIndex-0x100), 1, 1
+	// stepping on (5, 9) walls up (5, 8) and (4, 8)
+	*at(0, 11) = byte(indexes.StoneBrickWall)
+	*at(8, 11), *at(8, 19) = 5, 9
+	*at(9, 11), *at(9, 19) = 5, 8
+	*at(10, 11), *at(10, 19) = 4, 8
+
+	if err := gs.GameReferences.DungeonReferences.AddRoomsFromBytes(rawRoomData); err != nil {
+		t.Fatalf("Failed to build the test rooms: %v", err)
+	}
+	gs.EnterDungeon(references.Deceit)
+	gs.GetCurrentDungeonLevel().SetTile(references.Position{X: 3, Y: 4}, references.NewDungeonTile(references.DungeonRoom, 0))
+	gs.MapState.PlayerLocation.Position = references.Position{X: 3, Y: 5}
+	return gs, mockCallbacks
+}
+
+func TestDungeonRoom_SteppingOnARoomTileEntersTheRoom(t *testing.T) {
+	gs, _ := newDungeonRoomTestGameState(t)
+
+	gs.ActionMoveDungeonMap(references.Up)
+	if !gs.IsInDungeonRoom() {
+		t.Fatalf("Expected to be in the room")
+	}
+	if gs.Dungeon.Room.PartyPosition != (references.Position{X: 5, Y: 10}) {
+		t.Errorf("Expected to come in on the south side, got %v", gs.Dungeon.Room.PartyPosition)
+	}
+	if monster := gs.GetDungeonRoomMonsterAt(references.Position{X: 1, Y: 1}); monster == nil || monster.Tile != indexes.Rat_KeyIndex {
+		t.Errorf("Expected the rat in the corner, got %v", monster)
+	}
+	if gs.GetDungeonRoomTile(references.Position{X: 5, Y: 10}) != indexes.Avatar_KeyIndex {
+		t.Errorf("Expected the Avatar to be drawn where the party stands")
+	}
+}
+
+func TestDungeonRoom_TriggersChangeTheRoomOnce(t *testing.T) {
+	gs, mockCallbacks := newDungeonRoomTestGameState(t)
+	gs.ActionMoveDungeonMap(references.Up)
+
+	if !gs.ActionMoveDungeonMap(references.Up) {
+		t.Fatalf("Expected to walk onto the trigger")
+	}
+	mockCallbacks.AssertLastMessage("North")
+	for _, position := range []references.Position{{X: 5, Y: 8}, {X: 4, Y: 8}} {
+		if gs.Dungeon.Room.Map.GetTile(position) != indexes.StoneBrickWall {
+			t.Errorf("Expected the trigger to wall up %v", position)
+		}
+	}
+	if gs.ActionMoveDungeonMap(references.Up) {
+		t.Errorf("Expected the new wall to be in the way")
+	}
+	mockCallbacks.AssertLastMessage("Blocked!")
+
+	if original := gs.GameReferences.DungeonReferences.GetDungeon(references.Deceit).GetRoom(0).GetTile(references.Position{X: 5, Y: 8}); original != indexes.BrickFloor {
+		t.Errorf("Expected the room's references not to be changed by the trigger")
+	}
+}
+
+func TestDungeonRoom_LeavingReturnsToTheCorridorFacingTheWayOut(t *testing.T) {
+	gs, _ := newDungeonRoomTestGameState(t)
+	gs.ActionMoveDungeonMap(references.Up)
+
+	gs.ActionMoveDungeonMap(references.Down)
+	if gs.IsInDungeonRoom() {
+		t.Fatalf("Expected walking off the south side to leave the room")
+	}
+	if gs.MapState.PlayerLocation.Position != (references.Position{X: 3, Y: 5}) || gs.Dungeon.Facing != references.Down {
+		t.Errorf("Expected to be south of the room facing south, got %v facing %v",
+			gs.MapState.PlayerLocation.Position, gs.Dungeon.Facing)
+	}
+
+	// the rat is still there, so the room is waiting for the party
+	gs.ActionMoveDungeonMap(references.Down)
+	if !gs.IsInDungeonRoom() || gs.Dungeon.Room.PartyPosition != (references.Position{X: 5, Y: 10}) {
+		t.Fatalf("Expected to be back in the room")
+	}
+	if gs.ActionKlimbDungeonMap(references.NoneDirection) {
+		t.Errorf("Expected nothing to klimb in a room")
+	}
+}
+
+func TestDungeonRoom_AttackingBeatsTheMonster(t *testing.T) {
+	gs, mockCallbacks := newDungeonRoomTestGameState(t)
+	enemyRefs := make(references.EnemyReferences, 21)
+	// the rat's key frame is the 21st enemy's
+	enemyRefs[20].HitPoints = 4
+	gs.GameReferences.EnemyReferences = &enemyRefs
+	// dexterity well beyond the rat's never misses
+	gs.PartyState.Characters[0].Dexterity = 30
+	gs.ActionMoveDungeonMap(references.Up)
+	gs.Dungeon.Room.PartyPosition = references.Position{X: 1, Y: 2}
+
+	for nSwing := 0; gs.GetDungeonRoomMonsterAt(references.Position{X: 1, Y: 1}) != nil; nSwing++ {
+		if nSwing == 4 {
+			t.Fatalf("Expected four bare-handed blows to beat a rat with 4 hit points")
+		}
+		if !gs.ActionAttackDungeonMap(references.Up) {
+			t.Fatalf("Expected the rat to be there to attack")
+		}
+	}
+	mockCallbacks.AssertLastMessage("Killed!")
+
+	if gs.ActionAttackDungeonMap(references.Up) {
+		t.Errorf("Expected nothing left to attack")
+	}
+}
+
+func TestDungeonRoom_BeatenRoomsStayCleared(t *testing.T) {
+	gs, _ := newDungeonRoomTestGameState(t)
+	gs.ActionMoveDungeonMap(references.Up)
+
+	if !gs.DefeatDungeonRoomMonster(references.Position{X: 1, Y: 1}) {
+		t.Fatalf("Expected to beat the rat")
+	}
+	gs.ActionMoveDungeonMap(references.Down)
+	if !gs.IsDungeonRoomCleared(0) {
+		t.Fatalf("Expected the room to be cleared")
+	}
+	if gs.RawSave[lbClearedDungeonRooms] != 1 {
+		t.Errorf("Expected Deceit's first room to be saved as cleared, got %#x", gs.RawSave[lbClearedDungeonRooms])
+	}
+
+	gs.ActionMoveDungeonMap(references.Down)
+	if gs.IsInDungeonRoom() || gs.MapState.PlayerLocation.Position != (references.Position{X: 3, Y: 4}) {
+		t.Errorf("Expected to walk through the cleared room, got %v", gs.MapState.PlayerLocation.Position)
+	}
+}
+
+func TestClearedDungeonRooms_SixteenBitsToADungeon(t *testing.T) {
+	var cleared ClearedDungeonRooms
+	cleared.setCleared(references.Despise, 3)
+	cleared.setCleared(references.Hythloth, 15)
+	cleared.setCleared(references.Doom, 0)
+
+	if cleared != (ClearedDungeonRooms{2: 0x08, 13: 0x80}) {
+		t.Errorf("Expected Despise's fourth and Hythloth's last room, and none of Doom's, got %v", cleared)
+	}
+	if !cleared.IsCleared(references.Despise, 3) || cleared.IsCleared(references.Deceit, 3) {
+		t.Errorf("Expected only Despise's fourth room to be cleared")
+	}
+}
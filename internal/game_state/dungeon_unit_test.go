@@ -0,0 +1,156 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// newDungeonTestGameState has the party on the overworld in front of a Deceit whose first level is a
+// corridor running north from the entrance ladder at (3, 6), with a ladder down at its far end
+func newDungeonTestGameState(t *testing.T) (*GameState, *MockSystemCallbacks) {
+	rawData := make([]byte, int(references.XDungeonTiles*references.YDungeonTiles)*references.DungeonLevels*references.TotalDungeons)
+	for y := 0; y < int(references.YDungeonTiles); y++ {
+		for x := 0; x < int(references.XDungeonTiles); x++ {
+			if x != 3 || y < 2 || y > 6 {
+				rawData[y*int(references.XDungeonTiles)+x] = byte(references.NewDungeonTile(references.DungeonWall, 0))
+			}
+		}
+	}
+	rawData[6*int(references.XDungeonTiles)+3] = byte(references.NewDungeonTile(references.DungeonLadderUp, 0))
+	rawData[2*int(references.XDungeonTiles)+3] = byte(references.NewDungeonTile(references.DungeonLadderDown, 0))
+
+	dungeonRefs, err := references.NewDungeonReferencesFromBytes(rawData)
+	if err != nil {
+		t.Fatalf("Failed to build the test dungeons: %v", err)
+	}
+
+	gs, mockCallbacks := newUnitTestGameState(t)
+	gs.GameReferences = &references.GameReferences{DungeonReferences: dungeonRefs}
+	gs.MapState.PlayerLocation.Location = references.Britannia_Underworld
+	gs.MapState.PlayerLocation.Position = references.Position{X: 100, Y: 100}
+	return gs, mockCallbacks
+}
+
+func TestDungeon_EnterAtTheEntranceLadderFacingNorth(t *testing.T) {
+	gs, _ := newDungeonTestGameState(t)
+
+	if !gs.EnterDungeon(references.Deceit) {
+		t.Fatalf("Expected to enter Deceit")
+	}
+	if gs.MapState.PlayerLocation.Position != (references.Position{X: 3, Y: 6}) || gs.MapState.PlayerLocation.Floor != 0 {
+		t.Errorf("Expected to start on the entrance ladder, got %v on level %d",
+			gs.MapState.PlayerLocation.Position, gs.MapState.PlayerLocation.Floor)
+	}
+	if gs.Dungeon.Facing != references.Up {
+		t.Errorf("Expected to face north, got %v", gs.Dungeon.Facing)
+	}
+	if gs.LastLargeMapPosition != (references.Position{X: 100, Y: 100}) {
+		t.Errorf("Expected the way back out to be remembered, got %v", gs.LastLargeMapPosition)
+	}
+
+	if gs.EnterDungeon(references.Britain) {
+		t.Errorf("Expected Britain not to be a dungeon")
+	}
+}
+
+func TestDungeon_AdvanceRetreatAndTurn(t *testing.T) {
+	gs, mockCallbacks := newDungeonTestGameState(t)
+	gs.EnterDungeon(references.Deceit)
+
+	if !gs.ActionMoveDungeonMap(references.Up) {
+		t.Fatalf("Expected to advance up the corridor")
+	}
+	mockCallbacks.AssertLastMessage("Advance")
+	if gs.MapState.PlayerLocation.Position != (references.Position{X: 3, Y: 5}) {
+		t.Errorf("Expected to be a tile north, got %v", gs.MapState.PlayerLocation.Position)
+	}
+
+	gs.ActionMoveDungeonMap(references.Right)
+	mockCallbacks.AssertLastMessage("Turn Right")
+	if gs.Dungeon.Facing != references.Right {
+		t.Errorf("Expected to face east, got %v", gs.Dungeon.Facing)
+	}
+	if gs.ActionMoveDungeonMap(references.Up) {
+		t.Errorf("Expected the corridor wall to block the way east")
+	}
+	mockCallbacks.AssertLastMessage("Blocked!")
+
+	gs.ActionMoveDungeonMap(references.Right)
+	if gs.Dungeon.Facing != references.Down {
+		t.Errorf("Expected to face south, got %v", gs.Dungeon.Facing)
+	}
+	if !gs.ActionMoveDungeonMap(references.Down) {
+		t.Fatalf("Expected to retreat north while facing south")
+	}
+	if gs.MapState.PlayerLocation.Position != (references.Position{X: 3, Y: 4}) || gs.Dungeon.Facing != references.Down {
+		t.Errorf("Expected to back up a tile without turning, got %v facing %v", gs.MapState.PlayerLocation.Position, gs.Dungeon.Facing)
+	}
+
+	gs.ActionMoveDungeonMap(references.Left)
+	gs.ActionMoveDungeonMap(references.Left)
+	if tile := gs.GetDungeonTileAhead(2); !tile.IsLadderDown() {
+		t.Errorf("Expected the ladder down two tiles ahead, got %v", tile)
+	}
+}
+
+func TestDungeon_KlimbDownAndBackOut(t *testing.T) {
+	gs, mockCallbacks := newDungeonTestGameState(t)
+	gs.EnterDungeon(references.Deceit)
+
+	gs.ActionMoveDungeonMap(references.Up)
+	if gs.ActionKlimbDungeonMap(references.NoneDirection) {
+		t.Errorf("Expected nothing to klimb in the corridor")
+	}
+
+	gs.MapState.PlayerLocation.Position = references.Position{X: 3, Y: 2}
+	if !gs.ActionKlimbDungeonMap(references.NoneDirection) {
+		t.Fatalf("Expected to klimb down the ladder")
+	}
+	mockCallbacks.AssertLastMessage("Klimb-Down!")
+	mockCallbacks.AssertTimeAdvanced(1)
+	if gs.MapState.PlayerLocation.Floor != 1 {
+		t.Errorf("Expected to be on the second level, got %d", gs.MapState.PlayerLocation.Floor)
+	}
+
+	gs.MapState.PlayerLocation.Floor = 0
+	gs.MapState.PlayerLocation.Position = references.Position{X: 3, Y: 6}
+	gs.ActionKlimbDungeonMap(references.NoneDirection)
+	mockCallbacks.AssertLastMessage("Klimb-Up!")
+	if gs.MapState.PlayerLocation.Location != references.Britannia_Underworld ||
+		gs.MapState.PlayerLocation.Position != (references.Position{X: 100, Y: 100}) {
+		t.Errorf("Expected to be back outside where the party went in, got %v at %v",
+			gs.MapState.PlayerLocation.Location, gs.MapState.PlayerLocation.Position)
+	}
+}
+
+func TestDungeon_ChangesLastUntilTheDungeonIsLeft(t *testing.T) {
+	gs, _ := newDungeonTestGameState(t)
+	gs.EnterDungeon(references.Deceit)
+
+	chest := references.NewDungeonTile(references.DungeonOpenChest, 0)
+	gs.GetCurrentDungeonLevel().SetTile(references.Position{X: 3, Y: 4}, chest)
+
+	if original := gs.GameReferences.DungeonReferences.GetDungeon(references.Deceit).Levels[0].GetTile(references.Position{X: 3, Y: 4}); original == chest {
+		t.Errorf("Expected the dungeon's references not to be changed by the party")
+	}
+}
+
+func TestDungeon_GemShowsTheCurrentLevel(t *testing.T) {
+	gs, mockCallbacks := newDungeonTestGameState(t)
+	gs.EnterDungeon(references.Deceit)
+	gs.PartyState.Inventory.Provisions.Gems.Set(1)
+
+	if !gs.ActionViewDungeonMap() {
+		t.Fatalf("Expected the gem to be used")
+	}
+	mockCallbacks.AssertLastMessage("View dungeon!")
+
+	img := gs.RenderGemView()
+	if img == nil {
+		t.Fatalf("Expected the level to be drawn for the gem that was used")
+	}
+	if img.Bounds().Dx() != img.Bounds().Dy() || img.Bounds().Dx()%int(references.XDungeonTiles) != 0 {
+		t.Errorf("Expected a square drawing of the 8x8 level, got %v", img.Bounds())
+	}
+}
@@ -2,6 +2,8 @@ package game_state
 
 import (
 	"github.com/bradhannah/Ultima5ReduxGo/internal/environment"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_units"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
 )
 
 // messageCallbacksAdapter adapts GameState SystemCallbacks to environment.SystemCallbacks interface
@@ -19,11 +21,7 @@ func (m *messageCallbacksAdapter) AddRowStr(message string) {
 func (gs *GameState) processEnvironmentalHazards() {
 	currentTile := gs.MapState.GetLayeredMapByCurrentLocation().GetTopTile(&gs.MapState.PlayerLocation.Position)
 
-	if gs.environmentalHazards == nil {
-		gs.environmentalHazards = environment.NewEnvironmentalHazards(gs.rng, &messageCallbacksAdapter{gs.SystemCallbacks})
-	}
-
-	hazardResult := gs.environmentalHazards.CheckTileHazards(
+	hazardResult := gs.getEnvironmentalHazards().CheckTileHazards(
 		currentTile,
 		&gs.PartyState,
 		gs.MapState.PlayerLocation.Location.GetMapType(),
@@ -35,6 +33,110 @@ func (gs *GameState) processEnvironmentalHazards() {
 	}
 }
 
+func (gs *GameState) getEnvironmentalHazards() *environment.EnvironmentalHazards {
+	if gs.environmentalHazards == nil {
+		gs.environmentalHazards = environment.NewEnvironmentalHazards(gs.rng, &messageCallbacksAdapter{gs.SystemCallbacks})
+	}
+	return gs.environmentalHazards
+}
+
+// waterfallDrop is how many tiles the party is carried down a waterfall
+const waterfallDrop = 2
+
+// processSeaHazardsAfterMovement checks the tile the party has just moved onto for rough seas and waterfalls
+func (gs *GameState) processSeaHazardsAfterMovement() {
+	if gs.MapState.PlayerLocation.Location.GetMapType() != references.LargeMapType {
+		return
+	}
+
+	hazardResult := gs.getEnvironmentalHazards().CheckSeaHazards(
+		gs.GetCurrentLayeredMapAvatarTopTile(),
+		&gs.PartyState,
+		gs.PartyVehicle.GetVehicleDetails().VehicleType,
+	)
+	gs.applyHazardResult(hazardResult)
+}
+
+// processLargeMapHazardsOnTurnAdvancement rolls for the hazards that find the party wherever they
+// are - earthquakes in the underworld, and whirlpools at sea
+func (gs *GameState) processLargeMapHazardsOnTurnAdvancement() {
+	if gs.MapState.PlayerLocation.Location.GetMapType() != references.LargeMapType {
+		return
+	}
+
+	hazards := gs.getEnvironmentalHazards()
+	if gs.MapState.IsUnderworld() {
+		gs.applyHazardResult(hazards.CheckUnderworldEarthquake(&gs.PartyState))
+	}
+
+	gs.applyHazardResult(hazards.CheckWhirlpools(
+		gs.MapState.PlayerLocation.Position,
+		gs.getWhirlpoolPositions(),
+		gs.PartyVehicle.GetVehicleDetails().VehicleType,
+	))
+}
+
+func (gs *GameState) getWhirlpoolPositions() []references.Position {
+	if gs.CurrentNPCAIController == nil {
+		return nil
+	}
+
+	var positions []references.Position
+	for _, mapUnit := range *gs.CurrentNPCAIController.GetNpcs() {
+		if enemy, ok := mapUnit.(*map_units.NPCEnemy); ok && enemy.IsWhirlpool() && enemy.IsVisible() {
+			positions = append(positions, enemy.Pos())
+		}
+	}
+	return positions
+}
+
+// applyHazardResult carries out the parts of a hazard that reach beyond the party - where the
+// party ends up
+func (gs *GameState) applyHazardResult(hazardResult environment.HazardResult) {
+	if hazardResult.Type == environment.NoHazard {
+		return
+	}
+	gs.logEnvironmentalHazard(hazardResult)
+
+	if hazardResult.DamageDealt > 0 {
+		gs.SystemCallbacks.Screen.MarkStatsChanged()
+	}
+
+	switch hazardResult.Type { //nolint:exhaustive
+	case environment.UnderworldEarthquake:
+		gs.SystemCallbacks.Audio.PlaySoundEffect(SoundEarthquake)
+	case environment.WaterfallFall:
+		gs.goDownWaterfall()
+	case environment.WhirlpoolPull:
+		gs.SystemCallbacks.Flow.DelayFx()
+		gs.enterUnderworld()
+	}
+}
+
+// goDownWaterfall carries the party down the falls, as far as their craft can go.
+// TODO: at_underworld_fall_spot (Movement_Overworld.md) is not documented, so no falls drop on into
+// the underworld yet
+func (gs *GameState) goDownWaterfall() {
+	for range waterfallDrop {
+		below := gs.MapState.PlayerLocation.Position.GetPositionDown().
+			GetWrapped(references.XLargeMapTiles, references.YLargeMapTiles)
+		if !gs.IsPassable(below) {
+			return
+		}
+		gs.movePartyTo(*below)
+		gs.SystemCallbacks.Visual.DelayGlide()
+	}
+}
+
+// enterUnderworld takes the party, and whatever they are sailing, straight down to the same spot in
+// the underworld
+func (gs *GameState) enterUnderworld() {
+	gs.MapState.PlayerLocation.Floor = references.FloorNumber(references.UNDERWORLD)
+	gs.CurrentNPCAIController = gs.GetCurrentLargeMapNPCAIController()
+	gs.MapState.UpdateLargeMap()
+	gs.SystemCallbacks.Screen.MarkStatsChanged()
+}
+
 // logEnvironmentalHazard provides debug logging for environmental hazard events
 func (gs *GameState) logEnvironmentalHazard(hazardResult environment.HazardResult) {
 	// This could be expanded for more detailed logging if needed
@@ -44,9 +146,11 @@ func (gs *GameState) logEnvironmentalHazard(hazardResult environment.HazardResul
 // ProcessEnvironmentalHazardsAfterMovement should be called after player movement
 func (gs *GameState) ProcessEnvironmentalHazardsAfterMovement() {
 	gs.processEnvironmentalHazards()
+	gs.processSeaHazardsAfterMovement()
 }
 
 // ProcessEnvironmentalHazardsOnTurnAdvancement should be called during turn processing for standing-on-tile effects
 func (gs *GameState) ProcessEnvironmentalHazardsOnTurnAdvancement() {
 	gs.processEnvironmentalHazards()
+	gs.processLargeMapHazardsOnTurnAdvancement()
 }
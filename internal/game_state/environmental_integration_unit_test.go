@@ -0,0 +1,102 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/ai"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/environment"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_units"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+func TestApplyHazardResult_WhirlpoolTakesTheShipToTheUnderworld(t *testing.T) {
+	gs, mockCallbacks := newShipTestGameState(t)
+	position := gs.MapState.PlayerLocation.Position
+
+	gs.applyHazardResult(environment.HazardResult{Type: environment.WhirlpoolPull})
+
+	if !gs.MapState.IsUnderworld() || gs.MapState.PlayerLocation.Position != position {
+		t.Errorf("Expected the party straight below in the underworld, got %v on level %d",
+			gs.MapState.PlayerLocation.Position, gs.MapState.PlayerLocation.Floor)
+	}
+	if gs.PartyVehicle.GetVehicleDetails().VehicleType != references.FrigateVehicle {
+		t.Errorf("Expected the frigate to go with them")
+	}
+	if mockCallbacks.DelayFxCalls == 0 {
+		t.Errorf("Expected a pause while the whirlpool does its work")
+	}
+}
+
+// newWaterfallTestGameState has a skiff at the top of falls at 100,100 with water, then more water
+// and then mountains below
+func newWaterfallTestGameState(t *testing.T) (*GameState, *MockSystemCallbacks) {
+	tiles := references.Tiles{
+		0:                      &references.Tile{Index: 0},
+		indexes.Water1:         &references.Tile{Index: indexes.Water1},
+		indexes.SmallMountains: &references.Tile{Index: indexes.SmallMountains, SpeedFactor: -1},
+	}
+
+	gs, mockCallbacks := newSailingTestGameState(t, references.SkiffVehicle)
+	gs.CurrentNPCAIController = ai.NewNPCAIControllerLargeMap(ai.NewNPCAIControllerLargeMapInput{})
+	gs.MapState.LayeredMaps = *map_state.NewLayeredMaps(&tiles, &references.LargeMapReference{}, &references.LargeMapReference{}, 19, 13)
+	overworld := gs.MapState.LayeredMaps.GetLayeredMap(references.LargeMapType, 0)
+	for y := references.Coordinate(101); y <= 104; y++ {
+		overworld.SetTileByLayer(map_state.MapLayer, &references.Position{X: 100, Y: y}, indexes.Water1)
+	}
+	overworld.SetTileByLayer(map_state.MapLayer, &references.Position{X: 100, Y: 105}, indexes.SmallMountains)
+	return gs, mockCallbacks
+}
+
+func TestApplyHazardResult_WaterfallCarriesThePartyDownTheFalls(t *testing.T) {
+	gs, mockCallbacks := newWaterfallTestGameState(t)
+
+	gs.applyHazardResult(environment.HazardResult{Type: environment.WaterfallFall})
+
+	if gs.MapState.PlayerLocation.Position != (references.Position{X: 100, Y: 102}) {
+		t.Errorf("Expected to be carried two tiles down the falls, got %v", gs.MapState.PlayerLocation.Position)
+	}
+	if gs.MapState.IsUnderworld() {
+		t.Errorf("Expected the falls to stay in the overworld")
+	}
+	mockCallbacks.AssertNoMessages()
+}
+
+func TestApplyHazardResult_WaterfallStopsShortOfImpassableGround(t *testing.T) {
+	gs, _ := newWaterfallTestGameState(t)
+	gs.MapState.PlayerLocation.Position = references.Position{X: 100, Y: 103}
+
+	gs.applyHazardResult(environment.HazardResult{Type: environment.WaterfallFall})
+
+	if gs.MapState.PlayerLocation.Position != (references.Position{X: 100, Y: 104}) {
+		t.Errorf("Expected to be carried down only as far as the mountains, got %v", gs.MapState.PlayerLocation.Position)
+	}
+}
+
+func TestApplyHazardResult_EarthquakeRumbles(t *testing.T) {
+	gs, mockCallbacks := newShipTestGameState(t)
+
+	gs.applyHazardResult(environment.HazardResult{Type: environment.UnderworldEarthquake, DamageDealt: 4})
+	mockCallbacks.AssertSoundEffectPlayed(SoundEarthquake)
+
+	mockCallbacks.Reset()
+	gs.applyHazardResult(environment.HazardResult{Type: environment.NoHazard})
+	mockCallbacks.AssertNoSoundEffects()
+}
+
+func TestGetWhirlpoolPositions_OnlyWhirlpools(t *testing.T) {
+	gs, _ := newShipTestGameState(t)
+	addTestPirateShip(gs, indexes.PirateShip_Up, references.Position{X: 90, Y: 90})
+
+	whirlpool := map_units.NewEnemyNPC(references.EnemyReference{KeyFrameTile: &references.Tile{Index: indexes.Whirlpool_KeyIndex}}, 1)
+	whirlpool.SetPos(references.Position{X: 101, Y: 100})
+	whirlpool.SetVisible(true)
+	npcs := gs.CurrentNPCAIController.GetNpcs()
+	*npcs = append(*npcs, &whirlpool)
+
+	positions := gs.getWhirlpoolPositions()
+	if len(positions) != 1 || positions[0] != whirlpool.Pos() {
+		t.Errorf("Expected only the whirlpool, got %v", positions)
+	}
+}
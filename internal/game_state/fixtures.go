@@ -0,0 +1,215 @@
+package game_state
+
+import (
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+// Fixtures are the furnishings of a map that do something - fountains, wells, lamps and sconces. What
+// each one does is kept in a registry keyed by its sprite, and Use, Look, Search and Get all consult it,
+// so a new fixture only needs an entry here. Some places override the defaults.
+// See Fixtures.md.
+
+// FixtureEffectKind is what happens when a fixture is used or taken
+type FixtureEffectKind int
+
+const (
+	NoFixtureEffect FixtureEffectKind = iota
+	// HealFixtureEffect restores between AmountMin and AmountMax hit points
+	HealFixtureEffect
+	CurePoisonFixtureEffect
+	PoisonFixtureEffect
+	// FillWaterFixtureEffect fills an empty flask
+	FillWaterFixtureEffect
+	// WishFixtureEffect asks for a coin and grants a wish - see wishing_well.go
+	WishFixtureEffect
+	ToggleLightFixtureEffect
+	// BorrowTorchFixtureEffect takes the torch out of a sconce
+	BorrowTorchFixtureEffect
+	// DamageFixtureEffect hurts for between AmountMin and AmountMax hit points
+	DamageFixtureEffect
+)
+
+// FixtureEffect is everything a fixture does
+type FixtureEffect struct {
+	// Use is what happens when the fixture is used
+	Use FixtureEffectKind
+	// AmountMin and AmountMax bound a heal or damage
+	AmountMin, AmountMax int
+	// Get is what happens when the party tries to take the fixture
+	Get FixtureEffectKind
+	// Look is printed after the fixture's description
+	Look string
+	// Search is printed when the fixture is searched
+	Search string
+}
+
+// FixtureEffects is a registry of fixtures by the sprite they are drawn with
+type FixtureEffects map[indexes.SpriteIndex]FixtureEffect
+
+// TODO: TBD placeholder messages - "Only water!", "Foul water!" and "The light goes out." are not
+// documented
+var (
+	fountainFixture = FixtureEffect{Use: CurePoisonFixtureEffect, Search: "Only water!"}
+	wellFixture     = FixtureEffect{Use: FillWaterFixtureEffect, Search: "Only water!"}
+	wishingWell     = FixtureEffect{Use: WishFixtureEffect, Search: "Only water!"}
+	sconceFixture   = FixtureEffect{Use: ToggleLightFixtureEffect, Get: BorrowTorchFixtureEffect}
+	lightFixture    = FixtureEffect{Use: ToggleLightFixtureEffect}
+)
+
+var defaultFixtureEffects = FixtureEffects{
+	indexes.Fountain:      fountainFixture,
+	indexes.Well:          wellFixture,
+	indexes.RightSconce:   sconceFixture,
+	indexes.LeftScone:     sconceFixture,
+	indexes.LampPost:      lightFixture,
+	indexes.CandleOnTable: lightFixture,
+	indexes.Brazier:       lightFixture,
+	indexes.Fireplace:     lightFixture,
+}
+
+// healingFountain is a fountain that heals between amountMin and amountMax hit points
+func healingFountain(amountMin, amountMax int) FixtureEffect {
+	return FixtureEffect{Use: HealFixtureEffect, AmountMin: amountMin, AmountMax: amountMax, Search: fountainFixture.Search}
+}
+
+// locationFixtureEffects replace the defaults for particular places - townes that aren't listed keep
+// the default fountain, which cures poison, and the default well, which fills a flask. The wells of
+// the townes with a wish table grant wishes.
+// TODO: TBD placeholder - which townes heal at their fountains, and by how much, is the template
+// matrix in Fixtures.md, not the original's
+var locationFixtureEffects = map[references.Location]FixtureEffects{
+	references.Britain:        {indexes.Fountain: healingFountain(3, 10), indexes.Well: wishingWell},
+	references.Moonglow:       {indexes.Well: wishingWell},
+	references.Jhelom:         {indexes.Fountain: healingFountain(2, 8), indexes.Well: wishingWell},
+	references.Yew:            {indexes.Well: wishingWell},
+	references.Minoc:          {indexes.Fountain: healingFountain(1, 6), indexes.Well: wishingWell},
+	references.Trinsic:        {indexes.Well: wishingWell},
+	references.Skara_Brae:     {indexes.Fountain: healingFountain(4, 12), indexes.Well: wishingWell},
+	references.New_Magincia:   {indexes.Fountain: healingFountain(2, 8), indexes.Well: wishingWell},
+	references.Cove:           {indexes.Well: wishingWell},
+	references.Buccaneers_Den: {indexes.Fountain: healingFountain(1, 4), indexes.Well: wishingWell},
+	references.Paws:           {indexes.Fountain: healingFountain(1, 6), indexes.Well: wishingWell},
+	references.Lord_Britishs_Castle: {
+		indexes.Fountain: healingFountain(5, 12),
+		indexes.Well:     wishingWell,
+		// the castle's sconces are kept lit
+		indexes.RightSconce: {Get: BorrowTorchFixtureEffect},
+		indexes.LeftScone:   {Get: BorrowTorchFixtureEffect},
+	},
+	references.Empath_Abbey: {indexes.Fountain: healingFountain(4, 10), indexes.Well: wishingWell},
+}
+
+// getFixtureEffect finds what a fixture does here, if it is a fixture at all
+func (g *GameState) getFixtureEffect(index indexes.SpriteIndex) (FixtureEffect, bool) {
+	if overrides, ok := locationFixtureEffects[g.MapState.PlayerLocation.Location]; ok {
+		if effect, ok := overrides[index]; ok {
+			return effect, true
+		}
+	}
+	effect, ok := defaultFixtureEffects[index]
+	return effect, ok
+}
+
+// useFixtureInDirection uses whatever fixture is next to the party. It is false if there is nothing
+// there to use.
+func (g *GameState) useFixtureInDirection(direction references.Direction) bool {
+	position := direction.GetNewPositionInDirection(&g.MapState.PlayerLocation.Position)
+	layeredMap := g.GetLayeredMapByCurrentLocation()
+	tile := layeredMap.GetTileTopMapOnlyTile(position)
+	if tile == nil {
+		return false
+	}
+
+	effect, ok := g.getFixtureEffect(tile.Index)
+	if !ok {
+		return false
+	}
+	return g.useFixture(effect, position, layeredMap)
+}
+
+// useFixture is the party using a fixture at position. The Avatar is the one who drinks.
+func (g *GameState) useFixture(effect FixtureEffect, position *references.Position, layeredMap *map_state.LayeredMap) bool {
+	avatar := &g.PartyState.Characters[0]
+
+	switch effect.Use {
+	case HealFixtureEffect:
+		if avatar.Heal(uint16(g.RandomIntInRange(effect.AmountMin, effect.AmountMax))) {
+			g.SystemCallbacks.Message.AddRowStr("Refreshed!")
+			g.SystemCallbacks.Audio.PlaySoundEffect(SoundHeal)
+			g.SystemCallbacks.Screen.MarkStatsChanged()
+		} else {
+			g.SystemCallbacks.Message.AddRowStr("Refreshing!")
+		}
+	case CurePoisonFixtureEffect:
+		if avatar.Status == party_state.Poisoned {
+			avatar.Status = party_state.Good
+			g.SystemCallbacks.Message.AddRowStr("Poison cured!")
+			g.SystemCallbacks.Audio.PlaySoundEffect(SoundHeal)
+			g.SystemCallbacks.Screen.MarkStatsChanged()
+		} else {
+			g.SystemCallbacks.Message.AddRowStr("Refreshing!")
+		}
+	case PoisonFixtureEffect:
+		g.SystemCallbacks.Message.AddRowStr("Foul water!")
+		if avatar.Poison() {
+			g.SystemCallbacks.Message.AddRowStr("Poisoned!")
+			g.SystemCallbacks.Screen.MarkStatsChanged()
+		}
+	case DamageFixtureEffect:
+		g.SystemCallbacks.Message.AddRowStr("Bad taste!")
+		avatar.Damage(uint16(g.RandomIntInRange(effect.AmountMin, effect.AmountMax)))
+		g.SystemCallbacks.Screen.MarkStatsChanged()
+	case FillWaterFixtureEffect:
+		// TODO: there are no flasks in the inventory yet, so there is never one to fill
+		g.SystemCallbacks.Message.AddRowStr("Nothing to fill.")
+	case WishFixtureEffect:
+		g.wishAtWell(position, layeredMap)
+	case ToggleLightFixtureEffect:
+		if layeredMap.ToggleLight(position) {
+			g.SystemCallbacks.Message.AddRowStr("The light flickers.")
+		} else {
+			g.SystemCallbacks.Message.AddRowStr("The light goes out.")
+		}
+	default:
+		return false
+	}
+
+	g.SystemCallbacks.Flow.AdvanceTime(1)
+	return true
+}
+
+// getFixture is the party trying to take a fixture. It is false if the fixture can't be taken.
+func (g *GameState) getFixture(effect FixtureEffect, position *references.Position, layeredMap *map_state.LayeredMap) bool {
+	switch effect.Get {
+	case BorrowTorchFixtureEffect:
+		g.SystemCallbacks.Message.AddRowStr("Borrowed!")
+		g.PartyState.Inventory.Provisions.Torches.IncrementByOne()
+		layeredMap.SetTileByLayer(map_state.MapLayer, position, indexes.BrickFloor)
+	default:
+		return false
+	}
+
+	g.SystemCallbacks.Flow.AdvanceTime(1)
+	return true
+}
+
+// lookAtFixture adds what the party notices about a fixture to its description
+func (g *GameState) lookAtFixture(index indexes.SpriteIndex) {
+	if effect, ok := g.getFixtureEffect(index); ok && effect.Look != "" {
+		g.SystemCallbacks.Message.AddRowStr(effect.Look)
+	}
+}
+
+// searchFixture is false if searching the fixture turns up nothing worth mentioning
+func (g *GameState) searchFixture(index indexes.SpriteIndex) bool {
+	effect, ok := g.getFixtureEffect(index)
+	if !ok || effect.Search == "" {
+		return false
+	}
+	g.SystemCallbacks.Message.AddRowStr(effect.Search)
+	g.SystemCallbacks.Flow.AdvanceTime(1)
+	return true
+}
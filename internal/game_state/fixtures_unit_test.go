@@ -0,0 +1,167 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+func newFixturesTestGameState(t *testing.T, location references.Location) (*GameState, *MockSystemCallbacks) {
+	gs, mockCallbacks := newUnitTestGameState(t)
+	gs.MapState.PlayerLocation.Location = location
+
+	avatar := addUnitTestPartyMember(gs, 0, "Avatar")
+	avatar.CurrentHp = 10
+	avatar.MaxHp = 100
+	avatar.Intelligence = 20
+	return gs, mockCallbacks
+}
+
+func useTestFixture(gs *GameState, index indexes.SpriteIndex) bool {
+	effect, ok := gs.getFixtureEffect(index)
+	if !ok {
+		return false
+	}
+	return gs.useFixture(effect, &references.Position{X: 5, Y: 5}, &map_state.LayeredMap{})
+}
+
+func TestFixtures_FountainCuresPoisonByDefault(t *testing.T) {
+	gs, mockCallbacks := newFixturesTestGameState(t, references.Yew)
+	gs.PartyState.Characters[0].Status = party_state.Poisoned
+
+	if !useTestFixture(gs, indexes.Fountain) {
+		t.Fatalf("Expected a fountain to be usable")
+	}
+	mockCallbacks.AssertLastMessage("Poison cured!")
+	mockCallbacks.AssertSoundEffectPlayed(SoundHeal)
+	mockCallbacks.AssertTimeAdvanced(1)
+	if gs.PartyState.Characters[0].Status != party_state.Good {
+		t.Errorf("Expected the Avatar to be cured")
+	}
+
+	mockCallbacks.Reset()
+	useTestFixture(gs, indexes.Fountain)
+	mockCallbacks.AssertLastMessage("Refreshing!")
+}
+
+func TestFixtures_TownesOverrideTheirFountains(t *testing.T) {
+	gs, mockCallbacks := newFixturesTestGameState(t, references.Britain)
+	useTestFixture(gs, indexes.Fountain)
+	mockCallbacks.AssertLastMessage("Refreshed!")
+	if hp := gs.PartyState.Characters[0].CurrentHp; hp < 13 || hp > 20 {
+		t.Errorf("Expected Britain's fountain to heal 3 to 10, got to %d", hp)
+	}
+
+	gs, mockCallbacks = newFixturesTestGameState(t, references.Buccaneers_Den)
+	useTestFixture(gs, indexes.Fountain)
+	mockCallbacks.AssertLastMessage("Refreshed!")
+	if hp := gs.PartyState.Characters[0].CurrentHp; hp < 11 || hp > 14 {
+		t.Errorf("Expected the Den's fountain to heal 1 to 4, got to %d", hp)
+	}
+}
+
+func TestFixtures_SconcesToggle(t *testing.T) {
+	gs, mockCallbacks := newFixturesTestGameState(t, references.Yew)
+	position := references.Position{X: 5, Y: 5}
+	layeredMap := &map_state.LayeredMap{}
+	effect, _ := gs.getFixtureEffect(indexes.RightSconce)
+
+	gs.useFixture(effect, &position, layeredMap)
+	mockCallbacks.AssertLastMessage("The light goes out.")
+	if !layeredMap.IsLightDoused(&position) {
+		t.Errorf("Expected the sconce to be out")
+	}
+
+	gs.useFixture(effect, &position, layeredMap)
+	mockCallbacks.AssertLastMessage("The light flickers.")
+	if layeredMap.IsLightDoused(&position) {
+		t.Errorf("Expected the sconce to be lit again")
+	}
+}
+
+func TestFixtures_CastleSconcesStayLit(t *testing.T) {
+	gs, _ := newFixturesTestGameState(t, references.Lord_Britishs_Castle)
+	if useTestFixture(gs, indexes.LeftScone) {
+		t.Errorf("Expected the castle's sconces not to be put out")
+	}
+	if effect, _ := gs.getFixtureEffect(indexes.LeftScone); effect.Get != BorrowTorchFixtureEffect {
+		t.Errorf("Expected a torch to still be borrowed from the castle's sconces")
+	}
+}
+
+func TestFixtures_WellFillsAFlaskByDefault(t *testing.T) {
+	gs, mockCallbacks := newFixturesTestGameState(t, references.Windemere)
+	gs.PartyState.Inventory.Gold.Set(10)
+
+	if !useTestFixture(gs, indexes.Well) {
+		t.Fatalf("Expected a well to be usable")
+	}
+	mockCallbacks.AssertLastMessage("Nothing to fill.")
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != 10 {
+		t.Errorf("Expected no wish to be asked for, got %d gold", gold)
+	}
+
+	gs, _ = newFixturesTestGameState(t, references.Yew)
+	if effect, _ := gs.getFixtureEffect(indexes.Well); effect.Use != WishFixtureEffect {
+		t.Errorf("Expected Yew's well to grant wishes")
+	}
+}
+
+func TestFixtures_LookAndSearch(t *testing.T) {
+	gs, mockCallbacks := newFixturesTestGameState(t, references.Yew)
+
+	if !gs.searchFixture(indexes.Fountain) {
+		t.Errorf("Expected searching a fountain to say something")
+	}
+	mockCallbacks.AssertLastMessage("Only water!")
+
+	mockCallbacks.Reset()
+	gs.lookAtFixture(indexes.Grass)
+	if gs.searchFixture(indexes.Grass) {
+		t.Errorf("Expected grass not to be a fixture")
+	}
+	mockCallbacks.AssertNoMessages()
+}
+
+func TestWishingWell_CoinIsNeeded(t *testing.T) {
+	gs, mockCallbacks := newFixturesTestGameState(t, references.Yew)
+	mockCallbacks.YesNoPromptResponse = false
+	gs.PartyState.Inventory.Gold.Set(10)
+
+	useTestFixture(gs, indexes.Well)
+	mockCallbacks.AssertLastMessage("No.")
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != 10 {
+		t.Errorf("Expected no coin to be dropped, got %d gold", gold)
+	}
+
+	gs, mockCallbacks = newFixturesTestGameState(t, references.Yew)
+	useTestFixture(gs, indexes.Well)
+	mockCallbacks.AssertLastMessage("No gold!")
+}
+
+func TestWishingWell_OutcomesFollowTheirWeights(t *testing.T) {
+	gs, mockCallbacks := newFixturesTestGameState(t, references.Yew)
+	gs.PartyState.Inventory.Gold.Set(10)
+
+	goldOnly := newWishTable(0, 0, 0, 1, 0, 0, 0, wishIntelligence)
+	if outcome := gs.pickWishOutcome(goldOnly); outcome != wishGold {
+		t.Fatalf("Expected the only weighted outcome, got %d", outcome)
+	}
+	gs.grantWish(wishGold, goldOnly, &references.Position{}, &map_state.LayeredMap{})
+	mockCallbacks.AssertLastMessage("Gold!")
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != 10+wishGoldGrant {
+		t.Errorf("Expected %d gold, got %d", 10+wishGoldGrant, gold)
+	}
+
+	strengthOnly := newWishTable(0, 0, 0, 0, 0, 1, 0, wishStrength)
+	gs.grantWish(gs.pickWishOutcome(strengthOnly), strengthOnly, &references.Position{}, &map_state.LayeredMap{})
+	mockCallbacks.AssertLastMessage("Thy STR increases!")
+
+	gs.PartyState.Characters[0].Intelligence = wishMaxStat
+	if gs.grantWish(wishBoostStat, defaultWishTable, &references.Position{}, &map_state.LayeredMap{}) {
+		t.Errorf("Expected a wish not to raise an attribute past %d", wishMaxStat)
+	}
+}
@@ -46,12 +46,26 @@ type GameState struct {
 	LastLargeMapPosition references.Position
 	LastLargeMapFloor    references.FloorNumber
 
+	// Dungeon is the dungeon the party is exploring - its level is the party's floor
+	Dungeon DungeonState
+	// OpenDungeons are the dungeons whose entrances have been opened with their Words of Power
+	OpenDungeons OpenDungeons
+	// ClearedDungeonRooms are the dungeon rooms whose monsters have all been beaten
+	ClearedDungeonRooms ClearedDungeonRooms
+
 	TheOdds references.TheOdds
 
 	DateTime datetime.UltimaDate
 
 	ItemStacksMap references.ItemStacksMap
 
+	// ActiveSpell is the single duration based spell currently in effect (Protection, Negate Time, etc.)
+	ActiveSpell ActiveSpell
+	// WindDirection is the direction the wind is blowing towards
+	WindDirection references.Direction
+	// WindStrength is how hard the wind is blowing - calm winds have no direction
+	WindStrength WindStrength
+
 	// Dependency injection callbacks for external systems
 	SystemCallbacks *SystemCallbacks
 
@@ -61,6 +75,14 @@ type GameState struct {
 	// Environmental hazards system
 	environmentalHazards *environment.EnvironmentalHazards
 
+	// moongateStones are where the moongates rise, by moon phase
+	moongateStones MoongateStones
+
+	// townGates are the guards' say over the current towne's drawbridges and portcullises
+	townGates TownGates
+	// guardAlarm is raised when the party is caught misbehaving in a towne
+	guardAlarm GuardAlarm
+
 	// Testing overrides
 	jimmySuccessForTesting func(*party_state.PlayerCharacter) bool
 }
@@ -172,8 +194,13 @@ func (g *GameState) IsPassable(pos *references.Position) bool {
 		return false
 	}
 
-	// Check terrain passability first (like legalmove() in original)
-	if !topTile.IsPassable(g.PartyVehicle.GetVehicleDetails().VehicleType) {
+	// Gates are decided by the same rule that draws them, not by whatever sprite is showing
+	if closed, isGate := g.isTownGateClosedAt(pos, theMap.GetTileByLayer(map_state.MapLayer, pos).Index); isGate {
+		if closed {
+			return false
+		}
+	} else if !topTile.IsPassable(g.PartyVehicle.GetVehicleDetails().VehicleType) {
+		// Check terrain passability first (like legalmove() in original)
 		return false
 	}
 
@@ -187,7 +214,7 @@ func (g *GameState) IsPassable(pos *references.Position) bool {
 }
 
 func (g *GameState) GetArchwayPortcullisSpriteByTime() indexes.SpriteIndex {
-	if g.DateTime.Hour >= nightTowneCloseTime || g.DateTime.Hour < nightTowneOpenTime {
+	if g.AreTownGatesClosed() {
 		return indexes.Portcullis
 	}
 
@@ -195,7 +222,7 @@ func (g *GameState) GetArchwayPortcullisSpriteByTime() indexes.SpriteIndex {
 }
 
 func (g *GameState) GetDrawBridgeWaterByTime(origIndex indexes.SpriteIndex) indexes.SpriteIndex {
-	if g.DateTime.Hour >= nightTowneCloseTime || g.DateTime.Hour < nightTowneOpenTime {
+	if g.AreTownGatesClosed() {
 		return indexes.WaterShallow
 	}
 
@@ -231,6 +258,16 @@ func (g *GameState) BoardVehicle(vehicle map_units.NPCFriendly) BoardVehicleResu
 	return result
 }
 
+// movePartyTo puts the party, and whatever they are riding or sailing, at a new position on the
+// current map
+func (g *GameState) movePartyTo(position references.Position) {
+	g.MapState.PlayerLocation.Position = position
+	if g.PartyVehicle.GetVehicleDetails().VehicleType != references.NoPartyVehicle {
+		g.PartyVehicle.SetPos(position)
+		g.PartyVehicle.NPCReference.Schedule.OverrideAllPositions(byte(position.X), byte(position.Y))
+	}
+}
+
 func (g *GameState) GetTilesVisibleOnScreen() (int, int) {
 	return g.GetCurrentLayeredMap().GetTilesVisibleOnScreen()
 }
@@ -0,0 +1,97 @@
+package game_state
+
+import (
+	"github.com/bradhannah/Ultima5ReduxGo/internal/ai"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_units"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// Assaulting the townsfolk, stealing under a guard's nose or being reported in conversation raises
+// the alarm across the whole towne. Guards leave their posts and hunt the Avatar down until the party
+// leaves, or surrenders and is put in the cells.
+// See Towns.md Guard Behavior and Jail Flow.
+
+const (
+	// a guard this close to the Avatar sees what they get up to
+	guardWitnessDistance = 6
+	// attacking the townsfolk costs this much karma
+	assaultKarmaLoss = 5
+)
+
+// GuardAlarm is the towne-wide alert
+type GuardAlarm struct {
+	active bool
+	reason string
+}
+
+// IsGuardAlarmActive is true while the guards are after the party
+func (g *GameState) IsGuardAlarmActive() bool {
+	return g.guardAlarm.active
+}
+
+// RaiseGuardAlarm sets the guards on the party. A castle raises its drawbridge behind them.
+func (g *GameState) RaiseGuardAlarm(reason string) {
+	if g.MapState.PlayerLocation.Location.GetMapType() != references.SmallMapType || g.guardAlarm.active {
+		return
+	}
+
+	g.guardAlarm = GuardAlarm{active: true, reason: reason}
+	g.SystemCallbacks.Message.AddRowStr("Guards!")
+	g.SystemCallbacks.Flow.ActivateGuards()
+	g.raiseTownGatesOnAlarm()
+	g.setGuardsPursuing(true)
+}
+
+func (g *GameState) clearGuardAlarm() {
+	if g.guardAlarm.active {
+		g.setGuardsPursuing(false)
+	}
+	g.guardAlarm = GuardAlarm{}
+}
+
+func (g *GameState) setGuardsPursuing(pursuing bool) {
+	if smallMapAI, ok := g.CurrentNPCAIController.(*ai.NPCAIControllerSmallMap); ok {
+		smallMapAI.SetGuardsWantToArrest(pursuing)
+	}
+}
+
+// isGuardWatching is true when a guard on the Avatar's floor is close enough to see them
+func (g *GameState) isGuardWatching() bool {
+	if g.CurrentNPCAIController == nil {
+		return false
+	}
+	for _, mapUnit := range *g.CurrentNPCAIController.GetNpcs() {
+		friendly, ok := mapUnit.(*map_units.NPCFriendly)
+		if !ok || !friendly.IsVisible() || friendly.NPCReference.GetNPCType() != references.Guard {
+			continue
+		}
+		if friendly.Floor() == g.MapState.PlayerLocation.Floor &&
+			friendly.PosPtr().IsWithinN(&g.MapState.PlayerLocation.Position, guardWitnessDistance) {
+			return true
+		}
+	}
+	return false
+}
+
+// reportTheft raises the alarm if a guard saw the party help themselves
+func (g *GameState) reportTheft() {
+	if g.isGuardWatching() {
+		g.RaiseGuardAlarm("Theft")
+	}
+}
+
+// assaultTownsperson is the price of raising a hand against the townsfolk
+func (g *GameState) assaultTownsperson() {
+	g.PartyState.Karma.DecreaseKarma(assaultKarmaLoss)
+	g.RaiseGuardAlarm("Assault")
+}
+
+// SurrenderToGuards gives the party up to the guards, who march them off to the cells.
+func (g *GameState) SurrenderToGuards() bool {
+	if !g.guardAlarm.active {
+		return false
+	}
+
+	g.SystemCallbacks.Message.AddRowStr("Thou dost surrender!")
+	return g.SendPartyToJail()
+}
@@ -0,0 +1,118 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/ai"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_units"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+func newGuardAlarmTestGameState(t *testing.T, location references.Location) (*GameState, *MockSystemCallbacks) {
+	gs, mockCallbacks := newUnitTestGameState(t)
+	gs.MapState.PlayerLocation.Location = location
+	gs.MapState.PlayerLocation.Position = references.Position{X: 15, Y: 15}
+	// any controller will do to keep the townsfolk in
+	gs.CurrentNPCAIController = ai.NewNPCAIControllerLargeMap(ai.NewNPCAIControllerLargeMapInput{})
+	return gs, mockCallbacks
+}
+
+func addTestTownsperson(gs *GameState, sprite indexes.SpriteIndex, pos references.Position) *map_units.NPCFriendly {
+	npcRef := references.NPCReference{}
+	npcRef.SetKeyIndex(sprite)
+	friendly := map_units.NewNPCFriendly(npcRef, len(*gs.CurrentNPCAIController.GetNpcs()))
+	friendly.SetPos(pos)
+	friendly.SetVisible(true)
+	npcs := gs.CurrentNPCAIController.GetNpcs()
+	*npcs = append(*npcs, friendly)
+	return friendly
+}
+
+func TestGuardAlarm_RaisedOnceInTowne(t *testing.T) {
+	gs, mockCallbacks := newGuardAlarmTestGameState(t, references.Britain)
+
+	gs.RaiseGuardAlarm("Assault")
+	if !gs.IsGuardAlarmActive() {
+		t.Fatalf("Expected the alarm to be raised")
+	}
+	mockCallbacks.AssertLastMessage("Guards!")
+	if mockCallbacks.GuardActivations != 1 {
+		t.Errorf("Expected the guards to be activated once, got %d", mockCallbacks.GuardActivations)
+	}
+	if gs.AreTownGatesClosed() && gs.DateTime.Hour >= nightTowneOpenTime {
+		t.Errorf("Expected a towne to leave its gates alone")
+	}
+
+	gs.RaiseGuardAlarm("Theft")
+	if mockCallbacks.GuardActivations != 1 {
+		t.Errorf("Expected an alarm already raised not to be raised again")
+	}
+}
+
+func TestGuardAlarm_CastleRaisesItsDrawbridge(t *testing.T) {
+	gs, mockCallbacks := newGuardAlarmTestGameState(t, references.Lord_Britishs_Castle)
+	gs.DateTime.Hour = 12
+
+	gs.RaiseGuardAlarm("Conversation callback")
+	mockCallbacks.AssertLastMessage("The drawbridge is raised!")
+	if !gs.AreTownGatesClosed() {
+		t.Errorf("Expected the castle gates to be shut in broad daylight")
+	}
+}
+
+func TestGuardAlarm_NotOnTheLargeMap(t *testing.T) {
+	gs, mockCallbacks := newGuardAlarmTestGameState(t, references.Britannia_Underworld)
+
+	gs.RaiseGuardAlarm("Assault")
+	if gs.IsGuardAlarmActive() {
+		t.Errorf("Expected no guards on the large map")
+	}
+	mockCallbacks.AssertNoMessages()
+}
+
+func TestAttack_TownspersonRaisesTheAlarm(t *testing.T) {
+	gs, mockCallbacks := newGuardAlarmTestGameState(t, references.Britain)
+	gs.PartyState.Karma.Value = 50
+	addTestTownsperson(gs, indexes.TownsPerson_KeyIndex, references.Position{X: 16, Y: 15})
+
+	if gs.ActionAttackSmallMap(references.Up) {
+		t.Errorf("Expected nothing to attack to the north")
+	}
+	mockCallbacks.AssertLastMessage("Nothing to attack!")
+
+	if !gs.ActionAttackSmallMap(references.Right) {
+		t.Fatalf("Expected the attack to land")
+	}
+	if !gs.IsGuardAlarmActive() {
+		t.Errorf("Expected the assault to raise the alarm")
+	}
+	if gs.PartyState.Karma.Value != 50-assaultKarmaLoss {
+		t.Errorf("Expected the assault to cost %d karma, got %d", assaultKarmaLoss, gs.PartyState.Karma.Value)
+	}
+}
+
+func TestTheft_OnlyWhenAGuardIsWatching(t *testing.T) {
+	gs, _ := newGuardAlarmTestGameState(t, references.Britain)
+	guard := addTestTownsperson(gs, indexes.Guard_KeyIndex, references.Position{X: 30, Y: 30})
+
+	gs.reportTheft()
+	if gs.IsGuardAlarmActive() {
+		t.Errorf("Expected a guard across the towne not to notice")
+	}
+
+	guard.SetPos(references.Position{X: 18, Y: 12})
+	gs.reportTheft()
+	if !gs.IsGuardAlarmActive() {
+		t.Errorf("Expected a guard nearby to raise the alarm")
+	}
+}
+
+func TestSurrender_OnlyWhenTheGuardsAreAfterTheParty(t *testing.T) {
+	gs, mockCallbacks := newGuardAlarmTestGameState(t, references.Britain)
+
+	if gs.SurrenderToGuards() {
+		t.Errorf("Expected no surrender without an alarm")
+	}
+	mockCallbacks.AssertNoMessages()
+}
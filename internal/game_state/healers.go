@@ -0,0 +1,99 @@
+package game_state
+
+import (
+	"fmt"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// Healers cure poison, heal wounds and raise the dead for a price that depends on the town, and
+// will pay for a party member's blood. A service is only paid for when it would do something.
+// See docs/ALGOS/Shops.md.
+
+// TODO: TBD placeholders - the blood donation is not sourced from the original game, and nor are any
+// messages but "Not enough gold!" and "Poison cured!" ("Resurrected!", the refusals and the thanks
+// for blood)
+const (
+	// a heal is rolld30 as in Shops.md heal()
+	healerMinHeal = 1
+	healerMaxHeal = 30
+
+	// bloodDonationHitPoints is what giving blood costs a party member, who has to be able to spare it
+	bloodDonationHitPoints = 25
+	bloodDonationGold      = 30
+)
+
+// BuyHealerService pays the healer to cure, heal or resurrect a party member
+func (g *GameState) BuyHealerService(shop *Shop, service references.HealerService, playerIndex int) bool {
+	if !g.PartyState.IsCharacterInParty(playerIndex) {
+		return false
+	}
+
+	character := &g.PartyState.Characters[playerIndex]
+	if reason, ok := g.canUseHealerService(service, playerIndex); !ok {
+		g.SystemCallbacks.Message.AddRowStr(fmt.Sprintf("%s %s", character.GetNameAsString(), reason))
+		return false
+	}
+
+	if !g.PartyState.Inventory.Gold.DecrementBy(uint16(references.GetHealerPrice(shop.Location, service))) {
+		g.SystemCallbacks.Message.AddRowStr("Not enough gold!")
+		return false
+	}
+
+	switch service {
+	case references.HealerCure:
+		character.Cure()
+		g.SystemCallbacks.Message.AddRowStr("Poison cured!")
+	case references.HealerHeal:
+		character.Heal(uint16(g.RandomIntInRange(healerMinHeal, healerMaxHeal)))
+	case references.HealerResurrect:
+		character.Resurrect(resurrectedHitPoints)
+		g.SystemCallbacks.Message.AddRowStr("Resurrected!")
+	}
+	g.SystemCallbacks.Audio.PlaySoundEffect(SoundHeal)
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	return true
+}
+
+// canUseHealerService is false, with the reason, when the service would do nothing for the party member
+func (g *GameState) canUseHealerService(service references.HealerService, playerIndex int) (string, bool) {
+	character := &g.PartyState.Characters[playerIndex]
+	switch service {
+	case references.HealerCure:
+		if character.Status != party_state.Poisoned {
+			return "is not poisoned!", false
+		}
+	case references.HealerHeal:
+		if character.Status == party_state.Dead {
+			return "is beyond healing!", false
+		}
+		if character.CurrentHp >= character.MaxHp {
+			return "is not wounded!", false
+		}
+	case references.HealerResurrect:
+		if character.Status != party_state.Dead {
+			return "is not dead!", false
+		}
+	}
+	return "", true
+}
+
+// DonateBlood has a party member give blood to the healer for gold
+func (g *GameState) DonateBlood(playerIndex int) bool {
+	if !g.PartyState.IsCharacterInParty(playerIndex) {
+		return false
+	}
+
+	character := &g.PartyState.Characters[playerIndex]
+	if character.Status == party_state.Dead || character.CurrentHp <= bloodDonationHitPoints {
+		g.SystemCallbacks.Message.AddRowStr(fmt.Sprintf("%s is too weak!", character.GetNameAsString()))
+		return false
+	}
+
+	character.Damage(bloodDonationHitPoints)
+	g.PartyState.Inventory.Gold.IncrementBy(bloodDonationGold)
+	g.SystemCallbacks.Message.AddRowStr(fmt.Sprintf("Thank thee! Here is %dgp.", bloodDonationGold))
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	return true
+}
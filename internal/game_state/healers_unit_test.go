@@ -0,0 +1,120 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// newHealersTestGameState has Iolo in the party with the given status and hit points, and 1000gp
+func newHealersTestGameState(t *testing.T, status party_state.CharacterStatus, hitPoints uint16) (*GameState, *MockSystemCallbacks, *party_state.PlayerCharacter) {
+	gs, mockCallbacks := newUnitTestGameState(t)
+	gs.PartyState.Inventory.Gold.Set(1000)
+
+	iolo := addUnitTestPartyMember(gs, 1, "Iolo")
+	iolo.Status = status
+	iolo.MaxHp = 100
+	iolo.CurrentHp = hitPoints
+	return gs, mockCallbacks, iolo
+}
+
+var healerInJhelom = &Shop{Type: references.HealerShop, Location: references.Jhelom}
+
+func TestHealers_CurePoison(t *testing.T) {
+	gs, mockCallbacks, iolo := newHealersTestGameState(t, party_state.Poisoned, 50)
+
+	if !gs.BuyHealerService(healerInJhelom, references.HealerCure, 1) {
+		t.Fatalf("Expected Iolo to be cured")
+	}
+	if iolo.Status != party_state.Good {
+		t.Errorf("Expected Iolo to be in good health, got %c", iolo.Status)
+	}
+	// Jhelom's healer charges a fifth more than the 30gp base
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != 964 {
+		t.Errorf("Expected 964gp left, got %d", gold)
+	}
+	mockCallbacks.AssertLastMessage("Poison cured!")
+	mockCallbacks.AssertSoundEffectPlayed(SoundHeal)
+
+	if gs.BuyHealerService(healerInJhelom, references.HealerCure, 1) {
+		t.Errorf("Expected no cure for a healthy party member")
+	}
+	mockCallbacks.AssertLastMessage("Iolo is not poisoned!")
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != 964 {
+		t.Errorf("Expected a needless cure to cost nothing, got %d", gold)
+	}
+}
+
+func TestHealers_HealWounds(t *testing.T) {
+	gs, mockCallbacks, iolo := newHealersTestGameState(t, party_state.Good, 50)
+
+	if !gs.BuyHealerService(healerInJhelom, references.HealerHeal, 1) {
+		t.Fatalf("Expected Iolo to be healed")
+	}
+	if iolo.CurrentHp <= 50 || iolo.CurrentHp > 50+healerMaxHeal {
+		t.Errorf("Expected up to %d hit points to be restored, got %d", healerMaxHeal, iolo.CurrentHp)
+	}
+	mockCallbacks.AssertNoMessages()
+	mockCallbacks.AssertSoundEffectPlayed(SoundHeal)
+
+	iolo.CurrentHp = iolo.MaxHp
+	if gs.BuyHealerService(healerInJhelom, references.HealerHeal, 1) {
+		t.Errorf("Expected no healing for an unwounded party member")
+	}
+	mockCallbacks.AssertLastMessage("Iolo is not wounded!")
+}
+
+func TestHealers_ResurrectOnlyTheDead(t *testing.T) {
+	gs, mockCallbacks, iolo := newHealersTestGameState(t, party_state.Good, 50)
+
+	if gs.BuyHealerService(healerInJhelom, references.HealerResurrect, 1) {
+		t.Errorf("Expected the living not to be resurrected")
+	}
+	mockCallbacks.AssertLastMessage("Iolo is not dead!")
+
+	iolo.Damage(50)
+	if gs.BuyHealerService(healerInJhelom, references.HealerHeal, 1) {
+		t.Errorf("Expected the dead to be beyond healing")
+	}
+
+	gs.PartyState.Inventory.Gold.Set(100)
+	if gs.BuyHealerService(healerInJhelom, references.HealerResurrect, 1) {
+		t.Errorf("Expected resurrection to need more gold")
+	}
+	mockCallbacks.AssertLastMessage("Not enough gold!")
+
+	gs.PartyState.Inventory.Gold.Set(1000)
+	if !gs.BuyHealerService(healerInJhelom, references.HealerResurrect, 1) {
+		t.Fatalf("Expected Iolo to be resurrected")
+	}
+	if iolo.Status != party_state.Good || iolo.CurrentHp == 0 {
+		t.Errorf("Expected Iolo to live again, got %c with %d HP", iolo.Status, iolo.CurrentHp)
+	}
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != 640 {
+		t.Errorf("Expected 640gp left, got %d", gold)
+	}
+}
+
+func TestHealers_DonateBlood(t *testing.T) {
+	gs, mockCallbacks, iolo := newHealersTestGameState(t, party_state.Good, 50)
+	gs.PartyState.Inventory.Gold.Set(0)
+
+	if !gs.DonateBlood(1) {
+		t.Fatalf("Expected Iolo to give blood")
+	}
+	if iolo.CurrentHp != 50-bloodDonationHitPoints {
+		t.Errorf("Expected Iolo to lose %d hit points, got %d", bloodDonationHitPoints, iolo.CurrentHp)
+	}
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != bloodDonationGold {
+		t.Errorf("Expected %dgp for the blood, got %d", bloodDonationGold, gold)
+	}
+
+	if gs.DonateBlood(1) {
+		t.Errorf("Expected Iolo to be too weak to give blood again")
+	}
+	mockCallbacks.AssertLastMessage("Iolo is too weak!")
+	if iolo.Status == party_state.Dead {
+		t.Errorf("Expected giving blood never to kill")
+	}
+}
@@ -0,0 +1,110 @@
+package game_state
+
+import (
+	"fmt"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/datetime"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// Innkeepers rent the party a room for the night, which sleeps them through to the morning with
+// their hit points and magic restored. Companions can also be left at an inn for as many months as
+// the party pays for up front.
+// See docs/ALGOS/Shops.md.
+// TODO: TBD placeholder messages - only "Not enough gold!" and "Rest well!" are documented, so "Thou
+// must lead thy party!" and "%s rejoins thy party." are not the original's
+
+const (
+	// innCheckOutHour is when the party wakes after a night at the inn
+	innCheckOutHour byte = 8
+	// maxMinutesAdvancedAtOnce is as far as UltimaDate will move time in one go
+	maxMinutesAdvancedAtOnce = datetime.MinutesPerHour * 9
+	// MaxMonthsAtInn is as long as a companion can be left at an inn
+	MaxMonthsAtInn = 255
+)
+
+// RentInnRoom has the party pay for a night at the inn and sleep until morning
+func (g *GameState) RentInnRoom(shop *Shop) bool {
+	price := references.GetInnRoomPrice(shop.Location)
+	if !g.PartyState.Inventory.Gold.DecrementBy(uint16(price)) {
+		g.SystemCallbacks.Message.AddRowStr("Not enough gold!")
+		return false
+	}
+
+	g.SystemCallbacks.Message.AddRowStr("Rest well!")
+	g.sleepUntil(innCheckOutHour)
+	for i := range g.PartyState.Characters {
+		if g.PartyState.IsCharacterInParty(i) {
+			g.PartyState.Characters[i].Rest()
+		}
+	}
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	return true
+}
+
+// GetInnStayPrice is what the inn charges up front for a companion to stay the given number of months
+func (g *GameState) GetInnStayPrice(shop *Shop, months int) int {
+	return months * references.GetInnMonthlyPrice(shop.Location)
+}
+
+// LeaveCompanionAtInn has a companion stay behind at the inn for the months the party pays for - the
+// Avatar never leaves the party. See Shops.md visit_inn.
+func (g *GameState) LeaveCompanionAtInn(shop *Shop, playerIndex int, months int) bool {
+	if playerIndex == 0 {
+		g.SystemCallbacks.Message.AddRowStr("Thou must lead thy party!")
+		return false
+	}
+	if !g.PartyState.IsCharacterInParty(playerIndex) || months < 1 || months > MaxMonthsAtInn {
+		return false
+	}
+
+	if !g.PartyState.Inventory.Gold.DecrementBy(uint16(g.GetInnStayPrice(shop, months))) {
+		g.SystemCallbacks.Message.AddRowStr("Not enough gold!")
+		return false
+	}
+
+	g.PartyState.Characters[playerIndex].LeaveAtTheInn(shop.Location, byte(months))
+	g.SystemCallbacks.Message.AddRowStr("Rest well!")
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	return true
+}
+
+// GetCompanionsAtInn is every companion staying at the inn in the given location
+func (g *GameState) GetCompanionsAtInn(location references.Location) []int {
+	var companions []int
+	for i := range g.PartyState.Characters {
+		if g.PartyState.Characters[i].GetInnLocation() == location {
+			companions = append(companions, i)
+		}
+	}
+	return companions
+}
+
+// CollectCompanionFromInn has a companion staying at this inn rejoin the party - their stay was paid
+// for when they were left
+// TODO: what happens once the months paid for have run out is not documented
+func (g *GameState) CollectCompanionFromInn(shop *Shop, playerIndex int) bool {
+	character := &g.PartyState.Characters[playerIndex]
+	if character.GetInnLocation() != shop.Location {
+		return false
+	}
+
+	character.ReturnFromTheInn()
+	g.SystemCallbacks.Message.AddRowStr(fmt.Sprintf("%s rejoins thy party.", character.GetNameAsString()))
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	return true
+}
+
+// sleepUntil moves time on to the next time it is the given hour
+func (g *GameState) sleepUntil(hour byte) {
+	nMinutes := (int(hour)-int(g.DateTime.Hour)+datetime.HoursPerDay)%datetime.HoursPerDay*datetime.MinutesPerHour - int(g.DateTime.Minute)
+	if nMinutes <= 0 {
+		nMinutes += datetime.HoursPerDay * datetime.MinutesPerHour
+	}
+
+	for nMinutes > 0 {
+		nAdvance := min(nMinutes, maxMinutesAdvancedAtOnce)
+		g.DateTime.Advance(nAdvance)
+		nMinutes -= nAdvance
+	}
+}
@@ -0,0 +1,116 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// newInnsTestGameState has the Avatar and a wounded Shamino at the inn in Moonglow
+func newInnsTestGameState(t *testing.T) (*GameState, *MockSystemCallbacks, *Shop) {
+	gs, mockCallbacks := newUnitTestGameState(t)
+	gs.DateTime.Year, gs.DateTime.Month, gs.DateTime.Day, gs.DateTime.Hour = 139, 4, 6, 22
+
+	for i, name := range []string{"Avatar", "Shamino"} {
+		character := addUnitTestPartyMember(gs, i, name)
+		character.Class = party_state.Avatar
+		character.Intelligence = 20
+		character.MaxHp = 100
+		character.CurrentHp = 10
+	}
+	for i := 2; i < party_state.NPlayers; i++ {
+		gs.PartyState.Characters[i].PartyStatus = party_state.HasntJoinedYet
+	}
+
+	return gs, mockCallbacks, &Shop{Type: references.InnShop, Location: references.Moonglow}
+}
+
+func TestInns_RoomRestsThePartyUntilMorning(t *testing.T) {
+	gs, mockCallbacks, shop := newInnsTestGameState(t)
+	gs.PartyState.Inventory.Gold.Set(100)
+
+	if !gs.RentInnRoom(shop) {
+		t.Fatalf("Expected a room to be rented")
+	}
+	// a 20gp room costs a tenth more in Moonglow
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != 78 {
+		t.Errorf("Expected 78gp left, got %d", gold)
+	}
+	if gs.DateTime.Day != 7 || gs.DateTime.Hour != innCheckOutHour || gs.DateTime.Minute != 0 {
+		t.Errorf("Expected to wake the next morning, got day %d %d:%02d", gs.DateTime.Day, gs.DateTime.Hour, gs.DateTime.Minute)
+	}
+	for i := 0; i < 2; i++ {
+		character := &gs.PartyState.Characters[i]
+		if character.CurrentHp != character.MaxHp || character.CurrentMp != character.GetMaxMp() {
+			t.Errorf("Expected %s to be rested, got %d HP %d MP", character.GetNameAsString(), character.CurrentHp, character.CurrentMp)
+		}
+	}
+	mockCallbacks.AssertLastMessage("Rest well!")
+}
+
+func TestInns_RoomNeedsGold(t *testing.T) {
+	gs, mockCallbacks, shop := newInnsTestGameState(t)
+	gs.PartyState.Inventory.Gold.Set(5)
+
+	if gs.RentInnRoom(shop) {
+		t.Errorf("Expected no room without the gold for it")
+	}
+	mockCallbacks.AssertLastMessage("Not enough gold!")
+	if gs.DateTime.Hour != 22 || gs.PartyState.Characters[0].CurrentHp != 10 {
+		t.Errorf("Expected the party not to rest")
+	}
+}
+
+func TestInns_CompanionStaysForTheMonthsPaidFor(t *testing.T) {
+	gs, mockCallbacks, shop := newInnsTestGameState(t)
+	gs.PartyState.Inventory.Gold.Set(70)
+
+	if gs.LeaveCompanionAtInn(shop, 0, 1) {
+		t.Errorf("Expected the Avatar to stay with the party")
+	}
+	// a 30gp month costs a tenth more in Moonglow
+	if gs.LeaveCompanionAtInn(shop, 1, 3) {
+		t.Errorf("Expected Shamino to need 99gp for three months")
+	}
+	mockCallbacks.AssertLastMessage("Not enough gold!")
+
+	if !gs.LeaveCompanionAtInn(shop, 1, 2) {
+		t.Fatalf("Expected Shamino to stay at the inn")
+	}
+	mockCallbacks.AssertLastMessage("Rest well!")
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != 4 {
+		t.Errorf("Expected two months to be paid up front leaving 4gp, got %d", gold)
+	}
+	shamino := &gs.PartyState.Characters[1]
+	if gs.PartyState.IsCharacterInParty(1) || shamino.GetInnLocation() != references.Moonglow || shamino.MonthsAtInn != 2 {
+		t.Fatalf("Expected Shamino to be staying two months in Moonglow")
+	}
+	if companions := gs.GetCompanionsAtInn(references.Moonglow); len(companions) != 1 || companions[0] != 1 {
+		t.Errorf("Expected Shamino to be the only companion at the inn, got %v", companions)
+	}
+
+	if !gs.CollectCompanionFromInn(shop, 1) {
+		t.Fatalf("Expected Shamino to rejoin the party")
+	}
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != 4 {
+		t.Errorf("Expected nothing more to pay, got %d gp left", gold)
+	}
+	if !gs.PartyState.IsCharacterInParty(1) || shamino.MonthsAtInn != 0 {
+		t.Errorf("Expected Shamino back in the party")
+	}
+}
+
+func TestInns_CompanionOnlyCollectedFromTheirOwnInn(t *testing.T) {
+	gs, _, shop := newInnsTestGameState(t)
+	gs.PartyState.Inventory.Gold.Set(100)
+	gs.LeaveCompanionAtInn(shop, 1, 1)
+
+	elsewhere := &Shop{Type: references.InnShop, Location: references.Britain}
+	if gs.CollectCompanionFromInn(elsewhere, 1) {
+		t.Errorf("Expected Shamino to be waiting in Moonglow")
+	}
+	if len(gs.GetCompanionsAtInn(references.Britain)) != 0 {
+		t.Errorf("Expected nobody waiting in Britain")
+	}
+}
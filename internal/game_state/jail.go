@@ -0,0 +1,31 @@
+package game_state
+
+import (
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+// The party is locked in with an ordinary lock so that a lockpick (Jimmy), a skull key or An Sanct
+// will get them out again. See Towns.md Jail Flow.
+
+// SendPartyToJail locks the party in the towne's cell and calls off the guards. It is false if the
+// towne has no jail.
+func (g *GameState) SendPartyToJail() bool {
+	if g.MapState.PlayerLocation.Location.GetMapType() != references.SmallMapType {
+		return false
+	}
+
+	jailConfig, found := g.GameReferences.JailReferences.GetJailConfig(g.MapState.PlayerLocation.Location)
+	if !found {
+		return false
+	}
+
+	g.MapState.PlayerLocation.Floor = jailConfig.Floor
+	g.movePartyTo(jailConfig.Cell)
+	g.MapState.LayeredMaps.GetLayeredMap(references.SmallMapType, jailConfig.Floor).
+		SetTileByLayer(map_state.MapOverrideLayer, &jailConfig.Door, indexes.LockedDoor)
+	g.clearGuardAlarm()
+	g.SystemCallbacks.Message.AddRowStr("To the cells with thee!")
+	return true
+}
@@ -0,0 +1,28 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+func TestSendPartyToJail_NothingHappensInATowneWithoutAJail(t *testing.T) {
+	gs, mockCallbacks := newGuardAlarmTestGameState(t, references.Britain)
+	gs.GameReferences = &references.GameReferences{JailReferences: references.NewJailReferences()}
+	gs.RaiseGuardAlarm("Assault")
+	mockCallbacks.Reset()
+
+	if gs.SendPartyToJail() {
+		t.Fatalf("Expected no cell to lock the party in")
+	}
+	if len(mockCallbacks.Messages) != 0 {
+		t.Errorf("Expected nothing to be said, got %v", mockCallbacks.Messages)
+	}
+	if !gs.IsGuardAlarmActive() {
+		t.Errorf("Expected the guards to stay alerted")
+	}
+	if gs.MapState.PlayerLocation.Location != references.Britain ||
+		gs.MapState.PlayerLocation.Position != (references.Position{X: 15, Y: 15}) {
+		t.Errorf("Expected the party to stay where they are, got %v", gs.MapState.PlayerLocation)
+	}
+}
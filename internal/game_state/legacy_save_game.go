@@ -12,6 +12,11 @@ import (
 
 const savedGamFileSize = 4192
 
+// lbOpenDungeons is the dungeons opened with their Words of Power, a byte each in DUNGEON.DAT order
+const lbOpenDungeons = 0x32A
+
+const openDungeonsSize = 8
+
 type (
 	StartingMemoryAddressUb  uint16
 	StartingMemoryAddressU16 uint16
@@ -88,12 +93,67 @@ func (g *GameState) LoadLegacySaveGameFromBytes(rawSaveData []byte) error {
 	g.PartyState.Inventory.Provisions.Keys.Set(uint16(rawSaveData[lbKeys]))
 	g.PartyState.Inventory.Provisions.SkullKeys.Set(uint16(rawSaveData[lbSkullKeys]))
 
+	// Weapons, armour, rings and amulets
+	const lbEquipment = 0x21A
+	for equipment := references.LeatherHelm; equipment <= references.Ankh; equipment++ {
+		g.PartyState.Inventory.Equipment.Set(equipment, uint16(rawSaveData[lbEquipment+int(equipment)]))
+	}
+
+	// Scrolls
+	const lbScrolls = 0x27A
+	for scroll := references.ScrollVasLor; scroll <= references.ScrollAnTym; scroll++ {
+		g.PartyState.Inventory.Scrolls.Set(scroll, uint16(rawSaveData[lbScrolls+int(scroll)]))
+	}
+
+	// Potions
+	const lbPotions = 0x282
+	for potion := references.Blue; potion <= references.White; potion++ {
+		g.PartyState.Inventory.Potions.Set(potion, uint16(rawSaveData[lbPotions+int(potion)]))
+	}
+
+	// Artifacts of Lord British - 0xFF means the item is not in the inventory
+	const lbQuestItems = 0x20D
+	for questItem := references.Amulet; questItem <= references.Sceptre; questItem++ {
+		if nQuantity := rawSaveData[lbQuestItems+int(questItem)]; nQuantity != 0xFF {
+			g.PartyState.Inventory.QuestItems.Set(questItem, uint16(nQuantity))
+		}
+	}
+
+	// Active duration spell, which includes the worn Amulet or Crown
+	const lbActiveSpell = 0x2D4
+	const lbSpellDuration = 0x2E8
+	if rawSaveData[lbSpellDuration] > 0 {
+		g.startDurationSpell(DurationSpell(rawSaveData[lbActiveSpell]), int(rawSaveData[lbSpellDuration]))
+	}
+
+	// Spells (mixed)
+	const lbSpells = 0x24A
+	for spell := references.InLor; spell <= references.AnTym; spell++ {
+		g.PartyState.Inventory.Spells.Set(spell, uint16(rawSaveData[lbSpells+int(spell)]))
+	}
+
+	// Reagents
+	const lbReagents = 0x2AA
+	for reagent := references.SulfurAsh; reagent <= references.MandrakeRoot; reagent++ {
+		g.PartyState.Inventory.Reagent.Set(reagent, uint16(rawSaveData[lbReagents+int(reagent)]))
+	}
+
 	g.MapState.LayeredMaps = *map_state.NewLayeredMaps(g.GameReferences.TileReferences,
 		g.GameReferences.OverworldLargeMapReference,
 		g.GameReferences.UnderworldLargeMapReference,
 		g.MapState.XTilesVisibleOnGameScreen,
 		g.MapState.YTilesVisibleOnGameScreen)
 
+	// Moongates
+	g.moongateStones = NewMoongateStonesFromRaw(rawSaveData)
+
+	// Dungeons opened with their Words of Power - the rest are drawn sealed
+	g.OpenDungeons = OpenDungeons(rawSaveData[lbOpenDungeons : lbOpenDungeons+openDungeonsSize])
+	g.drawDungeonSeals()
+
+	// Dungeon rooms already beaten
+	g.ClearedDungeonRooms = ClearedDungeonRooms(rawSaveData[lbClearedDungeonRooms : lbClearedDungeonRooms+clearedDungeonRoomsSize])
+
 	g.LargeMapNPCAIController = make(map[references.World]*ai.NPCAIControllerLargeMap)
 	overworldNPCAIInput := ai.NewNPCAIControllerLargeMapInput{
 		World:           references.OVERWORLD,
@@ -48,6 +48,7 @@ type MockSystemCallbacks struct {
 	// Talk tracking
 	TalkDialogCalls []TalkDialogCall
 	DialogsPushed   []TalkDialog
+	ShopsOpened     []*Shop
 	TalkCallCount   int
 
 	// Test configuration
@@ -143,6 +144,7 @@ func (m *MockSystemCallbacks) ToSystemCallbacks() *SystemCallbacks {
 	talkCallbacks := NewTalkCallbacks(
 		m.createTalkDialog,
 		m.pushDialog,
+		m.openShop,
 	)
 
 	systemCallbacks, err := NewSystemCallbacks(
@@ -294,6 +296,12 @@ func (m *MockSystemCallbacks) pushDialog(dialog TalkDialog) {
 	m.t.Logf("📋 Dialog pushed")
 }
 
+func (m *MockSystemCallbacks) openShop(shop *Shop) {
+	m.ShopsOpened = append(m.ShopsOpened, shop)
+	m.TalkCallCount++
+	m.t.Logf("🛒 Shop opened")
+}
+
 // Test assertion helpers
 func (m *MockSystemCallbacks) AssertMessageContains(expected string) {
 	m.t.Helper()
@@ -382,5 +390,6 @@ func (m *MockSystemCallbacks) Reset() {
 
 	m.TalkDialogCalls = m.TalkDialogCalls[:0]
 	m.DialogsPushed = m.DialogsPushed[:0]
+	m.ShopsOpened = nil
 	m.TalkCallCount = 0
 }
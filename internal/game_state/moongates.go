@@ -0,0 +1,110 @@
+package game_state
+
+import (
+	"github.com/bradhannah/Ultima5ReduxGo/internal/datetime"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// Moongates rise at night on the stones kept in SAVED.GAM. Stepping into one sends the party to the
+// stone chosen by the phase of Trammel (before noon) or Felucca (after noon) - see Moongates.md.
+
+// MoongateStone is where a moongate rises - the map, position and level of the stone
+type MoongateStone struct {
+	Location references.Location
+	Position references.Position
+	Floor    references.FloorNumber
+}
+
+// MoongateStones are the moongates, by the moon phase that leads to them. A phase without a stone
+// leads nowhere.
+type MoongateStones map[datetime.MoonPhase]MoongateStone
+
+// NewMoongateStonesFromRaw reads the stones from SAVED.GAM - four arrays indexed by moon phase of
+// the X, Y, map and level of each stone
+func NewMoongateStonesFromRaw(rawSaveData []byte) MoongateStones {
+	const lbStonesX = 0x28A
+	const lbStonesY = 0x292
+	const lbStonesMap = 0x29A
+	const lbStonesLevel = 0x2A2
+
+	stones := make(MoongateStones)
+	for phase := range datetime.NumberOfMoonPhases {
+		// a phase whose stone is on no map leads nowhere
+		location := references.Location(rawSaveData[lbStonesMap+phase])
+		if location == references.EmptyLocation {
+			continue
+		}
+		stones[datetime.MoonPhase(phase)] = MoongateStone{
+			Location: location,
+			Position: references.Position{
+				X: references.Coordinate(rawSaveData[lbStonesX+phase]),
+				Y: references.Coordinate(rawSaveData[lbStonesY+phase]),
+			},
+			Floor: references.FloorNumber(rawSaveData[lbStonesLevel+phase]),
+		}
+	}
+	return stones
+}
+
+// IsMoongateAt is true when a moongate has risen at the position on the map the party is on - they
+// only appear at night
+func (g *GameState) IsMoongateAt(pos *references.Position) bool {
+	if !g.DateTime.IsNight() {
+		return false
+	}
+
+	for _, stone := range g.moongateStones {
+		if stone.Location == g.MapState.PlayerLocation.Location &&
+			stone.Floor == g.MapState.PlayerLocation.Floor &&
+			stone.Position.Equals(pos) {
+			return true
+		}
+	}
+	return false
+}
+
+// EnterMoongateIfPresent sends the party through the moongate they are standing in, returning
+// false if there is no gate or the current moon phase leads nowhere
+func (g *GameState) EnterMoongateIfPresent() bool {
+	if !g.IsMoongateAt(&g.MapState.PlayerLocation.Position) {
+		return false
+	}
+
+	if g.DateTime.IsShrineOfSpiritualityGateOpen() {
+		// TODO: enter the Shrine of Spirituality once shrines are implemented
+		g.SystemCallbacks.Message.AddRowStr("Shrine of Spirituality!")
+		return false
+	}
+
+	destination, ok := g.moongateStones[g.DateTime.GetMoongatePhase()]
+	if !ok {
+		return false
+	}
+
+	g.SystemCallbacks.Audio.PlaySoundEffect(SoundMoongate)
+	g.SystemCallbacks.Flow.DelayFx()
+
+	g.travelToMoongateStone(destination)
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	return true
+}
+
+// travelToMoongateStone puts the party on the stone, on its map and level. The original only
+// rebuilds the map when the gate joins two towns or two parts of Britannia.
+func (g *GameState) travelToMoongateStone(stone MoongateStone) {
+	from := g.MapState.PlayerLocation
+	g.MapState.PlayerLocation.Location = stone.Location
+	g.MapState.PlayerLocation.Floor = stone.Floor
+	g.movePartyTo(stone.Position)
+
+	if from.Location == stone.Location && from.Floor == stone.Floor {
+		return
+	}
+	switch fromMapType := from.Location.GetMapType(); {
+	case fromMapType == references.SmallMapType && stone.Location.GetMapType() == references.SmallMapType:
+		g.UpdateSmallMap(g.GameReferences.TileReferences, g.GameReferences.LocationReferences)
+	case fromMapType == references.LargeMapType && stone.Location.GetMapType() == references.LargeMapType:
+		g.CurrentNPCAIController = g.GetCurrentLargeMapNPCAIController()
+		g.MapState.UpdateLargeMap()
+	}
+}
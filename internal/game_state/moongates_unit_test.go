@@ -0,0 +1,136 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/datetime"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+func newMoongateTestGameState(t *testing.T) (*GameState, *MockSystemCallbacks) {
+	gs, mockCallbacks := newUnitTestGameState(t)
+	gs.MapState.PlayerLocation.Location = references.Britannia_Underworld
+	gs.MapState.PlayerLocation.Floor = 0
+	gs.moongateStones = MoongateStones{
+		datetime.NewMoon:        {Location: references.Britannia_Underworld, Position: references.Position{X: 10, Y: 10}},
+		datetime.CrescentWaxing: {Location: references.Britannia_Underworld, Position: references.Position{X: 20, Y: 20}},
+		datetime.FullMoon:       {Location: references.Britannia_Underworld, Position: references.Position{X: 50, Y: 50}},
+	}
+	// 1-5-139 - Trammel is Crescent Waxing and Felucca is Full Moon
+	gs.DateTime = datetime.UltimaDate{Year: 139, Month: 1, Day: 5, Hour: 22}
+	gs.MapState.PlayerLocation.Position = references.Position{X: 10, Y: 10}
+	return gs, mockCallbacks
+}
+
+func TestNewMoongateStonesFromRaw_ReadsEachPhasesStone(t *testing.T) {
+	const lbStonesX, lbStonesY, lbStonesMap, lbStonesLevel = 0x28A, 0x292, 0x29A, 0x2A2
+	rawSaveData := make([]byte, savedGamFileSize)
+	for phase := range datetime.NumberOfMoonPhases {
+		rawSaveData[lbStonesMap+phase] = byte(references.EmptyLocation)
+	}
+	rawSaveData[lbStonesX+2], rawSaveData[lbStonesY+2] = 0x3A, 0x66
+	rawSaveData[lbStonesMap+2], rawSaveData[lbStonesLevel+2] = byte(references.Britannia_Underworld), 0xFF
+	rawSaveData[lbStonesX+5], rawSaveData[lbStonesY+5] = 7, 9
+	rawSaveData[lbStonesMap+5], rawSaveData[lbStonesLevel+5] = byte(references.Britain), 1
+
+	stones := NewMoongateStonesFromRaw(rawSaveData)
+
+	if len(stones) != 2 {
+		t.Fatalf("Expected only the two phases with a stone, got %v", stones)
+	}
+	expected := MoongateStone{Location: references.Britannia_Underworld, Position: references.Position{X: 0x3A, Y: 0x66}, Floor: -1}
+	if got := stones[datetime.FirstQuarter]; got != expected {
+		t.Errorf("Expected the First Quarter stone in the underworld, got %v", got)
+	}
+	expected = MoongateStone{Location: references.Britain, Position: references.Position{X: 7, Y: 9}, Floor: 1}
+	if got := stones[datetime.GibbousWaning]; got != expected {
+		t.Errorf("Expected the Gibbous Waning stone upstairs in Britain, got %v", got)
+	}
+}
+
+func TestIsMoongateAt_OnlyOnTheStonesMapAtNight(t *testing.T) {
+	gs, _ := newMoongateTestGameState(t)
+	gatePosition := references.Position{X: 20, Y: 20}
+
+	if !gs.IsMoongateAt(&gatePosition) {
+		t.Errorf("Expected a moongate at night")
+	}
+	if gs.IsMoongateAt(&references.Position{X: 21, Y: 20}) {
+		t.Errorf("Expected no moongate away from the stones")
+	}
+
+	gs.DateTime.Hour = 12
+	if gs.IsMoongateAt(&gatePosition) {
+		t.Errorf("Expected no moongate during the day")
+	}
+
+	gs.DateTime.Hour = 22
+	gs.MapState.PlayerLocation.Floor = -1
+	if gs.IsMoongateAt(&gatePosition) {
+		t.Errorf("Expected no moongate in the underworld")
+	}
+}
+
+func TestEnterMoongate_KeepsTheStonesLevel(t *testing.T) {
+	gs, _ := newMoongateTestGameState(t)
+	underworldStone := MoongateStone{Location: references.Britannia_Underworld, Position: references.Position{X: 30, Y: 40}, Floor: -1}
+	gs.moongateStones[datetime.FullMoon] = underworldStone
+
+	if !gs.EnterMoongateIfPresent() {
+		t.Fatalf("Expected the party to travel through the moongate")
+	}
+	if gs.MapState.PlayerLocation.Floor != -1 || gs.MapState.PlayerLocation.Position != underworldStone.Position {
+		t.Errorf("Expected to arrive on the underworld stone, got %v on level %d",
+			gs.MapState.PlayerLocation.Position, gs.MapState.PlayerLocation.Floor)
+	}
+}
+
+func TestEnterMoongate_TravelsByMoonPhase(t *testing.T) {
+	tests := []struct {
+		name     string
+		hour     byte
+		expected references.Position
+	}{
+		{"Trammel leads to the Crescent Waxing gate before noon", 3, references.Position{X: 20, Y: 20}},
+		{"Felucca leads to the Full Moon gate after noon", 22, references.Position{X: 50, Y: 50}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs, mockCallbacks := newMoongateTestGameState(t)
+			gs.DateTime.Hour = tt.hour
+
+			if !gs.EnterMoongateIfPresent() {
+				t.Fatalf("Expected the party to travel through the moongate")
+			}
+			if !gs.MapState.PlayerLocation.Position.Equals(&tt.expected) {
+				t.Errorf("Expected to arrive at %v, got %v", tt.expected, gs.MapState.PlayerLocation.Position)
+			}
+			mockCallbacks.AssertSoundEffectPlayed(SoundMoongate)
+		})
+	}
+}
+
+func TestEnterMoongate_NoTravel(t *testing.T) {
+	gs, mockCallbacks := newMoongateTestGameState(t)
+	gs.MapState.PlayerLocation.Position = references.Position{X: 11, Y: 10}
+	if gs.EnterMoongateIfPresent() {
+		t.Errorf("Expected no travel without a moongate")
+	}
+	mockCallbacks.AssertNoSoundEffects()
+
+	gs.MapState.PlayerLocation.Position = references.Position{X: 10, Y: 10}
+	gs.DateTime.Day = 2 // Felucca is Crescent Waxing, which has no gate here
+	gs.DateTime.Hour = 22
+	delete(gs.moongateStones, datetime.CrescentWaxing)
+	if gs.EnterMoongateIfPresent() {
+		t.Errorf("Expected no travel when the moon phase leads nowhere")
+	}
+	if !gs.MapState.PlayerLocation.Position.Equals(&references.Position{X: 10, Y: 10}) {
+		t.Errorf("Expected the party to stay put")
+	}
+
+	gs.DateTime.Hour, gs.DateTime.Minute = 0, 5
+	gs.EnterMoongateIfPresent()
+	mockCallbacks.AssertLastMessage("Shrine of Spirituality!")
+}
@@ -0,0 +1,158 @@
+// Integration tests replaying a whole day of NPC schedules in every towne with real game data.
+// These validate the LEAV/ARIV/POP transitions from NPC_Schedules.md on every floor the Avatar can stand on,
+// and that every NPC that sets off on a transition hour gets where it is going before the next hour.
+package game_state
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/datetime"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_units"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// TestNPCScheduleReplay_FullDayEveryTowne steps through 24 hours on each floor of every small map and checks
+// the schedule actions after every turn
+func TestNPCScheduleReplay_FullDayEveryTowne(t *testing.T) {
+	for location := references.Moonglow; location <= references.Serpents_Hold; location++ {
+		t.Run(location.String(), func(t *testing.T) {
+			gs, _ := NewIntegrationTestBuilder(t).
+				WithLocation(location).
+				// the Avatar stands in the corner, out of everyone's way
+				WithPlayerAt(0, 0).
+				WithSystemCallbacks().
+				WithRandomSeed(42).
+				Build()
+
+			if gs == nil {
+				return
+			}
+
+			slr := gs.GameReferences.LocationReferences.GetLocationReference(location)
+			for _, floor := range slr.ListOfFloors {
+				t.Run(fmt.Sprintf("floor %d", floor), func(t *testing.T) {
+					replayScheduleDay(t, gs, slr, floor)
+				})
+			}
+		})
+	}
+}
+
+func replayScheduleDay(t *testing.T, gs *GameState, slr *references.SmallLocationReference, floor references.FloorNumber) {
+	gs.MapState.PlayerLocation.Floor = floor
+	gs.DateTime.Hour = 0
+	gs.DateTime.Minute = 0
+	gs.CurrentNPCAIController.PopulateMapFirstLoad()
+
+	for _, friendly := range getScheduledNPCs(gs) {
+		refBehaviour := friendly.NPCReference.Schedule.GetIndividualNPCBehaviourByUltimaDate(gs.DateTime)
+		if friendly.Pos() != refBehaviour.Position || friendly.Floor() != refBehaviour.Floor {
+			t.Errorf("NPC %d should start the day at its scheduled spot", friendly.MapUnitDetails().NPCNum)
+		}
+	}
+
+	// onTheirWay are the NPCs that set off this hour and haven't yet reached their destination
+	onTheirWay := make(map[int]*map_units.NPCFriendly)
+
+	turnsPerDay := datetime.HoursPerDay * datetime.MinutesPerHour / DefaultSmallMapMinutesPerTurn
+	for turn := 0; turn < turnsPerDay; turn++ {
+		previousHour := gs.DateTime.Hour
+		gs.DateTime.Advance(DefaultSmallMapMinutesPerTurn)
+		hourChanged := previousHour != gs.DateTime.Hour
+
+		if hourChanged {
+			for _, friendly := range onTheirWay {
+				t.Errorf("NPC %d didn't reach its destination for hour %d by the end of the hour",
+					friendly.MapUnitDetails().NPCNum, previousHour)
+			}
+			clear(onTheirWay)
+			for _, friendly := range getScheduledNPCs(gs) {
+				if friendly.NPCReference.Schedule.IsTransitionHour(gs.DateTime.Hour) {
+					onTheirWay[friendly.MapUnitDetails().NPCNum] = friendly
+				}
+			}
+			// anyone already there has nowhere to go
+			forgetArrivedNPCs(gs, onTheirWay)
+		}
+
+		gs.CurrentNPCAIController.AdvanceNextTurnCalcAndMoveNPCs()
+		forgetArrivedNPCs(gs, onTheirWay)
+
+		for _, friendly := range getScheduledNPCs(gs) {
+			assertScheduleReplayTurn(t, gs, slr, friendly, hourChanged)
+		}
+	}
+}
+
+// forgetArrivedNPCs drops the NPCs that are standing at their scheduled position on their scheduled floor
+func forgetArrivedNPCs(gs *GameState, onTheirWay map[int]*map_units.NPCFriendly) {
+	for nNPC, friendly := range onTheirWay {
+		refBehaviour := friendly.NPCReference.Schedule.GetIndividualNPCBehaviourByUltimaDate(gs.DateTime)
+		if friendly.Pos() == refBehaviour.Position && friendly.Floor() == refBehaviour.Floor {
+			delete(onTheirWay, nNPC)
+		}
+	}
+}
+
+func assertScheduleReplayTurn(t *testing.T, gs *GameState, slr *references.SmallLocationReference, friendly *map_units.NPCFriendly, hourChanged bool) {
+	t.Helper()
+
+	details := friendly.MapUnitDetails()
+	when := fmt.Sprintf("NPC %d at %s", details.NPCNum, gs.DateTime.GetTimeAsString())
+	refBehaviour := friendly.NPCReference.Schedule.GetIndividualNPCBehaviourByUltimaDate(gs.DateTime)
+	atDestination := friendly.Pos() == refBehaviour.Position && friendly.Floor() == refBehaviour.Floor
+	currentFloor := gs.MapState.PlayerLocation.Floor
+
+	if !isFloorInSmallMap(slr, friendly.Floor()) {
+		t.Fatalf("%s is on floor %d which doesn't exist", when, friendly.Floor())
+	}
+	pos := friendly.Pos()
+	if pos.X < 0 || pos.Y < 0 || pos.X >= references.XSmallMapTiles || pos.Y >= references.YSmallMapTiles {
+		t.Fatalf("%s has wandered off the map to %v", when, pos)
+	}
+
+	switch details.ScheduleAction {
+	case references.SchedulePop:
+		t.Fatalf("%s should have popped to its destination within the turn", when)
+	case references.ScheduleInactive:
+		if friendly.Floor() != refBehaviour.Floor {
+			t.Fatalf("%s is idle on floor %d but is scheduled for floor %d", when, friendly.Floor(), refBehaviour.Floor)
+		}
+		if friendly.Floor() != currentFloor && !atDestination {
+			t.Fatalf("%s is out of sight but not at its destination", when)
+		}
+	case references.ScheduleArriveFromAbove, references.ScheduleArriveFromBelow:
+		if friendly.Floor() == currentFloor || refBehaviour.Floor != currentFloor {
+			t.Fatalf("%s is arriving but isn't coming from another floor to ours", when)
+		}
+	case references.ScheduleMove, references.ScheduleLeaveUp, references.ScheduleLeaveDown:
+		if friendly.Floor() != currentFloor {
+			t.Fatalf("%s is walking on a floor the Avatar can't see", when)
+		}
+	}
+
+	if hourChanged && friendly.NPCReference.Schedule.IsTransitionHour(gs.DateTime.Hour) &&
+		!atDestination && details.ScheduleAction == references.ScheduleInactive {
+		t.Fatalf("%s didn't set off on its transition hour", when)
+	}
+}
+
+func getScheduledNPCs(gs *GameState) []*map_units.NPCFriendly {
+	friendlies := make([]*map_units.NPCFriendly, 0)
+	for _, mu := range *gs.CurrentNPCAIController.GetNpcs() {
+		if friendly, ok := mu.(*map_units.NPCFriendly); ok && !friendly.IsEmptyMapUnit() && friendly.IsVisible() {
+			friendlies = append(friendlies, friendly)
+		}
+	}
+	return friendlies
+}
+
+func isFloorInSmallMap(slr *references.SmallLocationReference, floor references.FloorNumber) bool {
+	for _, smallMapFloor := range slr.ListOfFloors {
+		if smallMapFloor == floor {
+			return true
+		}
+	}
+	return false
+}
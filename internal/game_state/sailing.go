@@ -0,0 +1,105 @@
+package game_state
+
+import (
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// A frigate with furled sails is rowed a tile at a time, which is hard going against the wind.
+// With its sails hoisted the wind carries it along its heading at the end of every turn - unless
+// it is heading straight into the wind. Skiffs have no sails and are always rowed.
+// See Movement_Overworld.md Wind System and Commands.md Yell.
+// TODO: TBD placeholder rules - Movement_Overworld.md does not document how the party's ship moves
+// with the wind, so the rowing odds, "Slow progress!" and the light cross wind resting every other
+// turn are not the original's
+
+const (
+	// rowing into a light wind only makes headway one attempt in two, and into a strong wind one in three
+	rowAgainstLightWindOdds  = 2
+	rowAgainstStrongWindOdds = 3
+)
+
+// ActionYellSails hoists or furls the sails when the party is aboard a frigate. It returns false
+// when there are no sails to yell at, so the Yell command can carry on as normal.
+func (g *GameState) ActionYellSails() bool {
+	vehicleDetails := g.PartyVehicle.GetVehicleDetails()
+	if vehicleDetails.VehicleType != references.FrigateVehicle {
+		return false
+	}
+
+	if vehicleDetails.AreSailsHoisted() {
+		g.SystemCallbacks.Message.AddRowStr("FURL!")
+		vehicleDetails.FurlSails()
+	} else {
+		g.SystemCallbacks.Message.AddRowStr("HOIST!")
+		vehicleDetails.HoistSails()
+	}
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	return true
+}
+
+// IsPartyUnderSail is true when the party's frigate has its sails hoisted
+func (g *GameState) IsPartyUnderSail() bool {
+	return g.PartyVehicle.GetVehicleDetails().AreSailsHoisted()
+}
+
+// TryToRowPartyVehicle returns false when a frigate or skiff being rowed into the wind makes no
+// headway this turn
+func (g *GameState) TryToRowPartyVehicle(direction references.Direction) bool {
+	switch g.PartyVehicle.GetVehicleDetails().VehicleType { //nolint:exhaustive
+	case references.FrigateVehicle, references.SkiffVehicle:
+	default:
+		return true
+	}
+
+	if g.IsWindCalm() || direction != g.WindDirection.GetOppositeDirection() {
+		return true
+	}
+
+	odds := rowAgainstLightWindOdds
+	if g.WindStrength == WindStrong {
+		odds = rowAgainstStrongWindOdds
+	}
+	if g.OneInXOdds(odds) {
+		return true
+	}
+
+	g.SystemCallbacks.Message.AddRowStr("Slow progress!")
+	return false
+}
+
+// getSailingHeading returns the heading the wind carries a frigate under sail this turn, or false
+// if it is not carried at all. A light wind only carries a ship across the wind every other turn.
+func (g *GameState) getSailingHeading() (references.Direction, bool) {
+	vehicleDetails := g.PartyVehicle.GetVehicleDetails()
+	if !vehicleDetails.AreSailsHoisted() || g.IsWindCalm() {
+		return references.NoneDirection, false
+	}
+
+	heading := vehicleDetails.GetCurrentDirection()
+	switch {
+	case heading == g.WindDirection.GetOppositeDirection():
+		return references.NoneDirection, false
+	case heading != g.WindDirection && g.WindStrength == WindLight && g.DateTime.Turn%2 == 0:
+		return references.NoneDirection, false
+	}
+	return heading, true
+}
+
+// sailPartyFrigateWithWind moves a frigate under sail a tile along its heading, running it aground
+// if the wind drives it onto anything but open water
+func (g *GameState) sailPartyFrigateWithWind() bool {
+	heading, ok := g.getSailingHeading()
+	if !ok {
+		return false
+	}
+
+	newPosition := heading.GetNewPositionInDirection(&g.MapState.PlayerLocation.Position).
+		GetWrapped(references.XLargeMapTiles, references.YLargeMapTiles)
+	if !g.IsPassable(newPosition) {
+		g.runPartyShipAground()
+		return false
+	}
+
+	g.movePartyTo(*newPosition)
+	return true
+}
@@ -0,0 +1,196 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_units"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+func newSailingTestGameState(t *testing.T, vehicleType references.VehicleType) (*GameState, *MockSystemCallbacks) {
+	gs, mockCallbacks := newUnitTestGameState(t)
+	gs.MapState.PlayerLocation.Location = references.Britannia_Underworld
+	gs.MapState.PlayerLocation.Position = references.Position{X: 100, Y: 100}
+	gs.PartyVehicle = *map_units.NewNPCFriendlyVehiceNewRef(vehicleType, gs.MapState.PlayerLocation.Position, 0)
+	gs.PartyVehicle.GetVehicleDetails().SetPartyVehicleDirection(references.Up)
+	return gs, mockCallbacks
+}
+
+func TestWind_Description(t *testing.T) {
+	gs, _ := newSailingTestGameState(t, references.FrigateVehicle)
+
+	tests := []struct {
+		direction references.Direction
+		strength  WindStrength
+		expected  string
+	}{
+		{references.NoneDirection, WindStrong, "Calm"},
+		{references.Up, WindCalm, "Calm"},
+		{references.Up, WindLight, "South Winds"},
+		{references.Left, WindStrong, "Strong East Winds"},
+	}
+	for _, tt := range tests {
+		gs.SetWind(tt.direction, tt.strength)
+		if got := gs.GetWindDescription(); got != tt.expected {
+			t.Errorf("Expected %q, got %q", tt.expected, got)
+		}
+	}
+}
+
+func TestWind_ChangesRarelyAndIsSeldomCalm(t *testing.T) {
+	gs, _ := newSailingTestGameState(t, references.NoPartyVehicle)
+	gs.SetWind(references.Up, WindLight)
+
+	const turns = 64 * 1000
+	changes, calms := 0, 0
+	previousDirection, previousStrength := gs.WindDirection, gs.WindStrength
+	for range turns {
+		gs.updateWind()
+		if gs.WindDirection != previousDirection || gs.WindStrength != previousStrength {
+			changes++
+			if gs.IsWindCalm() {
+				calms++
+			}
+		}
+		previousDirection, previousStrength = gs.WindDirection, gs.WindStrength
+	}
+
+	// roughly 1 in 64 turns rolls a new wind, though some rolls repeat the current one
+	if changes < 500 || changes > 1100 {
+		t.Errorf("Expected the wind to change about once every 64 turns, got %d changes", changes)
+	}
+	// a new wind is calm about 1 time in 17 (one of five picks, kept a quarter of the time)
+	if calms > changes/5 {
+		t.Errorf("Expected calm to be rare, got %d of %d changes", calms, changes)
+	}
+}
+
+func TestWind_RelHurSetsTheWind(t *testing.T) {
+	gs, _ := newSailingTestGameState(t, references.FrigateVehicle)
+	gs.SetWind(references.NoneDirection, WindCalm)
+
+	if !gs.castRelHur(references.Right) {
+		t.Fatalf("Expected Rel Hur to change the wind")
+	}
+	if gs.WindDirection != references.Right || gs.WindStrength != WindLight {
+		t.Errorf("Expected a light wind blowing east, got %d/%d", gs.WindDirection, gs.WindStrength)
+	}
+}
+
+func TestYellSails_HoistsAndFurls(t *testing.T) {
+	gs, mockCallbacks := newSailingTestGameState(t, references.FrigateVehicle)
+
+	if !gs.ActionYellLargeMap(references.NoneDirection) {
+		t.Fatalf("Expected yelling aboard a frigate to hoist the sails")
+	}
+	mockCallbacks.AssertLastMessage("HOIST!")
+	if !gs.IsPartyUnderSail() {
+		t.Errorf("Expected the sails to be hoisted")
+	}
+	if sprite := gs.PartyVehicle.GetVehicleDetails().GetBoardedSpriteIndex(); sprite != indexes.FrigateUpUnfurled {
+		t.Errorf("Expected the unfurled frigate sprite, got %d", sprite)
+	}
+
+	gs.ActionYellLargeMap(references.NoneDirection)
+	mockCallbacks.AssertLastMessage("FURL!")
+	if gs.IsPartyUnderSail() {
+		t.Errorf("Expected the sails to be furled")
+	}
+}
+
+func TestYellSails_NoSailsOnASkiff(t *testing.T) {
+	gs, mockCallbacks := newSailingTestGameState(t, references.SkiffVehicle)
+
+	if gs.ActionYellSails() {
+		t.Errorf("Expected a skiff to have no sails")
+	}
+	mockCallbacks.AssertNoMessages()
+	if gs.PartyVehicle.GetVehicleDetails().HoistSails() {
+		t.Errorf("Expected a skiff to refuse to hoist sails")
+	}
+}
+
+func TestSailing_HeadingAgainstTheWind(t *testing.T) {
+	tests := []struct {
+		name     string
+		wind     references.Direction
+		strength WindStrength
+		turn     uint32
+		moves    bool
+	}{
+		{"the wind behind carries the ship", references.Up, WindLight, 2, true},
+		{"a strong cross wind carries the ship", references.Left, WindStrong, 2, true},
+		{"a light cross wind carries the ship on odd turns", references.Left, WindLight, 3, true},
+		{"a light cross wind rests on even turns", references.Left, WindLight, 2, false},
+		{"the ship cannot sail into the wind", references.Down, WindStrong, 3, false},
+		{"a calm leaves the ship becalmed", references.NoneDirection, WindCalm, 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs, _ := newSailingTestGameState(t, references.FrigateVehicle)
+			gs.PartyVehicle.GetVehicleDetails().HoistSails()
+			gs.SetWind(tt.wind, tt.strength)
+			gs.DateTime.Turn = tt.turn
+
+			heading, moves := gs.getSailingHeading()
+			if moves != tt.moves {
+				t.Fatalf("Expected moves=%t, got %t", tt.moves, moves)
+			}
+			if moves && heading != references.Up {
+				t.Errorf("Expected the ship to keep its heading, got %d", heading)
+			}
+		})
+	}
+
+	gs, _ := newSailingTestGameState(t, references.FrigateVehicle)
+	gs.SetWind(references.Up, WindStrong)
+	if _, moves := gs.getSailingHeading(); moves {
+		t.Errorf("Expected a ship with furled sails to stay put")
+	}
+}
+
+func TestRowing_SlowAgainstTheWind(t *testing.T) {
+	for _, vehicleType := range []references.VehicleType{references.FrigateVehicle, references.SkiffVehicle} {
+		gs, mockCallbacks := newSailingTestGameState(t, vehicleType)
+		gs.SetWind(references.Down, WindStrong)
+
+		for range 20 {
+			if !gs.TryToRowPartyVehicle(references.Down) {
+				t.Fatalf("Expected rowing with the wind to always make headway")
+			}
+		}
+
+		headway := 0
+		const attempts = 300
+		for range attempts {
+			if gs.TryToRowPartyVehicle(references.Up) {
+				headway++
+			}
+		}
+		if headway == 0 || headway > attempts/2 {
+			t.Errorf("Expected about one in three attempts against a strong wind to succeed, got %d of %d", headway, attempts)
+		}
+		mockCallbacks.AssertMessageContains("Slow progress!")
+	}
+
+	gs, _ := newSailingTestGameState(t, references.HorseVehicle)
+	gs.SetWind(references.Down, WindStrong)
+	for range 20 {
+		if !gs.TryToRowPartyVehicle(references.Up) {
+			t.Fatalf("Expected the wind not to slow a horse")
+		}
+	}
+}
+
+func TestPass_UnderSailStopsTheShip(t *testing.T) {
+	gs, mockCallbacks := newSailingTestGameState(t, references.FrigateVehicle)
+	gs.PartyVehicle.GetVehicleDetails().HoistSails()
+
+	gs.ActionPass()
+	mockCallbacks.AssertMessageContains("Sheets in irons!")
+	if gs.IsPartyUnderSail() {
+		t.Errorf("Expected passing to furl the sails")
+	}
+}
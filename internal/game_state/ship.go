@@ -0,0 +1,233 @@
+package game_state
+
+import (
+	"fmt"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_units"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// A frigate's hull is worn down by pirate broadsides, storms and running onto reefs and shoals. It
+// is patched up by holing up at anchor, and when it gives out the ship goes down - taking the party
+// with it unless there is a skiff aboard to escape in.
+// See Commands.md Fire - Ship (Broadsides) and Hole Up & Camp.
+//
+// TODO: the pirates' odds of firing, the damage from running aground and the "Pirates fire!", "Ran
+// aground!", "Thy ship sinks!", "Abandon ship!" and "Drowned!" messages are TBD placeholders - they are
+// not sourced from the original game
+
+const (
+	// below this the ship is in danger of sinking, and holing up always patches it back to at least this
+	badlyDamagedHullPoints = 10
+	// cannonballs fly up to 3 tiles from the side of a ship
+	broadsideRange = 3
+	// a cannonball knocks 1 to 20 points off a ship's hull
+	cannonballMaxDamage = 20
+	// a pirate ship with the party in its sights fires one turn in four (TBD)
+	pirateBroadsideOdds = 4
+	// running onto a reef or shoal knocks 1 to 5 points off the hull (TBD)
+	runAgroundMaxDamage = 5
+	// repairs take five spells of 5 minutes, during which the world carries on
+	shipRepairSpells        = 5
+	shipRepairSpellMinutes  = 5
+	shipRepairMaxHullPoints = 3
+)
+
+// missiles and explosions are drawn relative to the party, which sits at the centre of the 11x11 view
+const (
+	partyScreenTileX = 5
+	partyScreenTileY = 5
+)
+
+// IsPartyAboardFrigate is true when the party is sailing (or rowing) a frigate
+func (g *GameState) IsPartyAboardFrigate() bool {
+	return g.PartyVehicle.GetVehicleDetails().VehicleType == references.FrigateVehicle
+}
+
+// isBroadside is true when firing in direction is out of the side of a ship with the given heading
+func isBroadside(heading references.Direction, direction references.Direction) bool {
+	headingIsVertical := heading == references.Up || heading == references.Down
+	directionIsVertical := direction == references.Up || direction == references.Down
+	return direction != references.NoneDirection && headingIsVertical != directionIsVertical
+}
+
+// fireShipBroadside fires the party's cannons out of the side of the ship at the first monster within
+// range - anything but a whirlpool
+func (g *GameState) fireShipBroadside(direction references.Direction) bool {
+	vehicleDetails := g.PartyVehicle.GetVehicleDetails()
+	if !isBroadside(vehicleDetails.GetCurrentDirection(), direction) {
+		g.SystemCallbacks.Message.AddRowStr("Fire broadsides only!")
+		return false
+	}
+
+	g.SystemCallbacks.Audio.PlaySoundEffect(SoundCannonFire)
+
+	targetPosition := g.MapState.PlayerLocation.Position
+	for distance := 1; distance <= broadsideRange; distance++ {
+		targetPosition = *direction.GetNewPositionInDirection(&targetPosition).
+			GetWrapped(references.XLargeMapTiles, references.YLargeMapTiles)
+
+		target := g.getBroadsideTargetAtPositionOrNil(targetPosition)
+		if target == nil {
+			continue
+		}
+
+		g.showCannonballFromParty(direction, distance, true)
+		if target.DamageHull(g.RandomIntInRange(1, cannonballMaxDamage)) {
+			g.CurrentNPCAIController.GetNpcs().RemoveNPCAtPosition(targetPosition)
+		}
+		return true
+	}
+
+	// nothing in range, but the cannons are still fired for show
+	g.showCannonballFromParty(direction, broadsideRange, false)
+	return true
+}
+
+func (g *GameState) showCannonballFromParty(direction references.Direction, distance int, hit bool) {
+	target := direction.GetNewPositionInDirection(&references.Position{X: partyScreenTileX, Y: partyScreenTileY})
+	targetX := partyScreenTileX + (int(target.X)-partyScreenTileX)*distance
+	targetY := partyScreenTileY + (int(target.Y)-partyScreenTileY)*distance
+
+	g.SystemCallbacks.Visual.ShowMissileEffect(partyScreenTileX, partyScreenTileY, targetX, targetY,
+		references.MissileCannonBall.GetStringName())
+	if hit {
+		g.SystemCallbacks.Visual.KapowAt(targetX, targetY)
+	}
+}
+
+func (g *GameState) getBroadsideTargetAtPositionOrNil(position references.Position) *map_units.NPCEnemy {
+	if g.CurrentNPCAIController == nil {
+		return nil
+	}
+	for _, mapUnit := range *g.CurrentNPCAIController.GetNpcs() {
+		enemy, ok := mapUnit.(*map_units.NPCEnemy)
+		if ok && !enemy.IsWhirlpool() && enemy.Pos() == position {
+			return enemy
+		}
+	}
+	return nil
+}
+
+// pirateShipsFireBroadsides gives every pirate ship that has the party's frigate off its side a
+// chance to fire on it
+func (g *GameState) pirateShipsFireBroadsides() {
+	if !g.IsPartyAboardFrigate() || g.CurrentNPCAIController == nil {
+		return
+	}
+
+	for _, mapUnit := range *g.CurrentNPCAIController.GetNpcs() {
+		pirateShip, ok := mapUnit.(*map_units.NPCEnemy)
+		if !ok || !pirateShip.IsPirateShip() || !g.isPartyOffTheSideOf(pirateShip) {
+			continue
+		}
+		if !g.OneInXOdds(pirateBroadsideOdds) {
+			continue
+		}
+
+		g.SystemCallbacks.Audio.PlaySoundEffect(SoundBroadside)
+		g.SystemCallbacks.Visual.KapowAt(partyScreenTileX, partyScreenTileY)
+		g.SystemCallbacks.Message.AddRowStr("Pirates fire!")
+		if !g.DamagePartyShip(g.RandomIntInRange(1, cannonballMaxDamage)) {
+			// the party is no longer aboard a frigate to fire at
+			return
+		}
+	}
+}
+
+func (g *GameState) isPartyOffTheSideOf(pirateShip *map_units.NPCEnemy) bool {
+	heading := pirateShip.GetPirateShipHeading()
+	for _, direction := range windDirections {
+		if !isBroadside(heading, direction) {
+			continue
+		}
+		position := pirateShip.Pos()
+		for range broadsideRange {
+			position = *direction.GetNewPositionInDirection(&position).
+				GetWrapped(references.XLargeMapTiles, references.YLargeMapTiles)
+			if position == g.MapState.PlayerLocation.Position {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// runPartyShipAground is called when the wind drives the party's frigate onto a reef or shoal. The
+// hull is holed and the crew take in the sails before it happens again.
+func (g *GameState) runPartyShipAground() {
+	g.SystemCallbacks.Message.AddRowStr("Ran aground!")
+	g.PartyVehicle.GetVehicleDetails().FurlSails()
+	g.DamagePartyShip(g.RandomIntInRange(1, runAgroundMaxDamage))
+}
+
+// DamagePartyShip knocks points off the hull of the party's frigate, sinking it if the hull gives
+// out. It returns false if the ship went down.
+func (g *GameState) DamagePartyShip(points int) bool {
+	if !g.IsPartyAboardFrigate() {
+		return true
+	}
+
+	vehicleDetails := g.PartyVehicle.GetVehicleDetails()
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	if vehicleDetails.DamageHull(points) {
+		g.sinkPartyShip()
+		return false
+	}
+	if vehicleDetails.GetHullPoints() < badlyDamagedHullPoints {
+		g.SystemCallbacks.Message.AddRowStr("DANGER: SHIP BADLY DAMAGED!")
+	}
+	return true
+}
+
+// sinkPartyShip sends the party's frigate to the bottom. With a skiff aboard the party rows away in
+// it, otherwise they are lost with the ship.
+func (g *GameState) sinkPartyShip() {
+	g.SystemCallbacks.Message.AddRowStr("Thy ship sinks!")
+
+	if g.PartyVehicle.GetVehicleDetails().HasAtLeastOneSkiff() {
+		g.SystemCallbacks.Message.AddRowStr("Abandon ship!")
+		skiff := map_units.NewNPCFriendlyVehiceNewRef(references.SkiffVehicle,
+			g.MapState.PlayerLocation.Position,
+			g.MapState.PlayerLocation.Floor)
+		skiff.SetPos(g.MapState.PlayerLocation.Position)
+		g.PartyVehicle = *skiff
+		return
+	}
+
+	g.SystemCallbacks.Message.AddRowStr("Drowned!")
+	g.PartyVehicle = map_units.NewNPCFriendlyVehiceNoVehicle()
+	for i := range g.PartyState.Characters {
+		if !g.PartyState.IsCharacterInParty(i) {
+			continue
+		}
+		g.PartyState.Characters[i].CurrentHp = 0
+		g.PartyState.Characters[i].Status = party_state.Dead
+	}
+}
+
+// repairPartyShip holes up aboard an anchored frigate to patch the hull
+func (g *GameState) repairPartyShip() bool {
+	vehicleDetails := g.PartyVehicle.GetVehicleDetails()
+	g.SystemCallbacks.Message.AddRowStr("repair...")
+	if vehicleDetails.AreSailsHoisted() {
+		g.SystemCallbacks.Message.AddRowStr("Sails must be lowered!")
+		return false
+	}
+
+	for range shipRepairSpells {
+		g.SystemCallbacks.Flow.AdvanceTime(shipRepairSpellMinutes)
+	}
+
+	for {
+		vehicleDetails.SetHullPoints(vehicleDetails.GetHullPoints() + g.RandomIntInRange(1, shipRepairMaxHullPoints))
+		if vehicleDetails.GetHullPoints() >= badlyDamagedHullPoints {
+			break
+		}
+	}
+
+	g.SystemCallbacks.Message.AddRowStr(fmt.Sprintf("Hull now %02d!", vehicleDetails.GetHullPoints()))
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	return true
+}
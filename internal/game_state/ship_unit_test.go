@@ -0,0 +1,213 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/ai"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_units"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+func newShipTestGameState(t *testing.T) (*GameState, *MockSystemCallbacks) {
+	gs, mockCallbacks := newSailingTestGameState(t, references.FrigateVehicle)
+	gs.CurrentNPCAIController = ai.NewNPCAIControllerLargeMap(ai.NewNPCAIControllerLargeMapInput{})
+
+	addUnitTestPartyMember(gs, 0, "Avatar").CurrentHp = 30
+	return gs, mockCallbacks
+}
+
+func addTestPirateShip(gs *GameState, heading indexes.SpriteIndex, pos references.Position) *map_units.NPCEnemy {
+	pirateShip := map_units.NewPirateShipNPC(&references.Tile{Index: heading}, len(*gs.CurrentNPCAIController.GetNpcs()))
+	pirateShip.SetPos(pos)
+	pirateShip.SetVisible(true)
+	npcs := gs.CurrentNPCAIController.GetNpcs()
+	*npcs = append(*npcs, &pirateShip)
+	return &pirateShip
+}
+
+func TestShip_NewFrigateHasASoundHull(t *testing.T) {
+	gs, _ := newShipTestGameState(t)
+	if hull := gs.PartyVehicle.GetVehicleDetails().GetHullPoints(); hull != map_units.MaxHullPoints {
+		t.Errorf("Expected a new frigate to have %d hull points, got %d", map_units.MaxHullPoints, hull)
+	}
+}
+
+func TestFire_OnlyFromTheSideOfAFrigate(t *testing.T) {
+	gs, mockCallbacks := newSailingTestGameState(t, references.SkiffVehicle)
+	if gs.ActionFireLargeMap(references.Left) {
+		t.Errorf("Expected a skiff to have no cannons")
+	}
+	mockCallbacks.AssertLastMessage("What?")
+
+	gs, mockCallbacks = newShipTestGameState(t)
+	if gs.ActionFireLargeMap(references.Up) {
+		t.Errorf("Expected a ship heading north not to fire over its bow")
+	}
+	mockCallbacks.AssertLastMessage("Fire broadsides only!")
+	mockCallbacks.AssertNoSoundEffects()
+
+	if !gs.ActionFireLargeMap(references.Right) {
+		t.Errorf("Expected the cannons to fire to starboard even with nothing to hit")
+	}
+	mockCallbacks.AssertSoundEffectPlayed(SoundCannonFire)
+}
+
+func addTestMonster(gs *GameState, sprite indexes.SpriteIndex, pos references.Position) *map_units.NPCEnemy {
+	enemyRef := references.EnemyReference{}
+	enemyRef.KeyFrameTile = &references.Tile{Index: sprite}
+	monster := map_units.NewEnemyNPC(enemyRef, len(*gs.CurrentNPCAIController.GetNpcs()))
+	monster.SetPos(pos)
+	monster.SetVisible(true)
+	npcs := gs.CurrentNPCAIController.GetNpcs()
+	*npcs = append(*npcs, &monster)
+	return &monster
+}
+
+func TestFire_OneBroadsideSinksAPirateShip(t *testing.T) {
+	gs, mockCallbacks := newShipTestGameState(t)
+	addTestPirateShip(gs, indexes.PirateShip_Up, references.Position{X: 98, Y: 100})
+
+	if !gs.ActionFireLargeMap(references.Left) {
+		t.Fatalf("Expected the broadside to fire")
+	}
+	if len(mockCallbacks.KapowCalls) == 0 {
+		t.Fatalf("Expected the cannonball to hit the pirate ship")
+	}
+	// it puts to sea at 100, so any hit takes it under 127
+	if len(*gs.CurrentNPCAIController.GetNpcs()) != 0 {
+		t.Errorf("Expected the pirate ship to be sunk")
+	}
+}
+
+func TestFire_BroadsidesHitMonstersButNotWhirlpools(t *testing.T) {
+	gs, mockCallbacks := newShipTestGameState(t)
+	addTestMonster(gs, indexes.Whirlpool_KeyIndex, references.Position{X: 99, Y: 100})
+	addTestMonster(gs, indexes.Dragon_KeyIndex, references.Position{X: 98, Y: 100})
+
+	gs.ActionFireLargeMap(references.Left)
+	if len(mockCallbacks.KapowCalls) == 0 {
+		t.Fatalf("Expected the cannonball to fly past the whirlpool and hit the dragon")
+	}
+	npcs := *gs.CurrentNPCAIController.GetNpcs()
+	if len(npcs) != 1 || npcs[0].Pos() != (references.Position{X: 99, Y: 100}) {
+		t.Errorf("Expected only the whirlpool to be left")
+	}
+}
+
+func TestFire_PirateShipOutOfRange(t *testing.T) {
+	gs, mockCallbacks := newShipTestGameState(t)
+	addTestPirateShip(gs, indexes.PirateShip_Up, references.Position{X: 96, Y: 100})
+
+	gs.ActionFireLargeMap(references.Left)
+	if len(mockCallbacks.KapowCalls) != 0 {
+		t.Errorf("Expected a pirate ship four tiles away to be out of range")
+	}
+}
+
+func TestPirateShips_FireBroadsidesAtTheParty(t *testing.T) {
+	gs, mockCallbacks := newShipTestGameState(t)
+	// heading north, with the party two tiles off its starboard side
+	addTestPirateShip(gs, indexes.PirateShip_Up, references.Position{X: 98, Y: 100})
+
+	for range 20 {
+		gs.pirateShipsFireBroadsides()
+	}
+	mockCallbacks.AssertSoundEffectPlayed(SoundBroadside)
+	mockCallbacks.AssertMessageContains("Pirates fire!")
+	if gs.IsPartyAboardFrigate() && gs.PartyVehicle.GetVehicleDetails().GetHullPoints() == map_units.MaxHullPoints {
+		t.Errorf("Expected the pirates to damage the hull")
+	}
+}
+
+func TestPirateShips_CannotFireOverTheBow(t *testing.T) {
+	gs, mockCallbacks := newShipTestGameState(t)
+	// heading west, straight at the party
+	addTestPirateShip(gs, indexes.PirateShip_Left, references.Position{X: 102, Y: 100})
+
+	for range 50 {
+		gs.pirateShipsFireBroadsides()
+	}
+	mockCallbacks.AssertNoSoundEffects()
+	if hull := gs.PartyVehicle.GetVehicleDetails().GetHullPoints(); hull != map_units.MaxHullPoints {
+		t.Errorf("Expected the hull to be untouched, got %d", hull)
+	}
+}
+
+func TestShip_SinksIntoASkiff(t *testing.T) {
+	gs, mockCallbacks := newShipTestGameState(t)
+	gs.PartyVehicle.GetVehicleDetails().SetSkiffQuantity(1)
+	gs.PartyVehicle.GetVehicleDetails().SetHullPoints(5)
+
+	if gs.DamagePartyShip(5) {
+		t.Fatalf("Expected the ship to sink")
+	}
+	mockCallbacks.AssertMessageContains("Thy ship sinks!")
+	mockCallbacks.AssertLastMessage("Abandon ship!")
+	if vehicleType := gs.PartyVehicle.GetVehicleDetails().VehicleType; vehicleType != references.SkiffVehicle {
+		t.Errorf("Expected the party to escape in a skiff, got %d", vehicleType)
+	}
+	if gs.PartyState.Characters[0].Status == party_state.Dead {
+		t.Errorf("Expected the party to survive")
+	}
+}
+
+func TestShip_SinksAndDrownsTheParty(t *testing.T) {
+	gs, mockCallbacks := newShipTestGameState(t)
+	gs.PartyVehicle.GetVehicleDetails().SetHullPoints(12)
+
+	if !gs.DamagePartyShip(4) {
+		t.Fatalf("Expected the ship to stay afloat")
+	}
+	mockCallbacks.AssertLastMessage("DANGER: SHIP BADLY DAMAGED!")
+
+	gs.DamagePartyShip(20)
+	mockCallbacks.AssertLastMessage("Drowned!")
+	if gs.PartyVehicle.GetVehicleDetails().VehicleType != references.NoPartyVehicle {
+		t.Errorf("Expected the party to have lost their ship")
+	}
+	if gs.PartyState.Characters[0].Status != party_state.Dead {
+		t.Errorf("Expected the party to drown")
+	}
+}
+
+func TestShip_RunsAground(t *testing.T) {
+	gs, mockCallbacks := newShipTestGameState(t)
+	gs.PartyVehicle.GetVehicleDetails().HoistSails()
+
+	gs.runPartyShipAground()
+	mockCallbacks.AssertMessageContains("Ran aground!")
+	if gs.IsPartyUnderSail() {
+		t.Errorf("Expected the sails to be taken in")
+	}
+	if hull := gs.PartyVehicle.GetVehicleDetails().GetHullPoints(); hull >= map_units.MaxHullPoints || hull < map_units.MaxHullPoints-runAgroundMaxDamage {
+		t.Errorf("Expected the reef to knock up to %d points off the hull, got %d", runAgroundMaxDamage, hull)
+	}
+}
+
+func TestHoleUp_RepairsTheHullAtAnchor(t *testing.T) {
+	gs, mockCallbacks := newShipTestGameState(t)
+	gs.PartyVehicle.GetVehicleDetails().SetHullPoints(2)
+	gs.PartyVehicle.GetVehicleDetails().HoistSails()
+
+	if gs.ActionHoleUpLargeMap() {
+		t.Errorf("Expected no repairs under sail")
+	}
+	mockCallbacks.AssertLastMessage("Sails must be lowered!")
+
+	gs.PartyVehicle.GetVehicleDetails().FurlSails()
+	if !gs.ActionHoleUpLargeMap() {
+		t.Fatalf("Expected the hull to be repaired")
+	}
+	hull := gs.PartyVehicle.GetVehicleDetails().GetHullPoints()
+	if hull < badlyDamagedHullPoints || hull > badlyDamagedHullPoints+shipRepairMaxHullPoints {
+		t.Errorf("Expected the hull patched up to just past %d, got %d", badlyDamagedHullPoints, hull)
+	}
+	mockCallbacks.AssertMessageContains("Hull now")
+	mockCallbacks.AssertTimeAdvanced(shipRepairSpells * shipRepairSpellMinutes)
+
+	gs.PartyVehicle.GetVehicleDetails().SetHullPoints(map_units.MaxHullPoints)
+	gs.ActionHoleUpLargeMap()
+	mockCallbacks.AssertLastMessage("Hull now 99!")
+}
@@ -0,0 +1,205 @@
+package game_state
+
+import (
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_units"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// Shopkeepers stand behind a counter, so the party talks to them across it. Talking to a shopkeeper
+// while their shoppe is open greets the party and opens the shoppe; goods are bought and sold at
+// their base price scaled by the town, and the shopkeeper haggles with lines out of SHOPPE.DAT.
+// See docs/ALGOS/Shops.md.
+// TODO: TBD placeholder messages - only "Not enough gold!" is documented, so "Closed! Come back in the
+// morning.", "I don't sell that!", "Thou canst carry no more!", "I don't buy that!" and "Thou hast
+// none to sell!" are not the original's, nor is the 99 limit on what can be carried
+
+const (
+	shopKeeperName = "the shopkeeper"
+	shopName       = "my shoppe"
+
+	// maxShopGoodsCarried is as many of any one of a shoppe's goods as the party can carry
+	maxShopGoodsCarried = 99
+)
+
+// Shop is the shoppe the party is trading with
+type Shop struct {
+	Type     references.ShopType
+	Location references.Location
+	Keeper   *map_units.NPCFriendly
+}
+
+// talkAcrossCounter opens the shoppe of a shopkeeper standing on the far side of a counter in the
+// given direction - it is false if there is no counter or no shopkeeper behind it
+func (g *GameState) talkAcrossCounter(direction references.Direction) bool {
+	counterPos := direction.GetNewPositionInDirection(&g.MapState.PlayerLocation.Position)
+	if g.IsOutOfBounds(*counterPos) {
+		return false
+	}
+	if counter := g.GetLayeredMapByCurrentLocation().GetTileTopMapOnlyTile(counterPos); counter == nil || !counter.IsTalkOverable {
+		return false
+	}
+
+	keeperPos := direction.GetNewPositionInDirection(counterPos)
+	npc := g.CurrentNPCAIController.GetNpcs().GetMapUnitAtPositionOrNil(*keeperPos)
+	if npc == nil {
+		return false
+	}
+	friendly, ok := (*npc).(*map_units.NPCFriendly)
+	if !ok {
+		return false
+	}
+	return g.openShop(friendly)
+}
+
+// openShop greets the party and opens the shopkeeper's shoppe if it is open for business
+func (g *GameState) openShop(keeper *map_units.NPCFriendly) bool {
+	shopType, ok := references.GetShopTypeByNPCType(keeper.NPCReference.GetNPCType())
+	if !ok {
+		return false
+	}
+
+	if !shopType.GetHours().IsOpen(g.DateTime.Hour) {
+		g.SystemCallbacks.Message.AddRowStr("Closed! Come back in the morning.")
+		g.SystemCallbacks.Flow.AdvanceTime(1)
+		return true
+	}
+
+	shop := &Shop{
+		Type:     shopType,
+		Location: g.MapState.PlayerLocation.Location,
+		Keeper:   keeper,
+	}
+	g.sayShoppeLine(shop, references.ShoppeGreeting, references.MerchantStringValues{})
+	g.SystemCallbacks.Talk.OpenShop(shop)
+	g.SystemCallbacks.Flow.AdvanceTime(1)
+	return true
+}
+
+// GetShopBuyPrice is what the shoppe charges for one of its goods
+func (g *GameState) GetShopBuyPrice(shop *Shop, goods references.ShopGoods) int {
+	return shop.Type.GetPrice(shop.Location, goods.BasePrice)
+}
+
+// GetShopSellPrice is what the shoppe pays for one of its goods - half what it charges
+func (g *GameState) GetShopSellPrice(shop *Shop, goods references.ShopGoods) int {
+	return g.GetShopBuyPrice(shop, goods) / 2
+}
+
+// BuyFromShop buys a number of the shoppe's goods if the party can afford them
+func (g *GameState) BuyFromShop(shop *Shop, item references.Item, quantity uint16) bool {
+	goods, ok := references.GetShopGoods(shop.Type, item)
+	if !ok || quantity == 0 {
+		g.SystemCallbacks.Message.AddRowStr("I don't sell that!")
+		return false
+	}
+
+	if reason, ok := g.canCarryShopGoods(item, quantity); !ok {
+		g.SystemCallbacks.Message.AddRowStr(reason)
+		return false
+	}
+
+	price := g.GetShopBuyPrice(shop, goods) * int(quantity)
+	if price > int(g.PartyState.Inventory.Gold.Get()) {
+		g.SystemCallbacks.Message.AddRowStr("Not enough gold!")
+		return false
+	}
+
+	g.PartyState.Inventory.Gold.DecrementBy(uint16(price))
+	switch item.Type() {
+	case references.ItemTypeEquipment:
+		g.PartyState.Inventory.Equipment.IncrementBy(references.Equipment(item.ID()), quantity)
+	case references.ItemTypeReagent:
+		g.PartyState.Inventory.Reagent.IncrementBy(references.Reagent(item.ID()), quantity)
+	case references.ItemTypeProvision:
+		g.PartyState.Inventory.PutItemInInventory(&references.ItemAndQuantity{Item: item, Quantity: quantity})
+	}
+
+	g.sayShoppeLine(shop, references.ShoppeBuying, g.getMerchantStringValues(item, price))
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	return true
+}
+
+// canCarryShopGoods is false, with the reason, when the party has no room for the goods
+func (g *GameState) canCarryShopGoods(item references.Item, quantity uint16) (string, bool) {
+	inventory := &g.PartyState.Inventory
+
+	var carried uint16
+	switch item.Type() {
+	case references.ItemTypeEquipment:
+		carried = inventory.Equipment.Get(references.Equipment(item.ID()))
+	case references.ItemTypeReagent:
+		carried = inventory.Reagent.Get(references.Reagent(item.ID()))
+	case references.ItemTypeProvision:
+		switch references.Provision(item.ID()) {
+		case references.Key:
+			carried = inventory.Provisions.Keys.Get()
+		case references.Gem:
+			carried = inventory.Provisions.Gems.Get()
+		case references.Torches:
+			carried = inventory.Provisions.Torches.Get()
+		}
+	}
+
+	if int(carried)+int(quantity) > maxShopGoodsCarried {
+		return "Thou canst carry no more!", false
+	}
+	return "", true
+}
+
+// SellToShop sells a number of the party's goods to a shoppe that deals in them
+func (g *GameState) SellToShop(shop *Shop, item references.Item, quantity uint16) bool {
+	goods, ok := references.GetShopGoods(shop.Type, item)
+	if !ok || shop.Type != references.ArmsShop || quantity == 0 {
+		g.SystemCallbacks.Message.AddRowStr("I don't buy that!")
+		return false
+	}
+
+	if !g.PartyState.Inventory.Equipment.DecrementBy(references.Equipment(item.ID()), quantity) {
+		g.SystemCallbacks.Message.AddRowStr("Thou hast none to sell!")
+		return false
+	}
+
+	price := g.GetShopSellPrice(shop, goods) * int(quantity)
+	g.PartyState.Inventory.Gold.IncrementBy(uint16(price))
+
+	g.sayShoppeLine(shop, references.ShoppeSelling, g.getMerchantStringValues(item, price))
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	return true
+}
+
+// LeaveShop is the shopkeeper bidding the party farewell
+func (g *GameState) LeaveShop(shop *Shop) {
+	g.sayShoppeLine(shop, references.ShoppeGoodbye, references.MerchantStringValues{})
+}
+
+func (g *GameState) getMerchantStringValues(item references.Item, gold int) references.MerchantStringValues {
+	return references.MerchantStringValues{
+		Gold: gold,
+		Item: g.GameReferences.InventoryItemReferences.GetReferenceByItem(item).ItemName,
+	}
+}
+
+// sayShoppeLine has the shopkeeper say one of their lines of the given kind, if they have any
+func (g *GameState) sayShoppeLine(shop *Shop, line references.ShoppeDialogueLine, values references.MerchantStringValues) {
+	dialogue := g.GameReferences.ShoppeDialogue
+	first, last, ok := dialogue.GetLineRange(shop.Type, line)
+	if !ok {
+		return
+	}
+
+	values.KeeperName = shopKeeperName
+	values.ShopName = shopName
+	values.TimeOfDay = g.getTimeOfDayGreeting()
+	g.SystemCallbacks.Message.AddRowStr(dialogue.GetMerchantString(first+g.rng.Intn(last-first+1), values))
+}
+
+func (g *GameState) getTimeOfDayGreeting() string {
+	switch {
+	case g.DateTime.Hour < 12:
+		return "morning"
+	case g.DateTime.Hour < 17:
+		return "afternoon"
+	default:
+		return "evening"
+	}
+}
@@ -0,0 +1,164 @@
+package game_state
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/ai"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_units"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+// newShopsTestGameState has the party standing south of a counter with a blacksmith behind it. The
+// counter is laid on the overworld, which is the map a test can build - counters work the same on
+// any map.
+func newShopsTestGameState(t *testing.T) (*GameState, *MockSystemCallbacks, *map_units.NPCFriendly) {
+	var rawShoppeData bytes.Buffer
+	for i := 0; i < 60; i++ {
+		rawShoppeData.WriteString("% for &")
+		rawShoppeData.WriteByte(0)
+	}
+	shoppeDialogue, err := references.NewShoppeDialogueFromBytes(rawShoppeData.Bytes(), references.NewWordDict(nil))
+	if err != nil {
+		t.Fatalf("Failed to read the shoppe dialogue: %v", err)
+	}
+
+	tiles := references.Tiles{
+		0:                   &references.Tile{Index: 0},
+		indexes.TableMiddle: &references.Tile{Index: indexes.TableMiddle, IsTalkOverable: true},
+	}
+
+	gs, mockCallbacks := newUnitTestGameState(t)
+	gs.GameReferences = &references.GameReferences{
+		InventoryItemReferences: references.NewInventoryItemsReferences(),
+		ShoppeDialogue:          shoppeDialogue,
+	}
+	gs.DateTime.Hour = 12
+	gs.MapState.LayeredMaps = *map_state.NewLayeredMaps(&tiles, &references.LargeMapReference{}, &references.LargeMapReference{}, 19, 13)
+	gs.MapState.PlayerLocation.Location = references.Britannia_Underworld
+	gs.MapState.PlayerLocation.Position = references.Position{X: 15, Y: 15}
+	gs.CurrentNPCAIController = ai.NewNPCAIControllerLargeMap(ai.NewNPCAIControllerLargeMapInput{})
+
+	counterPos := references.Position{X: 15, Y: 14}
+	gs.GetLayeredMapByCurrentLocation().SetTileByLayer(map_state.MapLayer, &counterPos, indexes.TableMiddle)
+	keeper := addTestTownsperson(gs, indexes.TownsPerson_KeyIndex, references.Position{X: 15, Y: 13})
+	keeper.NPCReference.DialogNumber = byte(references.Blacksmith)
+	return gs, mockCallbacks, keeper
+}
+
+func TestShops_TalkingAcrossTheCounterOpensTheShoppe(t *testing.T) {
+	gs, mockCallbacks, keeper := newShopsTestGameState(t)
+
+	if !gs.ActionTalkSmallMap(references.Up) {
+		t.Fatalf("Expected the blacksmith to be talked to across the counter")
+	}
+	if len(mockCallbacks.ShopsOpened) != 1 {
+		t.Fatalf("Expected the shoppe to be opened, got %d", len(mockCallbacks.ShopsOpened))
+	}
+	shop := mockCallbacks.ShopsOpened[0]
+	if shop.Type != references.ArmsShop || shop.Keeper != keeper {
+		t.Errorf("Expected the blacksmith's arms shoppe, got %v", shop.Type)
+	}
+	mockCallbacks.AssertTimeAdvanced(1)
+
+	mockCallbacks.Reset()
+	gs.MapState.PlayerLocation.Position = references.Position{X: 14, Y: 15}
+	if gs.ActionTalkSmallMap(references.Up) {
+		t.Errorf("Expected no-one to talk to without a counter")
+	}
+	mockCallbacks.AssertLastMessage("No-one to talk to!")
+}
+
+func TestShops_ClosedOutsideOfHours(t *testing.T) {
+	gs, mockCallbacks, _ := newShopsTestGameState(t)
+	gs.DateTime.Hour = 22
+
+	gs.ActionTalkSmallMap(references.Up)
+	mockCallbacks.AssertLastMessage("Closed! Come back in the morning.")
+	if len(mockCallbacks.ShopsOpened) != 0 {
+		t.Errorf("Expected a closed shoppe not to open")
+	}
+}
+
+func TestShops_BuyingCostsGold(t *testing.T) {
+	gs, mockCallbacks, keeper := newShopsTestGameState(t)
+	shop := &Shop{Type: references.ArmsShop, Location: references.Moonglow, Keeper: keeper}
+	gs.PartyState.Inventory.Gold.Set(100)
+
+	// a dagger is 10gp, and Moonglow charges a tenth more
+	if !gs.BuyFromShop(shop, references.Dagger, 2) {
+		t.Fatalf("Expected two daggers to be bought")
+	}
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != 78 {
+		t.Errorf("Expected 78gp left, got %d", gold)
+	}
+	if daggers := gs.PartyState.Inventory.Equipment.Get(references.Dagger); daggers != 2 {
+		t.Errorf("Expected two daggers, got %d", daggers)
+	}
+	mockCallbacks.AssertLastMessage("22 for Dagger")
+
+	if gs.BuyFromShop(shop, references.PlateMail, 1) {
+		t.Errorf("Expected plate mail to be out of reach")
+	}
+	mockCallbacks.AssertLastMessage("Not enough gold!")
+
+	if gs.BuyFromShop(shop, references.BlackPearl, 1) {
+		t.Errorf("Expected a blacksmith not to sell reagents")
+	}
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != 78 {
+		t.Errorf("Expected failed purchases to cost nothing, got %d", gold)
+	}
+}
+
+func TestShops_GuildSellsProvisionsButNoSextant(t *testing.T) {
+	gs, mockCallbacks, keeper := newShopsTestGameState(t)
+	shop := &Shop{Type: references.GuildShop, Location: references.Britain, Keeper: keeper}
+	gs.PartyState.Inventory.Gold.Set(1000)
+	gs.PartyState.Inventory.Provisions.Keys.Set(95)
+
+	if !gs.BuyFromShop(shop, references.Torches, 5) {
+		t.Fatalf("Expected five torches to be bought")
+	}
+	if torches := gs.PartyState.Inventory.Provisions.Torches.Get(); torches != 5 {
+		t.Errorf("Expected five torches, got %d", torches)
+	}
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != 960 {
+		t.Errorf("Expected 960gp left, got %d", gold)
+	}
+
+	if gs.BuyFromShop(shop, references.Key, 5) {
+		t.Errorf("Expected no room for five more keys")
+	}
+	mockCallbacks.AssertLastMessage("Thou canst carry no more!")
+
+	if gs.BuyFromShop(shop, references.Sextant, 1) {
+		t.Errorf("Expected no sextant to be sold")
+	}
+	mockCallbacks.AssertLastMessage("I don't sell that!")
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != 960 {
+		t.Errorf("Expected 960gp left, got %d", gold)
+	}
+}
+
+func TestShops_SellingPaysHalf(t *testing.T) {
+	gs, mockCallbacks, keeper := newShopsTestGameState(t)
+	shop := &Shop{Type: references.ArmsShop, Location: references.Britain, Keeper: keeper}
+	gs.PartyState.Inventory.Equipment.Set(references.ShortSword, 1)
+
+	if !gs.SellToShop(shop, references.ShortSword, 1) {
+		t.Fatalf("Expected the short sword to be sold")
+	}
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != 30 {
+		t.Errorf("Expected 30gp for the short sword, got %d", gold)
+	}
+	if gs.PartyState.Inventory.Equipment.HasSome(references.ShortSword) {
+		t.Errorf("Expected the short sword to be gone")
+	}
+
+	if gs.SellToShop(shop, references.ShortSword, 1) {
+		t.Errorf("Expected nothing left to sell")
+	}
+	mockCallbacks.AssertLastMessage("Thou hast none to sell!")
+}
@@ -0,0 +1,114 @@
+package game_state
+
+import (
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// spellContext is a bit mask of the map types a spell may be cast on (legacy OUTD/TOWN/DUNG/COMB flags)
+type spellContext byte
+
+const (
+	spellContextOverworld spellContext = 1 << iota
+	spellContextTown
+	spellContextDungeon
+	spellContextCombat
+
+	spellContextAnywhere = spellContextOverworld | spellContextTown | spellContextDungeon | spellContextCombat
+	spellContextNoCombat = spellContextOverworld | spellContextTown | spellContextDungeon
+)
+
+// spellContexts mirrors the Allowed Contexts table in Spells.md
+var spellContexts = map[references.Spell]spellContext{
+	references.InLor:         spellContextNoCombat,
+	references.GravPor:       spellContextCombat,
+	references.AnZu:          spellContextAnywhere,
+	references.AnNox:         spellContextAnywhere,
+	references.Mani:          spellContextAnywhere,
+	references.AnYlem:        spellContextTown | spellContextCombat,
+	references.AnSanct:       spellContextAnywhere,
+	references.AnXenCorp:     spellContextCombat,
+	references.RelHur:        spellContextOverworld,
+	references.InWis:         spellContextOverworld,
+	references.KalXen:        spellContextCombat,
+	references.InXenMani:     spellContextAnywhere,
+	references.VasLor:        spellContextNoCombat,
+	references.VasFlam:       spellContextCombat,
+	references.InFlamGrav:    spellContextDungeon | spellContextCombat,
+	references.InNoxGrav:     spellContextDungeon | spellContextCombat,
+	references.InZuGrav:      spellContextDungeon | spellContextCombat,
+	references.InPor:         spellContextOverworld | spellContextCombat,
+	references.AnGrav:        spellContextDungeon | spellContextCombat,
+	references.InSanct:       spellContextAnywhere,
+	references.InSanctGrav:   spellContextDungeon | spellContextCombat,
+	references.UusPor:        spellContextDungeon,
+	references.DesPor:        spellContextDungeon,
+	references.WisQuas:       spellContextCombat,
+	references.InBetXen:      spellContextCombat,
+	references.AnExPor:       spellContextTown | spellContextCombat,
+	references.InExPor:       spellContextTown | spellContextCombat,
+	references.VasMani:       spellContextAnywhere,
+	references.InZu:          spellContextCombat,
+	references.RelTym:        spellContextAnywhere,
+	references.InVasPorYlem:  spellContextCombat,
+	references.QuasAnWis:     spellContextCombat,
+	references.InAn:          spellContextAnywhere,
+	references.WisAnYlem:     spellContextOverworld,
+	references.AnXenEx:       spellContextCombat,
+	references.RelXenBet:     spellContextCombat,
+	references.SanctLor:      spellContextCombat,
+	references.XenCorp:       spellContextCombat,
+	references.InQuasXen:     spellContextCombat,
+	references.InQuasWis:     spellContextNoCombat,
+	references.InNoxHur:      spellContextCombat,
+	references.InQuasCorp:    spellContextCombat,
+	references.InManiCorp:    spellContextNoCombat,
+	references.KalXenCorp:    spellContextCombat,
+	references.InVasGravCorp: spellContextCombat,
+	references.InFlamHur:     spellContextCombat,
+	references.VasRelPor:     spellContextNoCombat,
+	references.AnTym:         spellContextAnywhere,
+}
+
+func getSpellContextForMapType(mapType references.GeneralMapType) spellContext {
+	switch mapType {
+	case references.LargeMapType:
+		return spellContextOverworld
+	case references.SmallMapType:
+		return spellContextTown
+	case references.DungeonMapType:
+		return spellContextDungeon
+	case references.CombatMapType:
+		return spellContextCombat
+	default:
+		return 0
+	}
+}
+
+// isMagicAbsorbedHere covers the global overrides that block every spell regardless of context
+func (g *GameState) isMagicAbsorbedHere() bool {
+	switch g.MapState.PlayerLocation.Location {
+	case references.Stonegate:
+		return true
+	case references.Palace_of_Blackthorn:
+		// wearing the Crown of Lord British protects the Avatar's magic here
+		return !g.IsWearingCrown()
+	default:
+		return false
+	}
+}
+
+// canCastSpellHere checks the global absorption rules and the spell's allowed contexts.
+// The appropriate failure message is output when the spell cannot be cast.
+func (g *GameState) canCastSpellHere(spell references.Spell) bool {
+	if g.isMagicAbsorbedHere() {
+		g.SystemCallbacks.Message.AddRowStr("Absorbed!")
+		return false
+	}
+
+	mapContext := getSpellContextForMapType(g.MapState.PlayerLocation.Location.GetMapType())
+	if spellContexts[spell]&mapContext == 0 {
+		g.SystemCallbacks.Message.AddRowStr("Not here!")
+		return false
+	}
+	return true
+}
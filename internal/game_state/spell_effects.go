@@ -0,0 +1,151 @@
+package game_state
+
+import (
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+	"github.com/bradhannah/Ultima5ReduxGo/pkg/helpers"
+)
+
+// DurationSpell is the legacy flag of a spell that stays in effect for a number of turns (see Spells.md)
+type DurationSpell byte
+
+const (
+	NoDurationSpell     DurationSpell = 0
+	DurationProtection  DurationSpell = 'P'
+	DurationQuickness   DurationSpell = 'Q'
+	DurationMassCharm   DurationSpell = 'C'
+	DurationNegateMagic DurationSpell = 'N'
+	DurationTimeStop    DurationSpell = 'T'
+	// the worn artifacts of Lord British are also tracked as the active spell; their flags
+	// only need to be distinct from the spell letters above
+	DurationLBAmulet DurationSpell = 'A'
+	DurationCrown    DurationSpell = 'W'
+)
+
+// ActiveSpell tracks the one duration spell that may be in effect at a time.
+// Casting a new duration spell replaces the previous one.
+type ActiveSpell struct {
+	Spell          DurationSpell
+	TurnsRemaining int
+}
+
+const (
+	// permanentSpellTurns marks a duration spell that never runs out (the worn Amulet and Crown)
+	permanentSpellTurns = 255
+	negateTimeTurns     = 20
+	// resurrected characters come back weak
+	resurrectedHitPoints = 1
+)
+
+func (g *GameState) startDurationSpell(spell DurationSpell, nTurns int) {
+	g.ActiveSpell = ActiveSpell{Spell: spell, TurnsRemaining: nTurns}
+	// the worn Amulet and Crown count as a light source
+	g.MapState.Lighting.SetPersistentLight(spell == DurationLBAmulet || spell == DurationCrown)
+}
+
+// IsDurationSpellActive returns true if the given duration spell is currently in effect
+func (g *GameState) IsDurationSpellActive(spell DurationSpell) bool {
+	return g.ActiveSpell.Spell == spell && g.ActiveSpell.TurnsRemaining > 0
+}
+
+func (g *GameState) advanceActiveSpell() {
+	if g.ActiveSpell.Spell == NoDurationSpell || g.ActiveSpell.TurnsRemaining == permanentSpellTurns {
+		return
+	}
+	g.ActiveSpell.TurnsRemaining = helpers.Max(g.ActiveSpell.TurnsRemaining-1, 0)
+	if g.ActiveSpell.TurnsRemaining == 0 {
+		g.startDurationSpell(NoDurationSpell, 0)
+	}
+}
+
+// The spell effect handlers below are shared by Cast and by scrolls. They assume the
+// context rules (canCastSpellHere) have already been checked by the caller.
+
+func (g *GameState) castLight(nTurns int) bool {
+	g.MapState.Lighting.StartMagicLight(nTurns)
+	g.SystemCallbacks.Audio.PlaySoundEffect(SoundSpellCast)
+	return true
+}
+
+func (g *GameState) castRelHur(direction references.Direction) bool {
+	g.SystemCallbacks.Message.AddRowStr("Wind change!")
+	if direction == references.NoneDirection {
+		return false
+	}
+	g.SetWind(direction, max(g.WindStrength, WindLight))
+	g.SystemCallbacks.Audio.PlaySoundEffect(SoundSpellCast)
+	return true
+}
+
+func (g *GameState) castInSanct(nTurns int) bool {
+	g.SystemCallbacks.Message.AddRowStr("Protection!")
+	g.startDurationSpell(DurationProtection, nTurns)
+	g.SystemCallbacks.Audio.PlaySoundEffect(SoundSpellCast)
+	return true
+}
+
+func (g *GameState) castInAn(nTurns int) bool {
+	g.SystemCallbacks.Message.AddRowStr("Negate magic!")
+	g.startDurationSpell(DurationNegateMagic, nTurns)
+	g.SystemCallbacks.Audio.PlaySoundEffect(SoundSpellCast)
+	return true
+}
+
+// castAnGrav dispels the energy field at the position, if there is one
+func (g *GameState) castAnGrav(pos *references.Position) bool {
+	if !g.dispelFieldAt(pos) {
+		g.SystemCallbacks.Message.AddRowStr("No effect!")
+		return false
+	}
+	g.SystemCallbacks.Message.AddRowStr("Field dissolved!")
+	g.SystemCallbacks.Audio.PlaySoundEffect(SoundSpellCast)
+	return true
+}
+
+// dispelFieldAt replaces a poison, sleep, fire or electric field with the ground it was covering
+func (g *GameState) dispelFieldAt(pos *references.Position) bool {
+	layeredMap := g.GetLayeredMapByCurrentLocation()
+	if !layeredMap.GetTileTopMapOnlyTile(pos).Index.IsField() {
+		return false
+	}
+	layeredMap.SetTileByLayer(map_state.MapOverrideLayer, pos, g.getDefaultGroundTile())
+	return true
+}
+
+func (g *GameState) getDefaultGroundTile() indexes.SpriteIndex {
+	if g.MapState.PlayerLocation.Location.GetMapType() == references.LargeMapType {
+		return indexes.Grass
+	}
+	return indexes.BrickFloor
+}
+
+func (g *GameState) castInQuasWis() bool {
+	// the map itself is shown by the front end from GetGemView, as for a gem
+	g.SystemCallbacks.Message.AddRowStr("View!")
+	g.SystemCallbacks.Audio.PlaySoundEffect(SoundSpellCast)
+	return true
+}
+
+func (g *GameState) castInManiCorp(playerIndex int) bool {
+	if !g.PartyState.IsCharacterInParty(playerIndex) {
+		return false
+	}
+
+	if !g.PartyState.Characters[playerIndex].Resurrect(resurrectedHitPoints) {
+		g.SystemCallbacks.Message.AddRowStr("No effect!")
+		return false
+	}
+
+	g.SystemCallbacks.Message.AddRowStr("Resurrected!")
+	g.SystemCallbacks.Audio.PlaySoundEffect(SoundSpellCast)
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	return true
+}
+
+func (g *GameState) castAnTym() bool {
+	g.SystemCallbacks.Message.AddRowStr("Negate time!")
+	g.startDurationSpell(DurationTimeStop, negateTimeTurns)
+	g.SystemCallbacks.Audio.PlaySoundEffect(SoundSpellCast)
+	return true
+}
@@ -21,6 +21,9 @@ const (
 	SoundUnlock
 	SoundTrapTrigger
 	SoundStepOnTrap
+	SoundSpellCast
+	SoundMoongate
+	SoundHeal
 )
 
 // SystemCallbacks provides comprehensive dependency injection for all external systems
@@ -14,19 +14,26 @@ type TalkCallbacks struct {
 
 	// PushDialog pushes a dialog to the UI dialog stack
 	PushDialog func(dialog TalkDialog)
+
+	// OpenShop opens the shoppe UI for a shopkeeper the party is talking to across their counter
+	OpenShop func(shop *Shop)
 }
 
 // NewTalkCallbacks creates TalkCallbacks with required function validation
-func NewTalkCallbacks(createTalkDialog func(*map_units.NPCFriendly) TalkDialog, pushDialog func(TalkDialog)) TalkCallbacks {
+func NewTalkCallbacks(createTalkDialog func(*map_units.NPCFriendly) TalkDialog, pushDialog func(TalkDialog), openShop func(*Shop)) TalkCallbacks {
 	if createTalkDialog == nil {
 		createTalkDialog = func(*map_units.NPCFriendly) TalkDialog { return nil } // No-op default
 	}
 	if pushDialog == nil {
 		pushDialog = func(TalkDialog) {} // No-op default
 	}
+	if openShop == nil {
+		openShop = func(*Shop) {} // No-op default
+	}
 
 	return TalkCallbacks{
 		CreateTalkDialog: createTalkDialog,
 		PushDialog:       pushDialog,
+		OpenShop:         openShop,
 	}
 }
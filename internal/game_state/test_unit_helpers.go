@@ -0,0 +1,32 @@
+// Test helpers for unit testing without any game data
+package game_state
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+)
+
+// newUnitTestGameState is a bare game state with mock callbacks, a seeded random number generator
+// and an empty inventory - each test adds the maps, references and characters it needs
+func newUnitTestGameState(t *testing.T) (*GameState, *MockSystemCallbacks) {
+	mockCallbacks := NewMockSystemCallbacks(t)
+	gs := &GameState{
+		SystemCallbacks: mockCallbacks.ToSystemCallbacks(),
+		rng:             rand.New(rand.NewSource(1)),
+	}
+	gs.PartyState.Inventory = *party_state.NewInventory()
+	return gs, mockCallbacks
+}
+
+// addUnitTestPartyMember puts a character in good health in the party's given slot
+func addUnitTestPartyMember(gs *GameState, nSlot int, name string) *party_state.PlayerCharacter {
+	character := &gs.PartyState.Characters[nSlot]
+	character.Name = [party_state.NMaxPlayerNameSize]byte{}
+	copy(character.Name[:], name)
+	character.Status = party_state.Good
+	character.PartyStatus = party_state.InTheParty
+	return character
+}
@@ -0,0 +1,90 @@
+package game_state
+
+import (
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+// Townes close their gates at night - drawbridges are raised and portcullises are lowered. A castle
+// also shuts them the moment its guards are alerted.
+// See Towns.md Drawbridges and Portcullis (Night Behavior).
+// TODO: guards lowering the gates for the party is not done - what persuades them is not documented
+
+// the drawbridge tiles in front of a castle gate
+const (
+	leftXDrawBridge   = 14
+	rightXDrawBridge  = 16
+	topYDrawBridge    = 28
+	bottomYDrawBridge = 29
+)
+
+// TownGates is why the gates of the current small map are open or shut, when it is not simply the
+// time of day. It is forgotten when the party leaves.
+type TownGates struct {
+	// raisedByAlarm is set when a castle has shut its gates on the party
+	raisedByAlarm bool
+}
+
+func isTowneNightTime(hour byte) bool {
+	return hour >= nightTowneCloseTime || hour < nightTowneOpenTime
+}
+
+// AreTownGatesClosed is true when the drawbridges are up and the portcullises are down
+func (g *GameState) AreTownGatesClosed() bool {
+	if g.townGates.raisedByAlarm {
+		return true
+	}
+	return isTowneNightTime(g.DateTime.Hour)
+}
+
+// isDrawBridge is true for the planks of a castle drawbridge - planks elsewhere are just floor
+func isDrawBridge(pos *references.Position, mapIndex indexes.SpriteIndex) bool {
+	if mapIndex != indexes.WoodenPlankVert1Floor && mapIndex != indexes.WoodenPlankVert2Floor {
+		return false
+	}
+	return pos.X >= leftXDrawBridge && pos.X <= rightXDrawBridge && pos.Y >= topYDrawBridge && pos.Y <= bottomYDrawBridge
+}
+
+func isPortcullis(mapIndex indexes.SpriteIndex) bool {
+	return mapIndex == indexes.Portcullis || mapIndex == indexes.BrickWallArchway
+}
+
+// IsTownGate is true when the tile the map was drawn with is a drawbridge or a portcullis
+func IsTownGate(pos *references.Position, mapIndex indexes.SpriteIndex) bool {
+	return isPortcullis(mapIndex) || isDrawBridge(pos, mapIndex)
+}
+
+// GetTownGateSprite is what a drawbridge or portcullis looks like right now, given the tile the map
+// was drawn with. Anything that isn't a gate is returned untouched.
+func (g *GameState) GetTownGateSprite(pos *references.Position, mapIndex indexes.SpriteIndex) indexes.SpriteIndex {
+	switch {
+	case isPortcullis(mapIndex):
+		return g.GetArchwayPortcullisSpriteByTime()
+	case isDrawBridge(pos, mapIndex):
+		return g.GetDrawBridgeWaterByTime(mapIndex)
+	}
+	return mapIndex
+}
+
+// isTownGateClosedAt is true when there is a gate at pos and it is shut. Passability is decided by this
+// rather than by the sprite so that the party is never let through a gate that hasn't been redrawn yet.
+func (g *GameState) isTownGateClosedAt(pos *references.Position, mapIndex indexes.SpriteIndex) (closed bool, isGate bool) {
+	if g.MapState.PlayerLocation.Location.GetMapType() != references.SmallMapType || !IsTownGate(pos, mapIndex) {
+		return false, false
+	}
+	return g.AreTownGatesClosed(), true
+}
+
+// raiseTownGatesOnAlarm shuts a castle's gates when its guards are alerted. Townes and keeps leave
+// theirs as they are.
+// TODO: TBD placeholder message - "The drawbridge is raised!" is not documented
+func (g *GameState) raiseTownGatesOnAlarm() {
+	if g.MapState.PlayerLocation.Location.GetMapType() != references.SmallMapType {
+		return
+	}
+	if g.MapState.PlayerLocation.Location.GetSmallMapMasterType() != references.Castle || g.townGates.raisedByAlarm {
+		return
+	}
+	g.townGates.raisedByAlarm = true
+	g.SystemCallbacks.Message.AddRowStr("The drawbridge is raised!")
+}
@@ -0,0 +1,102 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/ai"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/datetime"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+func newTownGatesTestGameState(t *testing.T, hour byte) (*GameState, *MockSystemCallbacks) {
+	gs, mockCallbacks := newUnitTestGameState(t)
+	gs.MapState.PlayerLocation.Location = references.Lord_Britishs_Castle
+	gs.MapState.PlayerLocation.Position = references.Position{X: 15, Y: 20}
+	gs.DateTime = datetime.UltimaDate{Year: 139, Month: 4, Day: 6, Hour: hour}
+	return gs, mockCallbacks
+}
+
+func TestTownGates_ShutAtNight(t *testing.T) {
+	testCases := []struct {
+		hour   byte
+		closed bool
+	}{
+		{4, true},
+		{nightTowneOpenTime, false},
+		{12, false},
+		{nightTowneCloseTime - 1, false},
+		{nightTowneCloseTime, true},
+		{23, true},
+	}
+
+	for _, tc := range testCases {
+		gs, _ := newTownGatesTestGameState(t, tc.hour)
+		if gs.AreTownGatesClosed() != tc.closed {
+			t.Errorf("At %d:00 expected closed=%t", tc.hour, tc.closed)
+		}
+	}
+}
+
+func TestTownGates_SpriteAndPassabilityAgree(t *testing.T) {
+	drawBridge := references.Position{X: leftXDrawBridge, Y: topYDrawBridge}
+	portcullis := references.Position{X: 15, Y: 10}
+
+	for _, hour := range []byte{2, 12} {
+		gs, _ := newTownGatesTestGameState(t, hour)
+
+		closed, isGate := gs.isTownGateClosedAt(&portcullis, indexes.BrickWallArchway)
+		if !isGate {
+			t.Fatalf("Expected an archway to be a gate")
+		}
+		if sprite := gs.GetTownGateSprite(&portcullis, indexes.BrickWallArchway); (sprite == indexes.Portcullis) != closed {
+			t.Errorf("At %d:00 the portcullis shows %d but closed=%t", hour, sprite, closed)
+		}
+
+		closed, isGate = gs.isTownGateClosedAt(&drawBridge, indexes.WoodenPlankVert1Floor)
+		if !isGate {
+			t.Fatalf("Expected the planks in front of the gate to be a drawbridge")
+		}
+		if sprite := gs.GetTownGateSprite(&drawBridge, indexes.WoodenPlankVert1Floor); (sprite == indexes.WaterShallow) != closed {
+			t.Errorf("At %d:00 the drawbridge shows %d but closed=%t", hour, sprite, closed)
+		}
+	}
+}
+
+func TestTownGates_OnlyGatesInTownes(t *testing.T) {
+	gs, _ := newTownGatesTestGameState(t, 2)
+	floor := references.Position{X: 3, Y: 3}
+
+	if _, isGate := gs.isTownGateClosedAt(&floor, indexes.WoodenPlankVert1Floor); isGate {
+		t.Errorf("Expected planks away from the gate to be ordinary floor")
+	}
+	if sprite := gs.GetTownGateSprite(&floor, indexes.WoodenPlankVert1Floor); sprite != indexes.WoodenPlankVert1Floor {
+		t.Errorf("Expected ordinary floor to be left alone, got %d", sprite)
+	}
+
+	gs.MapState.PlayerLocation.Location = references.Britannia_Underworld
+	archway := references.Position{X: 15, Y: 10}
+	if _, isGate := gs.isTownGateClosedAt(&archway, indexes.BrickWallArchway); isGate {
+		t.Errorf("Expected no gates on the large map")
+	}
+}
+
+func TestTownGates_TalkingToAGuardAtNightLeavesThemShut(t *testing.T) {
+	gs, _ := newTownGatesTestGameState(t, 2)
+	gs.CurrentNPCAIController = ai.NewNPCAIControllerLargeMap(ai.NewNPCAIControllerLargeMapInput{})
+	addTestTownsperson(gs, indexes.Guard_KeyIndex, references.Position{X: 15, Y: 19})
+
+	gs.ActionTalkSmallMap(references.Up)
+	if !gs.AreTownGatesClosed() {
+		t.Errorf("Expected a guard to need a reason to open the gates")
+	}
+}
+
+func TestTownGates_AlarmKeepsThemShut(t *testing.T) {
+	gs, _ := newTownGatesTestGameState(t, 12)
+	gs.townGates.raisedByAlarm = true
+
+	if !gs.AreTownGatesClosed() {
+		t.Errorf("Expected the alarm to shut the gates in broad daylight")
+	}
+}
@@ -10,6 +10,8 @@ func (g *GameState) FinishTurn() {
 		g.smallMapProcessEndOfTurn()
 	case references.LargeMapType:
 		g.largeMapProcessEndOfTurn()
+	case references.DungeonMapType:
+		g.dungeonProcessEndOfTurn()
 	default:
 		panic("unhandled default case")
 	}
@@ -20,6 +22,8 @@ func (g *GameState) FinishTurn() {
 	g.GenerateAndCleanupEnemies()
 
 	g.MapState.Lighting.AdvanceTurn()
+	g.advanceActiveSpell()
+	g.updateWind()
 }
 
 func (g *GameState) largeMapProcessEndOfTurn() {
@@ -28,6 +32,8 @@ func (g *GameState) largeMapProcessEndOfTurn() {
 	// g.LargeMapNPCAIController.AdvanceNextTurnCalcAndMoveNPCs()
 
 	g.GetCurrentLargeMapNPCAIController().AdvanceNextTurnCalcAndMoveNPCs()
+	g.sailPartyFrigateWithWind()
+	g.pirateShipsFireBroadsides()
 
 	// we care about the speed factor only for large maps
 	g.DateTime.Advance(topTile.SpeedFactor)
@@ -48,6 +54,10 @@ func (g *GameState) smallMapProcessNPCs() {
 // processDamageOnAdvanceTimeNonCombat
 // Processes damage from lava, poison, etc. on the non-combat map
 func (g *GameState) processDamageOnAdvanceTimeNonCombat() {
+	// dungeons have no layered map to stand on
+	if g.MapState.PlayerLocation.Location.GetMapType() == references.DungeonMapType {
+		return
+	}
 	// Process environmental hazards for standing-on-tile effects during turn advancement
 	g.ProcessEnvironmentalHazardsOnTurnAdvancement()
 }
@@ -0,0 +1,177 @@
+package game_state
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_units"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// Horse sellers leave the horse they sell waiting outside the town's gates, but won't sell a second
+// one. Shipwrights leave a new frigate or skiff at their town's dock, which has to be clear, and patch
+// the hull of a frigate tied up there. See docs/ALGOS/Shops.md.
+// TODO: TBD placeholder messages - the shipwright's "There is no dock here!", "Thy ship awaits thee at
+// the dock!", "Bring thy ship to my dock!" and "Thy ship needs no repair!" are not documented
+
+// BuyHorse pays for a horse that waits on the overworld beside the town
+func (g *GameState) BuyHorse(shop *Shop) bool {
+	if g.hasHorse(shop.Location) {
+		g.SystemCallbacks.Message.AddRowStr("Stable is full!")
+		return false
+	}
+
+	price := references.GetHorsePrice(shop.Location)
+	if int(g.PartyState.Inventory.Gold.Get()) < price {
+		g.SystemCallbacks.Message.AddRowStr("Not enough gold!")
+		return false
+	}
+
+	// TODO: what the original says when there is nowhere outside to leave the horse is TBD - until
+	// then the sale is quietly refused
+	position, ok := g.findFreeGroundOutsideTown(shop.Location)
+	if !ok {
+		return false
+	}
+	horse := map_units.NewNPCFriendlyVehiceNewRef(references.HorseVehicle, position, 0)
+	if !g.LargeMapNPCAIController[references.OVERWORLD].GetNpcs().AddVehicle(*horse) {
+		return false
+	}
+
+	g.PartyState.Inventory.Gold.DecrementBy(uint16(price))
+	g.SystemCallbacks.Message.AddRowStr("A fine steed is thine!")
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	return true
+}
+
+// hasHorse is true if the party is riding a horse, or one is already waiting beside the town's entrance
+func (g *GameState) hasHorse(location references.Location) bool {
+	if g.PartyVehicle.GetVehicleDetails().VehicleType == references.HorseVehicle {
+		return true
+	}
+	entrance := g.GameReferences.LocationReferences.WorldLocations.LargeMapLocationPositions[location].Position
+	npcs := g.LargeMapNPCAIController[references.OVERWORLD].GetNpcs()
+	for _, position := range entrance.Neighbors() {
+		position = *position.GetWrapped(references.XLargeMapTiles, references.YLargeMapTiles)
+		vehicle := npcs.GetVehicleAtPositionOrNil(position)
+		if vehicle != nil && vehicle.GetVehicleDetails().VehicleType == references.HorseVehicle {
+			return true
+		}
+	}
+	return false
+}
+
+// findFreeGroundOutsideTown is an empty, walkable tile beside the town's overworld entrance
+func (g *GameState) findFreeGroundOutsideTown(location references.Location) (references.Position, bool) {
+	entrance := g.GameReferences.LocationReferences.WorldLocations.LargeMapLocationPositions[location].Position
+	overworld := g.MapState.LayeredMaps.GetLayeredMap(references.LargeMapType, 0)
+	npcs := g.LargeMapNPCAIController[references.OVERWORLD].GetNpcs()
+
+	for _, position := range entrance.Neighbors() {
+		position = *position.GetWrapped(references.XLargeMapTiles, references.YLargeMapTiles)
+		tile := overworld.GetTileTopMapOnlyTile(&position)
+		if tile == nil || !tile.IsWalkingPassable() || npcs.GetMapUnitAtPositionOrNil(position) != nil {
+			continue
+		}
+		return position, true
+	}
+	return references.Position{}, false
+}
+
+// BuyShip pays for a frigate or a skiff that is left at the shipwright's dock
+func (g *GameState) BuyShip(shop *Shop, vehicleType references.VehicleType) bool {
+	dockPosition, ok := g.getShipwrightDock(shop)
+	if !ok {
+		g.SystemCallbacks.Message.AddRowStr("There is no dock here!")
+		return false
+	}
+
+	price := references.GetShipPrice(shop.Location, vehicleType)
+	if int(g.PartyState.Inventory.Gold.Get()) < price {
+		g.SystemCallbacks.Message.AddRowStr("Not enough gold!")
+		return false
+	}
+
+	// TODO: what the original says when the dock is occupied is TBD - until then the sale is quietly
+	// refused
+	npcs := g.LargeMapNPCAIController[references.OVERWORLD].GetNpcs()
+	if npcs.GetMapUnitAtPositionOrNil(dockPosition) != nil {
+		return false
+	}
+
+	ship := map_units.NewNPCFriendlyVehiceNewRef(vehicleType, dockPosition, 0)
+	if vehicleType == references.FrigateVehicle {
+		// every frigate comes with a skiff
+		ship.GetVehicleDetails().SetSkiffQuantity(1)
+	}
+	if !npcs.AddVehicle(*ship) {
+		return false
+	}
+
+	g.PartyState.Inventory.Gold.DecrementBy(uint16(price))
+	g.SystemCallbacks.Message.AddRowStr("Thy ship awaits thee at the dock!")
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	return true
+}
+
+// GetHullRepairPrice is what the shipwright asks to fully patch the frigate at their dock - it is
+// false if there is no frigate there, or it needs no repair
+func (g *GameState) GetHullRepairPrice(shop *Shop) (int, bool) {
+	frigate := g.getFrigateAtShipwrightDock(shop)
+	if frigate == nil {
+		return 0, false
+	}
+	nHullPoints := map_units.MaxHullPoints - frigate.GetVehicleDetails().GetHullPoints()
+	if nHullPoints == 0 {
+		return 0, false
+	}
+	return references.GetHullRepairPrice(shop.Location, nHullPoints), true
+}
+
+// RepairHull pays to patch the hull of the frigate at the shipwright's dock
+func (g *GameState) RepairHull(shop *Shop) bool {
+	if _, ok := g.getShipwrightDock(shop); !ok {
+		g.SystemCallbacks.Message.AddRowStr("There is no dock here!")
+		return false
+	}
+	frigate := g.getFrigateAtShipwrightDock(shop)
+	if frigate == nil {
+		g.SystemCallbacks.Message.AddRowStr("Bring thy ship to my dock!")
+		return false
+	}
+
+	price, ok := g.GetHullRepairPrice(shop)
+	if !ok {
+		g.SystemCallbacks.Message.AddRowStr("Thy ship needs no repair!")
+		return false
+	}
+	if !g.PartyState.Inventory.Gold.DecrementBy(uint16(price)) {
+		g.SystemCallbacks.Message.AddRowStr("Not enough gold!")
+		return false
+	}
+
+	frigate.GetVehicleDetails().SetHullPoints(map_units.MaxHullPoints)
+	g.SystemCallbacks.Message.AddRowStr(fmt.Sprintf("Hull now %02d!", map_units.MaxHullPoints))
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	return true
+}
+
+func (g *GameState) getFrigateAtShipwrightDock(shop *Shop) *map_units.NPCFriendly {
+	dockPosition, ok := g.getShipwrightDock(shop)
+	if !ok {
+		return nil
+	}
+	vehicle := g.LargeMapNPCAIController[references.OVERWORLD].GetNpcs().GetVehicleAtPositionOrNil(dockPosition)
+	if vehicle == nil || vehicle.GetVehicleDetails().VehicleType != references.FrigateVehicle {
+		return nil
+	}
+	return vehicle
+}
+
+// getShipwrightDock is where the shipwright's town keeps its boats - it is false if the town has no dock
+func (g *GameState) getShipwrightDock(shop *Shop) (references.Position, bool) {
+	if !slices.Contains(references.GetListOfAllLocationsWithDocks(), shop.Location) {
+		return references.Position{}, false
+	}
+	return g.GameReferences.DockReferences.GetDockPosition(shop.Location), true
+}
@@ -0,0 +1,174 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/ai"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_units"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+var (
+	jhelomDock       = references.Position{X: 40, Y: 200}
+	britainEntrance  = references.Position{X: 80, Y: 100}
+	horseSellerShop  = &Shop{Type: references.HorseShop, Location: references.Britain}
+	jhelomShipwright = &Shop{Type: references.ShipwrightShop, Location: references.Jhelom}
+)
+
+// newVehicleShopsTestGameState has the party in town with 5000gp, an empty dock in Jhelom, and
+// grass around Britain's gates with mountains to the west
+func newVehicleShopsTestGameState(t *testing.T) (*GameState, *MockSystemCallbacks) {
+	tiles := references.Tiles{
+		0:                      &references.Tile{Index: 0},
+		indexes.Grass:          &references.Tile{Index: indexes.Grass},
+		indexes.SmallMountains: &references.Tile{Index: indexes.SmallMountains, SpeedFactor: -1},
+	}
+
+	gs, mockCallbacks := newUnitTestGameState(t)
+	gs.GameReferences = &references.GameReferences{
+		DockReferences: &references.DockReferences{
+			Docks: []references.DockReference{{Location: references.Jhelom, Position: jhelomDock}},
+		},
+		LocationReferences: &references.LocationReferences{
+			WorldLocations: &references.WorldLocations{
+				LargeMapLocationPositions: map[references.Location]references.WorldLocation{
+					references.Britain: {Position: britainEntrance, Location: references.Britain},
+				},
+			},
+		},
+	}
+	gs.LargeMapNPCAIController = map[references.World]*ai.NPCAIControllerLargeMap{
+		references.OVERWORLD: ai.NewNPCAIControllerLargeMap(ai.NewNPCAIControllerLargeMapInput{}),
+	}
+	gs.PartyState.Inventory.Gold.Set(5000)
+	gs.MapState.LayeredMaps = *map_state.NewLayeredMaps(&tiles, &references.LargeMapReference{}, &references.LargeMapReference{}, 19, 13)
+	overworld := gs.MapState.LayeredMaps.GetLayeredMap(references.LargeMapType, 0)
+	for _, position := range britainEntrance.Neighbors() {
+		overworld.SetTileByLayer(map_state.MapLayer, &position, indexes.Grass)
+	}
+	mountains := britainEntrance.Neighbors()[0]
+	overworld.SetTileByLayer(map_state.MapLayer, &mountains, indexes.SmallMountains)
+	return gs, mockCallbacks
+}
+
+func getOverworldVehicleAt(gs *GameState, position references.Position) *map_units.NPCFriendly {
+	return gs.LargeMapNPCAIController[references.OVERWORLD].GetNpcs().GetVehicleAtPositionOrNil(position)
+}
+
+func TestVehicleShops_HorseWaitsOutsideTown(t *testing.T) {
+	gs, mockCallbacks := newVehicleShopsTestGameState(t)
+
+	if !gs.BuyHorse(horseSellerShop) {
+		t.Fatalf("Expected a horse to be bought")
+	}
+	// the mountains to the west are skipped for the open ground to the east
+	horse := getOverworldVehicleAt(gs, britainEntrance.Neighbors()[1])
+	if horse == nil || horse.GetVehicleDetails().VehicleType != references.HorseVehicle {
+		t.Fatalf("Expected the horse to wait east of the gates")
+	}
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != 4800 {
+		t.Errorf("Expected 4800gp left, got %d", gold)
+	}
+	mockCallbacks.AssertLastMessage("A fine steed is thine!")
+}
+
+func TestVehicleShops_HorseNeedsEnoughGold(t *testing.T) {
+	gs, mockCallbacks := newVehicleShopsTestGameState(t)
+
+	gs.PartyState.Inventory.Gold.Set(100)
+	if gs.BuyHorse(horseSellerShop) {
+		t.Errorf("Expected a horse to need more gold")
+	}
+	mockCallbacks.AssertLastMessage("Not enough gold!")
+}
+
+func TestVehicleShops_OnlyOneHorseAtATime(t *testing.T) {
+	gs, mockCallbacks := newVehicleShopsTestGameState(t)
+
+	gs.BuyHorse(horseSellerShop)
+	if gs.BuyHorse(horseSellerShop) {
+		t.Errorf("Expected no second horse while one waits outside")
+	}
+	mockCallbacks.AssertLastMessage("Stable is full!")
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != 4800 {
+		t.Errorf("Expected the refused horse to cost nothing, got %d", gold)
+	}
+
+	gs, mockCallbacks = newVehicleShopsTestGameState(t)
+	gs.PartyVehicle = *map_units.NewNPCFriendlyVehiceNewRef(references.HorseVehicle, references.Position{}, 0)
+	if gs.BuyHorse(horseSellerShop) {
+		t.Errorf("Expected no horse for a party already riding one")
+	}
+	mockCallbacks.AssertLastMessage("Stable is full!")
+}
+
+func TestVehicleShops_FrigateLeftAtTheDock(t *testing.T) {
+	gs, mockCallbacks := newVehicleShopsTestGameState(t)
+
+	if !gs.BuyShip(jhelomShipwright, references.FrigateVehicle) {
+		t.Fatalf("Expected a frigate to be bought")
+	}
+	frigate := getOverworldVehicleAt(gs, jhelomDock)
+	if frigate == nil || frigate.GetVehicleDetails().VehicleType != references.FrigateVehicle {
+		t.Fatalf("Expected the frigate to be at the dock")
+	}
+	if !frigate.GetVehicleDetails().HasAtLeastOneSkiff() {
+		t.Errorf("Expected the frigate to come with a skiff")
+	}
+	// the shipwright's 1500gp base price is the same in Jhelom
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != 3500 {
+		t.Errorf("Expected 3500gp left, got %d", gold)
+	}
+
+	mockCallbacks.AssertLastMessage("Thy ship awaits thee at the dock!")
+
+	if gs.BuyShip(jhelomShipwright, references.SkiffVehicle) {
+		t.Errorf("Expected no skiff while the dock is occupied")
+	}
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != 3500 {
+		t.Errorf("Expected the failed purchase to cost nothing, got %d", gold)
+	}
+}
+
+func TestVehicleShops_NoDockNoShips(t *testing.T) {
+	gs, mockCallbacks := newVehicleShopsTestGameState(t)
+
+	if gs.BuyShip(&Shop{Type: references.ShipwrightShop, Location: references.Britain}, references.SkiffVehicle) {
+		t.Errorf("Expected a town without a dock to sell no boats")
+	}
+	mockCallbacks.AssertLastMessage("There is no dock here!")
+}
+
+func TestVehicleShops_RepairHullAtTheDock(t *testing.T) {
+	gs, mockCallbacks := newVehicleShopsTestGameState(t)
+
+	if gs.RepairHull(jhelomShipwright) {
+		t.Errorf("Expected nothing to repair without a ship at the dock")
+	}
+	mockCallbacks.AssertLastMessage("Bring thy ship to my dock!")
+
+	gs.BuyShip(jhelomShipwright, references.FrigateVehicle)
+	frigate := getOverworldVehicleAt(gs, jhelomDock)
+	if gs.RepairHull(jhelomShipwright) {
+		t.Errorf("Expected a new frigate to need no repair")
+	}
+	mockCallbacks.AssertLastMessage("Thy ship needs no repair!")
+
+	frigate.GetVehicleDetails().DamageHull(40)
+	price, ok := gs.GetHullRepairPrice(jhelomShipwright)
+	if !ok || price != 120 {
+		t.Fatalf("Expected 40 points at 3gp, got %d", price)
+	}
+	if !gs.RepairHull(jhelomShipwright) {
+		t.Fatalf("Expected the hull to be repaired")
+	}
+	if hull := frigate.GetVehicleDetails().GetHullPoints(); hull != map_units.MaxHullPoints {
+		t.Errorf("Expected a sound hull, got %d", hull)
+	}
+	if gold := gs.PartyState.Inventory.Gold.Get(); gold != 3380 {
+		t.Errorf("Expected 3380gp left, got %d", gold)
+	}
+	mockCallbacks.AssertLastMessage("Hull now 99!")
+}
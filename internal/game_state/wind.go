@@ -0,0 +1,75 @@
+package game_state
+
+import (
+	"fmt"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// WindStrength is how hard the wind is blowing - a calm wind has no direction
+// TODO: TBD placeholder - Movement_Overworld.md only has the four directions and calm, so light and
+// strong winds are not the original's
+type WindStrength int
+
+const (
+	WindCalm WindStrength = iota
+	WindLight
+	WindStrong
+)
+
+const (
+	// the wind has a 1 in 64 chance of changing each turn
+	windChangeOdds = 64
+	// when a change rolls calm it only sticks 25% of the time
+	windCalmKeptPercent = 25
+)
+
+var windDirections = []references.Direction{references.Up, references.Down, references.Left, references.Right}
+
+// SetWind changes the wind - a direction of NoneDirection or a calm strength becomes a calm wind
+func (g *GameState) SetWind(direction references.Direction, strength WindStrength) {
+	if direction == references.NoneDirection || strength == WindCalm {
+		direction, strength = references.NoneDirection, WindCalm
+	}
+	g.WindDirection = direction
+	g.WindStrength = strength
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+}
+
+// IsWindCalm is true when there is no wind to fill a sail
+func (g *GameState) IsWindCalm() bool {
+	return g.WindStrength == WindCalm || g.WindDirection == references.NoneDirection
+}
+
+// updateWind gives the wind a small chance of changing each turn. See Movement_Overworld.md Wind System.
+func (g *GameState) updateWind() {
+	if !g.OneInXOdds(windChangeOdds) {
+		return
+	}
+
+	for {
+		newWind := g.RandomIntInRange(0, len(windDirections))
+		if newWind == 0 {
+			if g.HappenedByPercentLikely(windCalmKeptPercent) {
+				g.SetWind(references.NoneDirection, WindCalm)
+				return
+			}
+			continue
+		}
+		g.SetWind(windDirections[newWind-1], WindStrength(g.RandomIntInRange(int(WindLight), int(WindStrong))))
+		return
+	}
+}
+
+// GetWindDescription describes the wind the way sailors do - by where it blows from
+func (g *GameState) GetWindDescription() string {
+	if g.IsWindCalm() {
+		return "Calm"
+	}
+
+	description := fmt.Sprintf("%s Winds", g.WindDirection.GetOppositeDirection().GetDirectionCompassName())
+	if g.WindStrength == WindStrong {
+		return "Strong " + description
+	}
+	return description
+}
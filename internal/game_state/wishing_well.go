@@ -0,0 +1,206 @@
+package game_state
+
+import (
+	"fmt"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_units"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/party_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// A coin dropped down a wishing well buys a wish. What the wish grants is drawn from a weighted table,
+// and each towne weights it differently.
+// See Fixtures.md Wells and Wish.
+// TODO: TBD placeholder values - the weights and grants are the template tables in Fixtures.md, not
+// the original's
+
+type wishOutcome int
+
+const (
+	wishNothing wishOutcome = iota
+	wishHeal
+	wishCurePoison
+	wishFood
+	wishGold
+	wishHorse
+	wishBoostStat
+	nWishOutcomes
+)
+
+const (
+	wishHealMin   = 3
+	wishHealMax   = 10
+	wishFoodGrant = 3
+	wishGoldGrant = 25
+	// a wish won't raise an attribute past this
+	wishMaxStat = 30
+)
+
+type wishStat int
+
+const (
+	wishStrength wishStat = iota
+	wishDexterity
+	wishIntelligence
+)
+
+// wishTable weights each outcome of a wish, and says which attribute a lucky wish raises
+type wishTable struct {
+	weights [nWishOutcomes]int
+	stat    wishStat
+}
+
+func newWishTable(heal, curePoison, food, gold, horse, stat, nothing int, boosts wishStat) wishTable {
+	table := wishTable{stat: boosts}
+	table.weights[wishHeal] = heal
+	table.weights[wishCurePoison] = curePoison
+	table.weights[wishFood] = food
+	table.weights[wishGold] = gold
+	table.weights[wishHorse] = horse
+	table.weights[wishBoostStat] = stat
+	table.weights[wishNothing] = nothing
+	return table
+}
+
+var defaultWishTable = newWishTable(3, 2, 2, 2, 1, 1, 5, wishIntelligence)
+
+var locationWishTables = map[references.Location]wishTable{
+	references.Britain:              newWishTable(6, 2, 2, 2, 0, 1, 3, wishIntelligence),
+	references.Moonglow:             newWishTable(2, 2, 2, 1, 0, 4, 3, wishIntelligence),
+	references.Jhelom:               newWishTable(2, 1, 2, 2, 1, 4, 3, wishStrength),
+	references.Yew:                  newWishTable(3, 3, 2, 1, 0, 1, 3, wishIntelligence),
+	references.Minoc:                newWishTable(3, 2, 2, 2, 0, 1, 3, wishIntelligence),
+	references.Trinsic:              newWishTable(2, 3, 2, 2, 0, 1, 3, wishIntelligence),
+	references.Skara_Brae:           newWishTable(2, 1, 2, 1, 4, 1, 3, wishIntelligence),
+	references.New_Magincia:         newWishTable(3, 2, 2, 1, 0, 2, 3, wishIntelligence),
+	references.Cove:                 newWishTable(2, 3, 2, 1, 0, 1, 4, wishIntelligence),
+	references.Buccaneers_Den:       newWishTable(1, 1, 2, 5, 0, 1, 4, wishIntelligence),
+	references.Paws:                 newWishTable(3, 2, 3, 2, 0, 1, 3, wishIntelligence),
+	references.Lord_Britishs_Castle: newWishTable(5, 2, 2, 1, 0, 2, 2, wishIntelligence),
+	references.Empath_Abbey:         newWishTable(4, 2, 2, 1, 0, 3, 2, wishIntelligence),
+}
+
+func (g *GameState) getWishTable() wishTable {
+	if table, ok := locationWishTables[g.MapState.PlayerLocation.Location]; ok {
+		return table
+	}
+	return defaultWishTable
+}
+
+// wishAtWell asks for a coin and grants a wish. It is false if no coin was dropped.
+func (g *GameState) wishAtWell(wellPosition *references.Position, layeredMap *map_state.LayeredMap) bool {
+	if !g.SystemCallbacks.Screen.PromptYesNo("Drop a coin?") {
+		g.SystemCallbacks.Message.AddRowStr("No.")
+		return false
+	}
+	if !g.PartyState.Inventory.Gold.HasSome() {
+		g.SystemCallbacks.Message.AddRowStr("No gold!")
+		return false
+	}
+	g.PartyState.Inventory.Gold.DecrementByOne()
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+
+	table := g.getWishTable()
+	if !g.grantWish(g.pickWishOutcome(table), table, wellPosition, layeredMap) {
+		g.SystemCallbacks.Message.AddRowStr("Nothing happens.")
+	}
+	return true
+}
+
+func (g *GameState) pickWishOutcome(table wishTable) wishOutcome {
+	total := 0
+	for _, weight := range table.weights {
+		total += weight
+	}
+	if total <= 0 {
+		return wishNothing
+	}
+
+	roll := g.RandomIntInRange(0, total-1)
+	for outcome, weight := range table.weights {
+		if roll < weight {
+			return wishOutcome(outcome)
+		}
+		roll -= weight
+	}
+	return wishNothing
+}
+
+// grantWish is false if the wish came to nothing
+func (g *GameState) grantWish(outcome wishOutcome, table wishTable, wellPosition *references.Position, layeredMap *map_state.LayeredMap) bool {
+	avatar := &g.PartyState.Characters[0]
+
+	switch outcome {
+	case wishHeal:
+		if !avatar.Heal(uint16(g.RandomIntInRange(wishHealMin, wishHealMax))) {
+			return false
+		}
+		g.SystemCallbacks.Message.AddRowStr("Refreshed!")
+		g.SystemCallbacks.Audio.PlaySoundEffect(SoundHeal)
+	case wishCurePoison:
+		if avatar.Status != party_state.Poisoned {
+			return false
+		}
+		avatar.Status = party_state.Good
+		g.SystemCallbacks.Message.AddRowStr("Cured!")
+		g.SystemCallbacks.Audio.PlaySoundEffect(SoundHeal)
+	case wishFood:
+		g.PartyState.Inventory.Provisions.Food.IncrementBy(wishFoodGrant)
+		g.SystemCallbacks.Message.AddRowStr("Food!")
+	case wishGold:
+		g.PartyState.Inventory.Gold.IncrementBy(wishGoldGrant)
+		g.SystemCallbacks.Message.AddRowStr("Gold!")
+	case wishHorse:
+		if !g.spawnHorseNextTo(wellPosition, layeredMap) {
+			return false
+		}
+		g.SystemCallbacks.Message.AddRowStr("A fine steed!")
+	case wishBoostStat:
+		return g.boostStatByWish(avatar, table.stat)
+	default:
+		return false
+	}
+
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	return true
+}
+
+func (g *GameState) boostStatByWish(character *party_state.PlayerCharacter, stat wishStat) bool {
+	attribute, name := &character.Intelligence, "INT"
+	switch stat {
+	case wishStrength:
+		attribute, name = &character.Strength, "STR"
+	case wishDexterity:
+		attribute, name = &character.Dexterity, "DEX"
+	}
+
+	if *attribute >= wishMaxStat {
+		return false
+	}
+	*attribute++
+	g.SystemCallbacks.Message.AddRowStr(fmt.Sprintf("Thy %s increases!", name))
+	g.SystemCallbacks.Screen.MarkStatsChanged()
+	return true
+}
+
+// spawnHorseNextTo leaves a horse on free ground beside the well, if there is any
+func (g *GameState) spawnHorseNextTo(wellPosition *references.Position, layeredMap *map_state.LayeredMap) bool {
+	if g.CurrentNPCAIController == nil {
+		return false
+	}
+
+	npcs := g.CurrentNPCAIController.GetNpcs()
+	for _, position := range wellPosition.Neighbors() {
+		tile := layeredMap.GetTopTile(&position)
+		if tile == nil || !tile.IsWalkingPassable() || position == g.MapState.PlayerLocation.Position {
+			continue
+		}
+		if npcs.GetMapUnitAtPositionOrNil(position) != nil {
+			continue
+		}
+		horse := map_units.NewNPCFriendlyVehiceNewRef(references.HorseVehicle, position, g.MapState.PlayerLocation.Floor)
+		return npcs.AddVehicle(*horse)
+	}
+	return false
+}
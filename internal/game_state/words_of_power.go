@@ -0,0 +1,117 @@
+package game_state
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+// The dungeons are sealed until the party stands beside an entrance and yells its Word of Power,
+// which they have to learn from the people of Britannia - yelling it again seals the dungeon up.
+// Which dungeons are open is kept in SAVED.GAM, and a sealed entrance can't be entered.
+// See Commands.md.
+
+// wordsOfPower open and seal each dungeon, in DUNGEON.DAT order
+var wordsOfPower = []string{"FALLAX", "VILIS", "INOPIA", "MALUM", "AVIDUS", "INFAMA", "IGNAVUS", "VERAMOCOR"}
+
+// dungeonEntranceTiles are what each dungeon's entrance looks like while it is open, in DUNGEON.DAT
+// order
+var dungeonEntranceTiles = []indexes.SpriteIndex{
+	indexes.Dungeon, indexes.Cave, indexes.Cave, indexes.Dungeon,
+	indexes.Dungeon, indexes.Mine, indexes.Mine, indexes.Cave,
+}
+
+// wordOfPowerSiteDirections is the order the tiles around the party are checked for an entrance
+var wordOfPowerSiteDirections = []references.Direction{references.Left, references.Down, references.Right, references.Up}
+
+// OpenDungeons has a byte for each dungeon, in DUNGEON.DAT order, set once its entrance is opened
+type OpenDungeons [openDungeonsSize]byte
+
+// IsOpen is true when the dungeon's entrance has been opened with its Word of Power
+func (o *OpenDungeons) IsOpen(location references.Location) bool {
+	nDungeon := slices.Index(references.GetListOfAllDungeons(), location)
+	return nDungeon >= 0 && o[nDungeon] != 0
+}
+
+func (o *OpenDungeons) toggle(location references.Location) {
+	nDungeon := slices.Index(references.GetListOfAllDungeons(), location)
+	if nDungeon < 0 {
+		return
+	}
+	if o[nDungeon] != 0 {
+		o[nDungeon] = 0
+	} else {
+		o[nDungeon] = 1
+	}
+}
+
+// ActionYellWordOfPower yells a word on the overworld. The right word beside a dungeon entrance
+// opens it, or seals it again if it is already open.
+func (g *GameState) ActionYellWordOfPower(word string) bool {
+	nWord := slices.Index(wordsOfPower, strings.ToUpper(strings.TrimSpace(word)))
+	if nWord < 0 {
+		g.SystemCallbacks.Message.AddRowStr("No effect!")
+		return false
+	}
+
+	g.SystemCallbacks.Message.AddRowStr("A word of power is uttered")
+	g.SystemCallbacks.Audio.PlaySoundEffect(SoundEarthquake)
+
+	dungeon := references.GetListOfAllDungeons()[nWord]
+	position, ok := g.findDungeonEntranceBesideParty(dungeon)
+	if !ok {
+		g.SystemCallbacks.Message.AddRowStr("No effect!")
+		return false
+	}
+
+	g.OpenDungeons.toggle(dungeon)
+	copy(g.RawSave[lbOpenDungeons:], g.OpenDungeons[:])
+	g.drawDungeonEntrance(g.GetLayeredMapByCurrentLocation(), dungeon, position)
+	return true
+}
+
+// findDungeonEntranceBesideParty is the position of the dungeon's entrance if it is on one of the
+// four tiles around the party
+func (g *GameState) findDungeonEntranceBesideParty(dungeon references.Location) (references.Position, bool) {
+	layeredMap := g.GetLayeredMapByCurrentLocation()
+	for _, direction := range wordOfPowerSiteDirections {
+		position := direction.GetNewPositionInDirection(&g.MapState.PlayerLocation.Position).
+			GetWrapped(references.XLargeMapTiles, references.YLargeMapTiles)
+		if g.GameReferences.LocationReferences.WorldLocations.GetLocationByPosition(*position) != dungeon {
+			continue
+		}
+		if isDungeonEntranceTile(layeredMap.GetTileTopMapOnlyTile(position)) {
+			return *position, true
+		}
+	}
+	return references.Position{}, false
+}
+
+// drawDungeonSeals shows every dungeon entrance as sealed or open - an entrance is drawn on
+// whichever of the overworld and underworld has one at the dungeon's position
+func (g *GameState) drawDungeonSeals() {
+	for _, dungeon := range references.GetListOfAllDungeons() {
+		position := g.GameReferences.LocationReferences.WorldLocations.LargeMapLocationPositions[dungeon].Position
+		for _, floor := range []references.FloorNumber{0, -1} {
+			layeredMap := g.MapState.LayeredMaps.GetLayeredMap(references.LargeMapType, floor)
+			if isDungeonEntranceTile(layeredMap.GetTileTopMapOnlyTile(&position)) {
+				g.drawDungeonEntrance(layeredMap, dungeon, position)
+			}
+		}
+	}
+}
+
+func (g *GameState) drawDungeonEntrance(layeredMap *map_state.LayeredMap, dungeon references.Location, position references.Position) {
+	var tile indexes.SpriteIndex = indexes.SealedDungeon
+	if g.OpenDungeons.IsOpen(dungeon) {
+		tile = dungeonEntranceTiles[slices.Index(references.GetListOfAllDungeons(), dungeon)]
+	}
+	layeredMap.SetTileByLayer(map_state.MapOverrideLayer, &position, tile)
+}
+
+func isDungeonEntranceTile(tile *references.Tile) bool {
+	return tile != nil && (tile.Index == indexes.SealedDungeon || slices.Contains(dungeonEntranceTiles, tile.Index))
+}
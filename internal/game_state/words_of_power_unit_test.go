@@ -0,0 +1,132 @@
+package game_state
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/map_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+var deceitEntrance = references.Position{X: 100, Y: 100}
+
+// newWordsOfPowerTestGameState has the party on the overworld standing just south of a sealed Deceit
+func newWordsOfPowerTestGameState(t *testing.T) (*GameState, *MockSystemCallbacks) {
+	tiles := references.Tiles{}
+	for _, index := range []indexes.SpriteIndex{0, indexes.Cave, indexes.Mine, indexes.Dungeon, indexes.SealedDungeon} {
+		tiles[index] = &references.Tile{Index: index}
+	}
+
+	gs, mockCallbacks := newUnitTestGameState(t)
+	gs.GameReferences = &references.GameReferences{
+		LocationReferences: &references.LocationReferences{
+			WorldLocations: &references.WorldLocations{
+				LargeMapLocationPositions: map[references.Location]references.WorldLocation{
+					references.Deceit: {Position: deceitEntrance, Location: references.Deceit},
+				},
+			},
+		},
+	}
+	gs.MapState.LayeredMaps = *map_state.NewLayeredMaps(&tiles, &references.LargeMapReference{}, &references.LargeMapReference{}, 19, 13)
+	gs.MapState.LayeredMaps.GetLayeredMap(references.LargeMapType, 0).SetTileByLayer(map_state.MapLayer, &deceitEntrance, indexes.Dungeon)
+	gs.drawDungeonSeals()
+
+	gs.MapState.PlayerLocation.Location = references.Britannia_Underworld
+	gs.MapState.PlayerLocation.Position = *deceitEntrance.GetPositionDown()
+	return gs, mockCallbacks
+}
+
+func getDungeonEntranceTile(gs *GameState) indexes.SpriteIndex {
+	return gs.MapState.LayeredMaps.GetLayeredMap(references.LargeMapType, 0).GetTileTopMapOnlyTile(&deceitEntrance).Index
+}
+
+func TestWordsOfPower_DungeonsStartSealed(t *testing.T) {
+	gs, _ := newWordsOfPowerTestGameState(t)
+
+	if gs.OpenDungeons.IsOpen(references.Deceit) {
+		t.Errorf("Expected Deceit to be sealed")
+	}
+	if tile := getDungeonEntranceTile(gs); tile != indexes.SealedDungeon {
+		t.Errorf("Expected the entrance to be drawn sealed, got %d", tile)
+	}
+	if gs.OpenDungeons.IsOpen(references.Britain) {
+		t.Errorf("Expected Britain not to be a dungeon that can be opened")
+	}
+}
+
+func TestWordsOfPower_RightWordOpensAndSealsTheEntrance(t *testing.T) {
+	gs, mockCallbacks := newWordsOfPowerTestGameState(t)
+
+	if !gs.ActionYellWordOfPower("fallax") {
+		t.Fatalf("Expected the word of power to open Deceit")
+	}
+	mockCallbacks.AssertLastMessage("A word of power is uttered")
+	mockCallbacks.AssertSoundEffectPlayed(SoundEarthquake)
+	if !gs.OpenDungeons.IsOpen(references.Deceit) {
+		t.Errorf("Expected Deceit to be open")
+	}
+	if tile := getDungeonEntranceTile(gs); tile != indexes.Dungeon {
+		t.Errorf("Expected the entrance to be drawn open, got %d", tile)
+	}
+	if gs.RawSave[lbOpenDungeons] != 1 {
+		t.Errorf("Expected Deceit's flag to be saved, got %#x", gs.RawSave[lbOpenDungeons])
+	}
+
+	gs.ActionYellWordOfPower("FALLAX")
+	if gs.OpenDungeons.IsOpen(references.Deceit) || getDungeonEntranceTile(gs) != indexes.SealedDungeon {
+		t.Errorf("Expected yelling the word again to seal Deceit")
+	}
+}
+
+func TestWordsOfPower_SavedFlagsLeaveTheShrineQuestsAlone(t *testing.T) {
+	gs, _ := newWordsOfPowerTestGameState(t)
+	const lbQuestsInProgress = 0x326
+	gs.RawSave[lbQuestsInProgress], gs.RawSave[lbQuestsInProgress+1] = 0xA5, 0x5A
+
+	gs.ActionYellWordOfPower("FALLAX")
+	gs.ActionYellWordOfPower("FALLAX")
+	gs.ActionYellWordOfPower("FALLAX")
+
+	if gs.RawSave[lbQuestsInProgress] != 0xA5 || gs.RawSave[lbQuestsInProgress+1] != 0x5A {
+		t.Errorf("Expected the quests in progress to be untouched, got %#x %#x",
+			gs.RawSave[lbQuestsInProgress], gs.RawSave[lbQuestsInProgress+1])
+	}
+
+	reloaded := OpenDungeons(gs.RawSave[lbOpenDungeons : lbOpenDungeons+openDungeonsSize])
+	if !reloaded.IsOpen(references.Deceit) || reloaded.IsOpen(references.Despise) {
+		t.Errorf("Expected only Deceit to read back open, got %v", reloaded)
+	}
+}
+
+func TestWordsOfPower_WrongWordOrPlaceHasNoEffect(t *testing.T) {
+	gs, mockCallbacks := newWordsOfPowerTestGameState(t)
+
+	if gs.ActionYellWordOfPower("xyzzy") {
+		t.Errorf("Expected a word that isn't a word of power to do nothing")
+	}
+	mockCallbacks.AssertLastMessage("No effect!")
+	mockCallbacks.AssertNoSoundEffects()
+
+	if gs.ActionYellWordOfPower("VILIS") {
+		t.Errorf("Expected Despise's word not to open Deceit")
+	}
+	mockCallbacks.AssertMessageContains("A word of power is uttered")
+	mockCallbacks.AssertLastMessage("No effect!")
+
+	gs.MapState.PlayerLocation.Position = references.Position{X: 50, Y: 50}
+	if gs.ActionYellWordOfPower("FALLAX") || gs.OpenDungeons.IsOpen(references.Deceit) {
+		t.Errorf("Expected the word to need the entrance beside the party")
+	}
+}
+
+func TestWordsOfPower_SealedDungeonCannotBeEntered(t *testing.T) {
+	gs, mockCallbacks := newWordsOfPowerTestGameState(t)
+
+	if gs.enterDungeonFromLargeMap(references.Deceit) {
+		t.Errorf("Expected a sealed dungeon to refuse the party")
+	}
+	mockCallbacks.AssertLastMessage("Sealed!")
+	if gs.MapState.PlayerLocation.Location != references.Britannia_Underworld {
+		t.Errorf("Expected the party to stay outside, got %v", gs.MapState.PlayerLocation.Location)
+	}
+}
@@ -22,3 +22,26 @@ func (m *MapState) SmallMapProcessTurnDoors() {
 		}
 	}
 }
+
+// MagicUnlockDoor turns a magically locked door at the given position into a regular door, keeping
+// its window if it has one. It returns false if there is no magically locked door there.
+// See Objects.md Skull Key — Magical Unlock (wizard_unlock_magic_at).
+func (m *MapState) MagicUnlockDoor(pos *references.Position) bool {
+	theMap := m.GetLayeredMapByCurrentLocation()
+	if theMap == nil {
+		return false
+	}
+
+	var unlockedDoor indexes.SpriteIndex
+	switch theMap.GetTileTopMapOnlyTile(pos).Index {
+	case indexes.MagicLockDoor:
+		unlockedDoor = indexes.RegularDoor
+	case indexes.MagicLockDoorWithView:
+		unlockedDoor = indexes.RegularDoorView
+	default:
+		return false
+	}
+
+	theMap.SetTileByLayer(MapOverrideLayer, pos, unlockedDoor)
+	return true
+}
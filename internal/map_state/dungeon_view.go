@@ -0,0 +1,320 @@
+package map_state
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/pkg/helpers"
+)
+
+// DungeonViewDepth is how many tiles ahead of the party can be seen
+const DungeonViewDepth = 4
+
+var (
+	dungeonViewBackground = color.RGBA{A: 0xff}
+	dungeonViewWall       = color.RGBA{R: 0x50, G: 0x50, B: 0x60, A: 0xff}
+	dungeonViewEdge       = color.RGBA{R: 0xe0, G: 0xe0, B: 0xe0, A: 0xff}
+	dungeonViewDoor       = color.RGBA{R: 0x90, G: 0x50, B: 0x10, A: 0xff}
+	dungeonViewLadder     = color.RGBA{R: 0xf0, G: 0xe0, B: 0x20, A: 0xff}
+	dungeonViewHole       = color.RGBA{R: 0x20, G: 0x20, B: 0x20, A: 0xff}
+	dungeonViewChest      = color.RGBA{R: 0xc0, G: 0x80, B: 0x30, A: 0xff}
+	dungeonViewFountain   = color.RGBA{R: 0x30, G: 0x60, B: 0xd0, A: 0xff}
+)
+
+var dungeonViewFieldColours = map[references.DungeonFieldType]color.RGBA{
+	references.PoisonDungeonField: {R: 0x20, G: 0xc0, B: 0x20, A: 0xff},
+	references.SleepDungeonField:  {R: 0xa0, G: 0x40, B: 0xe0, A: 0xff},
+	references.FireDungeonField:   {R: 0xff, G: 0x60, B: 0x00, A: 0xff},
+	references.EnergyDungeonField: {R: 0x40, G: 0xc0, B: 0xff, A: 0xff},
+}
+
+// DungeonViewTileSource provides the level drawn by a dungeon view - a DungeonLevel satisfies it
+type DungeonViewTileSource interface {
+	GetTile(position references.Position) references.DungeonTile
+}
+
+// DungeonView is the first-person wireframe of a dungeon corridor. Each tile ahead is a slice of
+// corridor between two frames that shrink towards the middle of the view - walls on either side are
+// drawn between the frames and the first wall (or door) straight ahead closes the corridor off.
+// See Dungeon.md First-Person View.
+type DungeonView struct {
+	Width, Height int
+	Position      references.Position
+	// Facing is the compass direction the party is looking down
+	Facing references.Direction
+}
+
+func NewDungeonView(width, height int, position references.Position, facing references.Direction) DungeonView {
+	return DungeonView{Width: width, Height: height, Position: position, Facing: facing}
+}
+
+// Render draws the view offscreen so it can be shown by any front end (or inspected by tests)
+func (v *DungeonView) Render(source DungeonViewTileSource) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, v.Width, v.Height))
+	fillRect(img, img.Bounds(), dungeonViewBackground)
+
+	// the corridor is only drawn as far as the first thing that can't be seen through
+	nCells := DungeonViewDepth
+	for distance := 1; distance <= DungeonViewDepth; distance++ {
+		tile := v.getTile(source, distance, 0)
+		if blocksSight(tile) {
+			v.drawFace(img, v.getFrame(distance), img.Bounds(), tile)
+			nCells = distance
+			break
+		}
+	}
+
+	// far to near so the nearer walls are drawn over the farther ones
+	for distance := nCells - 1; distance >= 0; distance-- {
+		v.drawSide(img, source, distance, -1)
+		v.drawSide(img, source, distance, 1)
+		v.drawContents(img, distance, v.getTile(source, distance, 0))
+	}
+	return img
+}
+
+// getTile is the tile distance ahead of the party and across to the side (negative is to the left)
+func (v *DungeonView) getTile(source DungeonViewTileSource, distance, across int) references.DungeonTile {
+	forwardX, forwardY := getDirectionDelta(v.Facing)
+	// the party's right hand is a quarter turn clockwise from where they face
+	rightX, rightY := -forwardY, forwardX
+	return source.GetTile(references.Position{
+		X: v.Position.X + references.Coordinate(forwardX*distance+rightX*across),
+		Y: v.Position.Y + references.Coordinate(forwardY*distance+rightY*across),
+	})
+}
+
+func getDirectionDelta(direction references.Direction) (int, int) {
+	switch direction {
+	case references.Up:
+		return 0, -1
+	case references.Down:
+		return 0, 1
+	case references.Left:
+		return -1, 0
+	case references.Right:
+		return 1, 0
+	}
+	return 0, 0
+}
+
+// getFrame is the outline of the corridor at the near edge of the tile distance ahead. The party's
+// own tile starts at the edge of the view.
+func (v *DungeonView) getFrame(distance int) image.Rectangle {
+	halfWidth := v.Width / (distance + 2)
+	halfHeight := v.Height / (distance + 2)
+	centreX, centreY := v.Width/2, v.Height/2
+	return image.Rect(centreX-halfWidth, centreY-halfHeight, centreX+halfWidth, centreY+halfHeight)
+}
+
+func blocksSight(tile references.DungeonTile) bool {
+	return tile.IsWall() || isDoorLike(tile)
+}
+
+// isDoorLike is true for every tile that is drawn as a door - the doors into rooms included
+func isDoorLike(tile references.DungeonTile) bool {
+	switch tile.Type() {
+	case references.DungeonDoor, references.DungeonRoom, references.DungeonRoomsBroke:
+		return true
+	}
+	return false
+}
+
+// drawSide draws the wall beside the tile distance ahead or, if the side is open, the face of the
+// wall at the far end of the side passage
+func (v *DungeonView) drawSide(img *image.RGBA, source DungeonViewTileSource, distance, across int) {
+	near, far := v.getFrame(distance), v.getFrame(distance+1)
+	left := across < 0
+
+	sideTile := v.getTile(source, distance, across)
+	if blocksSight(sideTile) {
+		v.drawSideWall(img, near, far, left, sideTile)
+		return
+	}
+
+	beyondTile := v.getTile(source, distance+1, across)
+	if !blocksSight(beyondTile) {
+		return
+	}
+	face := far.Add(image.Pt(far.Dx(), 0))
+	clip := image.Rect(far.Max.X, far.Min.Y, near.Max.X, far.Max.Y)
+	if left {
+		face = far.Sub(image.Pt(far.Dx(), 0))
+		clip = image.Rect(near.Min.X, far.Min.Y, far.Min.X, far.Max.Y)
+	}
+	v.drawFace(img, face, clip, beyondTile)
+}
+
+// drawSideWall fills the wall running from the near frame to the far frame, column by column
+func (v *DungeonView) drawSideWall(img *image.RGBA, near, far image.Rectangle, left bool, tile references.DungeonTile) {
+	nearX, farX := near.Max.X-1, far.Max.X
+	if left {
+		nearX, farX = near.Min.X, far.Min.X-1
+	}
+	span := farX - nearX
+	if span == 0 {
+		return
+	}
+	step := 1
+	if span < 0 {
+		step = -1
+	}
+
+	for x := nearX; x != farX+step; x += step {
+		top := near.Min.Y + (far.Min.Y-near.Min.Y)*(x-nearX)/span
+		bottom := near.Max.Y + (far.Max.Y-near.Max.Y)*(x-nearX)/span
+		colour := dungeonViewWall
+		if isDoorLike(tile) && isInMiddleThird(x-nearX, span) {
+			doorTop := top + (bottom-top)/4
+			fillColumn(img, x, top, doorTop, dungeonViewWall)
+			top, colour = doorTop, dungeonViewDoor
+		}
+		fillColumn(img, x, top, bottom, colour)
+	}
+
+	drawLine(img, nearX, near.Min.Y, farX, far.Min.Y, dungeonViewEdge)
+	drawLine(img, nearX, near.Max.Y-1, farX, far.Max.Y-1, dungeonViewEdge)
+	drawLine(img, farX, far.Min.Y, farX, far.Max.Y-1, dungeonViewEdge)
+}
+
+func isInMiddleThird(offset, span int) bool {
+	if span < 0 {
+		offset, span = -offset, -span
+	}
+	return offset*3 >= span && offset*3 <= span*2
+}
+
+// drawFace draws a wall seen straight on, clipped to the part of the view it can be seen through
+func (v *DungeonView) drawFace(img *image.RGBA, face, clip image.Rectangle, tile references.DungeonTile) {
+	fillRect(img, face.Intersect(clip), dungeonViewWall)
+	if isDoorLike(tile) {
+		door := image.Rect(face.Min.X+face.Dx()/3, face.Min.Y+face.Dy()/4, face.Max.X-face.Dx()/3, face.Max.Y)
+		fillRect(img, door.Intersect(clip), dungeonViewDoor)
+	}
+	drawRectOutline(img, face.Intersect(clip), dungeonViewEdge)
+}
+
+// drawContents draws whatever is standing in the tile distance ahead
+func (v *DungeonView) drawContents(img *image.RGBA, distance int, tile references.DungeonTile) {
+	near, far := v.getFrame(distance), v.getFrame(distance+1)
+	// the middle of the tile, halfway between its frames
+	middle := image.Rect((near.Min.X+far.Min.X)/2, (near.Min.Y+far.Min.Y)/2, (near.Max.X+far.Max.X)/2, (near.Max.Y+far.Max.Y)/2)
+	centreX := v.Width / 2
+
+	switch tile.Type() {
+	case references.DungeonLadderUp, references.DungeonLadderDown, references.DungeonLadderUpDown:
+		if tile.IsLadderUp() {
+			fillRect(img, getHatch(centreX, middle, far.Min.Y, near.Min.Y), dungeonViewHole)
+		}
+		if tile.IsLadderDown() {
+			fillRect(img, getHatch(centreX, middle, far.Max.Y, near.Max.Y), dungeonViewHole)
+		}
+		drawLadder(img, centreX, middle)
+	case references.DungeonChest:
+		fillRect(img, getOnFloor(centreX, middle, middle.Dx()/4, middle.Dy()/6), dungeonViewChest)
+	case references.DungeonOpenChest:
+		drawRectOutline(img, getOnFloor(centreX, middle, middle.Dx()/4, middle.Dy()/6), dungeonViewChest)
+	case references.DungeonFountain:
+		fillRect(img, getOnFloor(centreX, middle, middle.Dx()/6, middle.Dy()/4), dungeonViewFountain)
+	case references.DungeonTrap:
+		// only the traps that can be seen are drawn
+		switch tile.GetTrapType() {
+		case references.LowerVisibleDungeonTrap:
+			fillRect(img, getHatch(centreX, middle, far.Max.Y, near.Max.Y), dungeonViewHole)
+		case references.UpperVisibleDungeonTrap:
+			fillRect(img, getHatch(centreX, middle, far.Min.Y, near.Min.Y), dungeonViewHole)
+		}
+	case references.DungeonMagicField:
+		fillDithered(img, middle, dungeonViewFieldColours[tile.GetFieldType()])
+	}
+}
+
+// getHatch is an opening in the floor or ceiling of a tile, between the heights of its two frames
+func getHatch(centreX int, middle image.Rectangle, farY, nearY int) image.Rectangle {
+	top, bottom := min(farY, nearY), max(farY, nearY)
+	inset := (bottom - top) / 4
+	return image.Rect(centreX-middle.Dx()/4, top+inset, centreX+middle.Dx()/4, bottom-inset)
+}
+
+// getOnFloor is a box of the given size sitting in the middle of the tile's floor
+func getOnFloor(centreX int, middle image.Rectangle, width, height int) image.Rectangle {
+	return image.Rect(centreX-width/2, middle.Max.Y-height, centreX+width/2, middle.Max.Y)
+}
+
+func drawLadder(img *image.RGBA, centreX int, middle image.Rectangle) {
+	railOffset := middle.Dx() / 8
+	drawLine(img, centreX-railOffset, middle.Min.Y, centreX-railOffset, middle.Max.Y-1, dungeonViewLadder)
+	drawLine(img, centreX+railOffset, middle.Min.Y, centreX+railOffset, middle.Max.Y-1, dungeonViewLadder)
+
+	rungGap := max(middle.Dy()/6, 2)
+	for y := middle.Min.Y + rungGap/2; y < middle.Max.Y; y += rungGap {
+		drawLine(img, centreX-railOffset, y, centreX+railOffset, y, dungeonViewLadder)
+	}
+}
+
+func fillRect(img *image.RGBA, rect image.Rectangle, colour color.RGBA) {
+	rect = rect.Intersect(img.Bounds())
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			img.SetRGBA(x, y, colour)
+		}
+	}
+}
+
+// fillDithered colours every other pixel so what is behind still shows through
+func fillDithered(img *image.RGBA, rect image.Rectangle, colour color.RGBA) {
+	rect = rect.Intersect(img.Bounds())
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			if (x+y)%2 == 0 {
+				img.SetRGBA(x, y, colour)
+			}
+		}
+	}
+}
+
+func fillColumn(img *image.RGBA, x, top, bottom int, colour color.RGBA) {
+	fillRect(img, image.Rect(x, top, x+1, bottom), colour)
+}
+
+func drawRectOutline(img *image.RGBA, rect image.Rectangle, colour color.RGBA) {
+	if rect.Empty() {
+		return
+	}
+	drawLine(img, rect.Min.X, rect.Min.Y, rect.Max.X-1, rect.Min.Y, colour)
+	drawLine(img, rect.Min.X, rect.Max.Y-1, rect.Max.X-1, rect.Max.Y-1, colour)
+	drawLine(img, rect.Min.X, rect.Min.Y, rect.Min.X, rect.Max.Y-1, colour)
+	drawLine(img, rect.Max.X-1, rect.Min.Y, rect.Max.X-1, rect.Max.Y-1, colour)
+}
+
+// drawLine is Bresenham's line, so the same view always comes out pixel for pixel the same
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, colour color.RGBA) {
+	dx, dy := helpers.AbsInt(x1-x0), -helpers.AbsInt(y1-y0)
+	stepX, stepY := 1, 1
+	if x0 > x1 {
+		stepX = -1
+	}
+	if y0 > y1 {
+		stepY = -1
+	}
+
+	err := dx + dy
+	for {
+		if image.Pt(x0, y0).In(img.Bounds()) {
+			img.SetRGBA(x0, y0, colour)
+		}
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += stepX
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += stepY
+		}
+	}
+}
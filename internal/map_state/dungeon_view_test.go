@@ -0,0 +1,135 @@
+package map_state
+
+import (
+	"bytes"
+	"flag"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden images in testdata")
+
+const dungeonViewTestSize = 128
+
+// newDungeonViewTestLevel is solid rock with a single corridor running north up column 3
+func newDungeonViewTestLevel() *references.DungeonLevel {
+	level := &references.DungeonLevel{}
+	for y := references.Coordinate(0); y < references.YDungeonTiles; y++ {
+		for x := references.Coordinate(0); x < references.XDungeonTiles; x++ {
+			level.SetTile(references.Position{X: x, Y: y}, references.NewDungeonTile(references.DungeonWall, 0))
+		}
+	}
+	for y := references.Coordinate(2); y < references.YDungeonTiles; y++ {
+		level.SetTile(references.Position{X: 3, Y: y}, references.NewDungeonTile(references.DungeonNothing, 0))
+	}
+	return level
+}
+
+func TestDungeonView_CorridorMatchesGolden(t *testing.T) {
+	level := newDungeonViewTestLevel()
+	// a side passage to the left, a door to the right, a ladder and a chest on the way to a door
+	level.SetTile(references.Position{X: 2, Y: 5}, references.NewDungeonTile(references.DungeonNothing, 0))
+	level.SetTile(references.Position{X: 4, Y: 4}, references.NewDungeonTile(references.DungeonDoor, 0))
+	level.SetTile(references.Position{X: 3, Y: 5}, references.NewDungeonTile(references.DungeonLadderUpDown, 0))
+	level.SetTile(references.Position{X: 3, Y: 4}, references.NewDungeonTile(references.DungeonChest, 0))
+	level.SetTile(references.Position{X: 3, Y: 3}, references.NewDungeonTile(references.DungeonMagicField, byte(references.FireDungeonField)))
+	level.SetTile(references.Position{X: 3, Y: 2}, references.NewDungeonTile(references.DungeonDoor, 0))
+
+	dungeonView := NewDungeonView(dungeonViewTestSize, dungeonViewTestSize, references.Position{X: 3, Y: 6}, references.Up)
+	assertMatchesGolden(t, dungeonView.Render(level), "dungeon_view_corridor.png")
+}
+
+func TestDungeonView_WallAheadClosesTheCorridor(t *testing.T) {
+	level := newDungeonViewTestLevel()
+	dungeonView := NewDungeonView(dungeonViewTestSize, dungeonViewTestSize, references.Position{X: 3, Y: 2}, references.Up)
+	img := dungeonView.Render(level)
+
+	centre := dungeonViewTestSize / 2
+	if got := img.RGBAAt(centre, centre); got != dungeonViewWall {
+		t.Errorf("Expected the wall ahead in the middle of the view, got %v", got)
+	}
+	if got := img.RGBAAt(2, centre); got != dungeonViewWall {
+		t.Errorf("Expected the wall beside the party at the edge of the view, got %v", got)
+	}
+}
+
+func TestDungeonView_FacingChangesWhatIsSeen(t *testing.T) {
+	level := newDungeonViewTestLevel()
+	centre := dungeonViewTestSize / 2
+
+	// looking down the corridor there is nothing close enough to close it off
+	dungeonView := NewDungeonView(dungeonViewTestSize, dungeonViewTestSize, references.Position{X: 3, Y: 7}, references.Up)
+	if got := dungeonView.Render(level).RGBAAt(centre, centre); got != dungeonViewBackground {
+		t.Errorf("Expected to see down the corridor, got %v", got)
+	}
+
+	// turned to the east the party faces the corridor wall
+	dungeonView.Facing = references.Right
+	if got := dungeonView.Render(level).RGBAAt(centre, centre); got != dungeonViewWall {
+		t.Errorf("Expected to face a wall, got %v", got)
+	}
+}
+
+func TestDungeonView_OpenSidesShowTheFarWall(t *testing.T) {
+	level := &references.DungeonLevel{}
+	// open floor all round except a wall two tiles ahead and one to the left
+	level.SetTile(references.Position{X: 3, Y: 3}, references.NewDungeonTile(references.DungeonWall, 0))
+
+	dungeonView := NewDungeonView(dungeonViewTestSize, dungeonViewTestSize, references.Position{X: 4, Y: 5}, references.Up)
+	img := dungeonView.Render(level)
+
+	near, far := dungeonView.getFrame(1), dungeonView.getFrame(2)
+	if got := img.RGBAAt((near.Min.X+far.Min.X)/2, dungeonViewTestSize/2); got != dungeonViewWall {
+		t.Errorf("Expected the face of the wall down the side passage, got %v", got)
+	}
+	if got := img.RGBAAt(2, dungeonViewTestSize/2); got != dungeonViewBackground {
+		t.Errorf("Expected no wall beside the party, got %v", got)
+	}
+}
+
+func assertMatchesGolden(t *testing.T, img *image.RGBA, name string) {
+	t.Helper()
+	goldenPath := filepath.Join("testdata", name)
+
+	if *updateGolden {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			t.Fatalf("Failed to encode %s: %v", name, err)
+		}
+		if err := os.WriteFile(goldenPath, buf.Bytes(), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", goldenPath, err)
+		}
+		return
+	}
+
+	goldenFile, err := os.Open(goldenPath)
+	if err != nil {
+		t.Fatalf("Failed to open %s (run with -update to create it): %v", goldenPath, err)
+	}
+	defer goldenFile.Close()
+	golden, err := png.Decode(goldenFile)
+	if err != nil {
+		t.Fatalf("Failed to decode %s: %v", goldenPath, err)
+	}
+
+	if golden.Bounds() != img.Bounds() {
+		t.Fatalf("Expected a %v image, got %v", golden.Bounds(), img.Bounds())
+	}
+	for y := img.Bounds().Min.Y; y < img.Bounds().Max.Y; y++ {
+		for x := img.Bounds().Min.X; x < img.Bounds().Max.X; x++ {
+			if gotR, gotG, gotB, gotA := img.At(x, y).RGBA(); [4]uint32{gotR, gotG, gotB, gotA} != rgbaOf(golden, x, y) {
+				t.Fatalf("First difference from %s at (%d, %d)", name, x, y)
+			}
+		}
+	}
+}
+
+func rgbaOf(img image.Image, x, y int) [4]uint32 {
+	r, g, b, a := img.At(x, y).RGBA()
+	return [4]uint32{r, g, b, a}
+}
@@ -0,0 +1,204 @@
+package map_state
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+const (
+	// GemViewLargeMapTiles is the width and height of the window of the overworld shown by a gem
+	GemViewLargeMapTiles = 64
+	// DungeonLevelTiles is the width and height of a single dungeon level
+	DungeonLevelTiles = 8
+
+	gemViewPixelsPerTile        = 4
+	gemViewDungeonPixelsPerTile = 16
+)
+
+var (
+	gemViewUnknownColour = color.RGBA{A: 0xff}
+	gemViewPartyColour   = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	gemViewDeepWater     = color.RGBA{R: 0x00, G: 0x00, B: 0x90, A: 0xff}
+	gemViewShallowWater  = color.RGBA{R: 0x30, G: 0x60, B: 0xd0, A: 0xff}
+	gemViewSwamp         = color.RGBA{R: 0x40, G: 0x50, B: 0x20, A: 0xff}
+	gemViewGrass         = color.RGBA{R: 0x20, G: 0xa0, B: 0x20, A: 0xff}
+	gemViewForest        = color.RGBA{R: 0x00, G: 0x60, B: 0x00, A: 0xff}
+	gemViewDesert        = color.RGBA{R: 0xd8, G: 0xc0, B: 0x70, A: 0xff}
+	gemViewHills         = color.RGBA{R: 0x80, G: 0x60, B: 0x30, A: 0xff}
+	gemViewMountains     = color.RGBA{R: 0xa0, G: 0xa0, B: 0xa0, A: 0xff}
+	gemViewLandmark      = color.RGBA{R: 0xe0, G: 0x20, B: 0x20, A: 0xff}
+	gemViewPath          = color.RGBA{R: 0xb0, G: 0x90, B: 0x60, A: 0xff}
+	gemViewWall          = color.RGBA{R: 0x60, G: 0x60, B: 0x60, A: 0xff}
+	gemViewFloor         = color.RGBA{R: 0x50, G: 0x40, B: 0x30, A: 0xff}
+	gemViewDoor          = color.RGBA{R: 0xc0, G: 0x70, B: 0x10, A: 0xff}
+	gemViewLadder        = color.RGBA{R: 0xf0, G: 0xe0, B: 0x20, A: 0xff}
+	gemViewHazard        = color.RGBA{R: 0xff, G: 0x60, B: 0x00, A: 0xff}
+	gemViewFurnishing    = color.RGBA{R: 0x30, G: 0x30, B: 0x30, A: 0xff}
+)
+
+var gemViewPixelsPerTileByMapType = map[references.GeneralMapType]int{
+	references.LargeMapType:   gemViewPixelsPerTile,
+	references.SmallMapType:   gemViewPixelsPerTile,
+	references.CombatMapType:  gemViewPixelsPerTile,
+	references.DungeonMapType: gemViewDungeonPixelsPerTile,
+}
+
+// GemViewTileSource provides the map tiles drawn by a gem view - a LayeredMap satisfies it
+type GemViewTileSource interface {
+	GetTileTopMapOnlyTile(position *references.Position) *references.Tile
+}
+
+// GemView is the miniature top-down map shown when a gem is peered into (or a View scroll is read).
+// Every tile becomes a single colour-coded block of pixels and the party is marked in white.
+// See Commands.md View (Gem Map).
+type GemView struct {
+	TopLeft              references.Position
+	TilesWide, TilesHigh references.Coordinate
+	PixelsPerTile        int
+	PartyPosition        references.Position
+
+	// mapWidth and mapHeight are only set on maps that wrap around (the overworld and underworld)
+	mapWidth, mapHeight references.Coordinate
+}
+
+// NewGemView creates the view for the given map type. Towns, combat maps and dungeon levels are shown
+// whole while the wrapping 256x256 overworld is shown as a window centred on the party.
+func NewGemView(mapType references.GeneralMapType, xTiles, yTiles references.Coordinate, partyPosition references.Position) GemView {
+	gemView := GemView{
+		TilesWide:     xTiles,
+		TilesHigh:     yTiles,
+		PixelsPerTile: gemViewPixelsPerTileByMapType[mapType],
+		PartyPosition: partyPosition,
+	}
+
+	if mapType == references.LargeMapType {
+		gemView.TilesWide = min(xTiles, GemViewLargeMapTiles)
+		gemView.TilesHigh = min(yTiles, GemViewLargeMapTiles)
+		gemView.mapWidth, gemView.mapHeight = xTiles, yTiles
+		gemView.TopLeft = *(&references.Position{
+			X: partyPosition.X - gemView.TilesWide/2,
+			Y: partyPosition.Y - gemView.TilesHigh/2,
+		}).GetWrapped(xTiles, yTiles)
+	}
+	return gemView
+}
+
+// Render draws the view offscreen so it can be shown by any front end (or inspected by tests)
+func (v *GemView) Render(source GemViewTileSource) *image.RGBA {
+	return v.render(func(pos references.Position) color.RGBA {
+		if tile := source.GetTileTopMapOnlyTile(&pos); tile != nil {
+			return GetGemViewTileColour(tile)
+		}
+		return gemViewUnknownColour
+	})
+}
+
+// RenderDungeonLevel draws the view of a dungeon level, which is made of dungeon tiles rather than sprites
+func (v *GemView) RenderDungeonLevel(source DungeonViewTileSource) *image.RGBA {
+	return v.render(func(pos references.Position) color.RGBA {
+		return GetGemViewDungeonTileColour(source.GetTile(pos))
+	})
+}
+
+func (v *GemView) render(getTileColour func(pos references.Position) color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, int(v.TilesWide)*v.PixelsPerTile, int(v.TilesHigh)*v.PixelsPerTile))
+
+	for x := range v.TilesWide {
+		for y := range v.TilesHigh {
+			pos := v.GetMapPosition(x, y)
+
+			tileColour := gemViewPartyColour
+			if !pos.Equals(&v.PartyPosition) {
+				tileColour = getTileColour(pos)
+			}
+			v.fillTile(img, x, y, tileColour)
+		}
+	}
+	return img
+}
+
+// GetMapPosition converts a tile position within the view to its position on the map
+func (v *GemView) GetMapPosition(viewX, viewY references.Coordinate) references.Position {
+	pos := references.Position{X: v.TopLeft.X + viewX, Y: v.TopLeft.Y + viewY}
+	if v.mapWidth > 0 {
+		pos = *pos.GetWrapped(v.mapWidth, v.mapHeight)
+	}
+	return pos
+}
+
+func (v *GemView) fillTile(img *image.RGBA, viewX, viewY references.Coordinate, tileColour color.RGBA) {
+	for px := range v.PixelsPerTile {
+		for py := range v.PixelsPerTile {
+			img.SetRGBA(int(viewX)*v.PixelsPerTile+px, int(viewY)*v.PixelsPerTile+py, tileColour)
+		}
+	}
+}
+
+// GetGemViewTileColour buckets a tile into the small palette used by the gem view
+func GetGemViewTileColour(tile *references.Tile) color.RGBA {
+	switch index := tile.Index; {
+	case index == indexes.Water1 || index == indexes.Water2:
+		return gemViewDeepWater
+	case index == indexes.WaterShallow:
+		return gemViewShallowWater
+	case index == indexes.Swamp:
+		return gemViewSwamp
+	case index == indexes.Grass || index == indexes.Brush || index == indexes.PlowedField || index == indexes.WheatInField:
+		return gemViewGrass
+	case index == indexes.ThickBrush || index == indexes.Forest || index == indexes.Tropical || index == indexes.FruitTree:
+		return gemViewForest
+	case tile.IsDesert() || index == indexes.Beach || index == indexes.Cactus:
+		return gemViewDesert
+	case index == indexes.Hills || index == indexes.LeftHills || index == indexes.RightHills:
+		return gemViewHills
+	case tile.IsMountain() || index == indexes.Peaks:
+		return gemViewMountains
+	case index >= indexes.Hut && index <= indexes.Oasis:
+		return gemViewLandmark
+	case tile.IsPath() || index == indexes.RedBridge || (index >= indexes.TrollBridgeHoriz && index <= indexes.Bridge):
+		return gemViewPath
+	case index.IsDoor():
+		return gemViewDoor
+	case index == indexes.LadderUp || index == indexes.LadderDown || index.IsStairs():
+		return gemViewLadder
+	case index == indexes.Lava || index.IsField():
+		return gemViewHazard
+	case tile.IsWall() || (index >= indexes.Rocks && index <= indexes.Window):
+		return gemViewWall
+	case index >= indexes.WoodenPlankHorizFloor && index <= indexes.SmallRocks:
+		return gemViewFloor
+	default:
+		return gemViewFurnishing
+	}
+}
+
+// GetGemViewDungeonTileColour buckets a dungeon tile into the same palette as the rest of the gem view
+func GetGemViewDungeonTileColour(tile references.DungeonTile) color.RGBA {
+	switch tile.Type() {
+	case references.DungeonWall, references.DungeonSecondaryWall, references.DungeonSecretDoor:
+		// a secret door looks like any other wall until it is found
+		return gemViewWall
+	case references.DungeonDoor, references.DungeonRoom, references.DungeonRoomsBroke:
+		return gemViewDoor
+	case references.DungeonLadderUp, references.DungeonLadderDown, references.DungeonLadderUpDown:
+		return gemViewLadder
+	case references.DungeonMagicField:
+		return gemViewHazard
+	case references.DungeonFountain:
+		return gemViewShallowWater
+	case references.DungeonChest, references.DungeonOpenChest:
+		return gemViewFurnishing
+	case references.DungeonTrap:
+		// only the traps that can be seen give themselves away
+		switch tile.GetTrapType() {
+		case references.LowerVisibleDungeonTrap, references.UpperVisibleDungeonTrap:
+			return gemViewHazard
+		}
+		return gemViewFloor
+	default:
+		return gemViewFloor
+	}
+}
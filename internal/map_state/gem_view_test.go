@@ -0,0 +1,116 @@
+package map_state
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+// gemViewTestSource is a map of water with a single wall, a door and a ladder
+type gemViewTestSource struct {
+	tiles map[references.Position]indexes.SpriteIndex
+}
+
+func (s *gemViewTestSource) GetTileTopMapOnlyTile(position *references.Position) *references.Tile {
+	if index, ok := s.tiles[*position]; ok {
+		return &references.Tile{Index: index}
+	}
+	return &references.Tile{Index: indexes.Water1}
+}
+
+func TestGemView_SmallMapColoursEachTile(t *testing.T) {
+	source := &gemViewTestSource{tiles: map[references.Position]indexes.SpriteIndex{
+		{X: 0, Y: 0}:   indexes.StoneBrickWall,
+		{X: 5, Y: 6}:   indexes.RegularDoor,
+		{X: 31, Y: 31}: indexes.LadderUp,
+	}}
+	partyPosition := references.Position{X: 10, Y: 12}
+
+	gemView := NewGemView(references.SmallMapType, references.XSmallMapTiles, references.YSmallMapTiles, partyPosition)
+	img := gemView.Render(source)
+
+	if img.Bounds().Dx() != int(references.XSmallMapTiles)*gemViewPixelsPerTile {
+		t.Fatalf("Expected the whole town to be drawn, got width %d", img.Bounds().Dx())
+	}
+
+	expectations := []struct {
+		pos    references.Position
+		colour color.RGBA
+	}{
+		{references.Position{X: 0, Y: 0}, gemViewWall},
+		{references.Position{X: 5, Y: 6}, gemViewDoor},
+		{references.Position{X: 31, Y: 31}, gemViewLadder},
+		{references.Position{X: 1, Y: 1}, gemViewDeepWater},
+		{partyPosition, gemViewPartyColour},
+	}
+	for _, expected := range expectations {
+		// check the last pixel of the block so the whole block is known to be filled
+		x := int(expected.pos.X)*gemViewPixelsPerTile + gemViewPixelsPerTile - 1
+		y := int(expected.pos.Y)*gemViewPixelsPerTile + gemViewPixelsPerTile - 1
+		if got := img.RGBAAt(x, y); got != expected.colour {
+			t.Errorf("Expected %v at %v, got %v", expected.colour, expected.pos, got)
+		}
+	}
+}
+
+func TestGemView_LargeMapWindowWrapsAroundParty(t *testing.T) {
+	source := &gemViewTestSource{tiles: map[references.Position]indexes.SpriteIndex{
+		{X: references.XLargeMapTiles - 1, Y: 0}: indexes.Castle,
+	}}
+	partyPosition := references.Position{X: 2, Y: 3}
+
+	gemView := NewGemView(references.LargeMapType, references.XLargeMapTiles, references.YLargeMapTiles, partyPosition)
+	if gemView.TilesWide != GemViewLargeMapTiles || gemView.TilesHigh != GemViewLargeMapTiles {
+		t.Fatalf("Expected a %d tile window, got %dx%d", GemViewLargeMapTiles, gemView.TilesWide, gemView.TilesHigh)
+	}
+
+	img := gemView.Render(source)
+
+	centre := references.Coordinate(GemViewLargeMapTiles / 2)
+	if got := img.RGBAAt(int(centre)*gemViewPixelsPerTile, int(centre)*gemViewPixelsPerTile); got != gemViewPartyColour {
+		t.Errorf("Expected the party in the centre of the window, got %v", got)
+	}
+
+	// the castle is three tiles left and three tiles up of the party, across the edge of the world
+	castleX, castleY := int(centre-3)*gemViewPixelsPerTile, int(centre-3)*gemViewPixelsPerTile
+	if got := img.RGBAAt(castleX, castleY); got != gemViewLandmark {
+		t.Errorf("Expected the wrapped castle at the top left of the party, got %v", got)
+	}
+}
+
+func TestGemView_DungeonLevelUsesLargerBlocks(t *testing.T) {
+	var level references.DungeonLevel
+	level.SetTile(references.Position{X: 0, Y: 0}, references.NewDungeonTile(references.DungeonWall, 0))
+	level.SetTile(references.Position{X: 3, Y: 6}, references.NewDungeonTile(references.DungeonLadderUp, 0))
+	level.SetTile(references.Position{X: 4, Y: 4}, references.NewDungeonTile(references.DungeonSecretDoor, 0))
+	level.SetTile(references.Position{X: 5, Y: 5}, references.NewDungeonTile(references.DungeonTrap, byte(references.InvisibleDungeonTrap)))
+
+	gemView := NewGemView(references.DungeonMapType, DungeonLevelTiles, DungeonLevelTiles, references.Position{X: 7, Y: 0})
+	img := gemView.RenderDungeonLevel(&level)
+
+	if img.Bounds().Dx() != DungeonLevelTiles*gemViewDungeonPixelsPerTile {
+		t.Errorf("Expected the whole level at %d pixels per cell, got width %d", gemViewDungeonPixelsPerTile, img.Bounds().Dx())
+	}
+
+	expectations := []struct {
+		pos    references.Position
+		colour color.RGBA
+	}{
+		{references.Position{X: 7, Y: 0}, gemViewPartyColour},
+		{references.Position{X: 0, Y: 0}, gemViewWall},
+		{references.Position{X: 3, Y: 6}, gemViewLadder},
+		// secret doors and hidden traps aren't given away
+		{references.Position{X: 4, Y: 4}, gemViewWall},
+		{references.Position{X: 5, Y: 5}, gemViewFloor},
+		{references.Position{X: 1, Y: 1}, gemViewFloor},
+	}
+	for _, expected := range expectations {
+		x := int(expected.pos.X)*gemViewDungeonPixelsPerTile + gemViewDungeonPixelsPerTile - 1
+		y := int(expected.pos.Y)*gemViewDungeonPixelsPerTile + gemViewDungeonPixelsPerTile - 1
+		if got := img.RGBAAt(x, y); got != expected.colour {
+			t.Errorf("Expected %v at %v, got %v", expected.colour, expected.pos, got)
+		}
+	}
+}
@@ -52,6 +52,9 @@ type LayeredMap struct {
 	XMaxTilesPerMap, YMaxTilesPerMap references.Coordinate
 
 	bWrappingMap bool
+
+	// dousedLights are light fixtures that have been put out
+	dousedLights map[references.Position]bool
 }
 
 func newLayeredMap(xMax references.Coordinate,
@@ -116,6 +119,17 @@ func (l *LayeredMap) RecalculateVisibleTiles(avatarPos references.Position, ligh
 	l.floodFillIfInside(avatarPos.GetPositionDown(), true)
 	l.floodFillIfInside(avatarPos.GetPositionUp(), true)
 
+	// X-ray vision sees through everything within the visible area
+	if lighting.HasXRayVision() {
+		l.visibleFlags.SetVisibilityCoordsRectangle(
+			&l.TopLeft,
+			&l.BottomRight,
+			l.XMaxTilesPerMap,
+			l.YMaxTilesPerMap,
+			l.bWrappingMap,
+		)
+	}
+
 	// get a full game screen lighting map
 	l.primaryDistanceMaskMap = lighting.BuildGameScreenDistanceMap(avatarPos)
 
@@ -123,7 +137,7 @@ func (l *LayeredMap) RecalculateVisibleTiles(avatarPos references.Position, ligh
 	lightSources := l.getAllLightSourcesInRange(avatarPos)
 	l.lightSourcesDistanceMap = lighting.BuildLightSourceDistanceMap(lightSources,
 		l.visibleFlags,
-		lighting.turnsToExtinguishTorch > 0,
+		lighting.HasAvatarLight(),
 		avatarPos,
 	)
 }
@@ -140,7 +154,7 @@ func (l *LayeredMap) getAllLightSourcesInRange(centerPosition references.Positio
 				continue
 			}
 
-			if tile.LightEmission > 0 {
+			if tile.LightEmission > 0 && !l.IsLightDoused(&pos) {
 				lightSources = append(lightSources, lightSource{
 					Tile: tile,
 					Pos:  pos,
@@ -152,6 +166,25 @@ func (l *LayeredMap) getAllLightSourcesInRange(centerPosition references.Positio
 	return lightSources
 }
 
+// ToggleLight puts out a light fixture, or relights it if it was already out. It is true if the
+// light is now lit.
+func (l *LayeredMap) ToggleLight(pos *references.Position) bool {
+	if l.dousedLights == nil {
+		l.dousedLights = make(map[references.Position]bool)
+	}
+	if l.dousedLights[*pos] {
+		delete(l.dousedLights, *pos)
+		return true
+	}
+	l.dousedLights[*pos] = true
+	return false
+}
+
+// IsLightDoused is true when the light fixture at pos has been put out
+func (l *LayeredMap) IsLightDoused(pos *references.Position) bool {
+	return l.dousedLights[*pos]
+}
+
 func (l *LayeredMap) floodFillIfInside(pos *references.Position, bForce bool) {
 	if l.bWrappingMap {
 		pos = pos.GetWrapped(l.XMaxTilesPerMap, l.YMaxTilesPerMap)
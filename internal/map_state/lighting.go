@@ -10,10 +10,16 @@ import (
 const (
 	TorchTileDistance                          = 3
 	DefaultNumberOfTurnsUntilTorchExtinguishes = 100
+	// DefaultNumberOfTurnsOfXRayVision is deliberately short - X-ray vision is "a flash of time". The
+	// turn it starts on is counted too, so it lasts through the turn after.
+	DefaultNumberOfTurnsOfXRayVision = 2
 )
 
 type Lighting struct {
 	turnsToExtinguishTorch int
+	turnsOfXRayVision      int
+	turnsOfMagicLight      int
+	bPersistentLight       bool
 	gameDimensions         GameDimensions
 	baselineFactor         float32
 	baselineRadius         int
@@ -47,8 +53,42 @@ func (l *Lighting) LightTorch() {
 	l.turnsToExtinguishTorch = DefaultNumberOfTurnsUntilTorchExtinguishes
 }
 
+// HasXRayVision returns true while walls and other opaque tiles should not block line of sight
+func (l *Lighting) HasXRayVision() bool {
+	return l.turnsOfXRayVision > 0
+}
+
+// StartXRayVision grants X-ray vision (White potion, Wis An Ylem) for a short number of turns
+func (l *Lighting) StartXRayVision() {
+	l.turnsOfXRayVision = DefaultNumberOfTurnsOfXRayVision
+}
+
+// HasMagicLight returns true while a light spell (In Lor, Vas Lor, Light scroll) is active
+func (l *Lighting) HasMagicLight() bool {
+	return l.turnsOfMagicLight > 0
+}
+
+// StartMagicLight lights the area around the avatar for the given number of turns.
+// A shorter light never cuts an existing longer one short.
+func (l *Lighting) StartMagicLight(nTurns int) {
+	l.turnsOfMagicLight = helpers.Max(l.turnsOfMagicLight, nTurns)
+}
+
+// SetPersistentLight is used by long-lived effects (the worn Amulet or Crown of Lord British) that
+// light the avatar's surroundings until they are cleared
+func (l *Lighting) SetPersistentLight(bPersistentLight bool) {
+	l.bPersistentLight = bPersistentLight
+}
+
+// HasAvatarLight returns true if a torch, magic light or a persistent effect is lighting the avatar's surroundings
+func (l *Lighting) HasAvatarLight() bool {
+	return l.HasTorchLit() || l.HasMagicLight() || l.bPersistentLight
+}
+
 func (l *Lighting) AdvanceTurn() {
 	l.turnsToExtinguishTorch = helpers.Max(l.turnsToExtinguishTorch-1, 0)
+	l.turnsOfMagicLight = helpers.Max(l.turnsOfMagicLight-1, 0)
+	l.turnsOfXRayVision = helpers.Max(l.turnsOfXRayVision-1, 0)
 }
 
 func (l *Lighting) BuildGameScreenDistanceMap(centrePos references.Position) DistanceMap {
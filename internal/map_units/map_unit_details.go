@@ -44,6 +44,9 @@ type MapUnitDetails struct {
 	// AStarMap *map_state.AStarMap
 
 	CurrentPath []references.Position
+
+	// ScheduleAction is how the NPC is getting to its scheduled destination, set when the hour ticks over
+	ScheduleAction references.ScheduleAction
 }
 
 func (mu *MapUnitDetails) SetOverriddenAiType(oAiType references.AiType) {
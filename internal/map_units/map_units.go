@@ -80,6 +80,22 @@ func (m *MapUnits) AddVehicle(vehicle NPCFriendly) bool {
 	return true
 }
 
+func (m *MapUnits) AddEnemy(enemyRef references.EnemyReference, pos references.Position, floor references.FloorNumber) bool {
+	index := m.getNextAvailableNPCIndexNumber()
+	if index == -1 {
+		return false
+	}
+
+	enemy := NewEnemyNPC(enemyRef, index)
+	enemy.SetPos(pos)
+	enemy.SetFloor(floor)
+	enemy.SetVisible(true)
+
+	*m = append(*m, &enemy)
+
+	return true
+}
+
 func (m *MapUnits) CreateFreshXyOccupiedMap() *XyOccupiedMap {
 	xy := make(XyOccupiedMap)
 	for _, mu := range *m {
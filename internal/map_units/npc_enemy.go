@@ -2,12 +2,24 @@ package map_units
 
 import (
 	references "github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+const (
+	// PirateShipHullPoints is the hull every pirate ship puts to sea with. See Encounters.md.
+	PirateShipHullPoints = 100
+	// sunkHullPoints is where the original takes a monster off the map once a cannonball has hit it.
+	// See Commands.md Fire - Ship (Broadsides).
+	sunkHullPoints = 127
 )
 
 // NPCEnemy single instance of an enemy NPC
 type NPCEnemy struct {
 	EnemyReference references.EnemyReference
 	mapUnitDetails MapUnitDetails
+
+	// hullPoints is the hull of a pirate ship - other monsters have none
+	hullPoints int
 }
 
 func NewEnemyNPC(enemyRef references.EnemyReference, npcNum int) NPCEnemy {
@@ -19,6 +31,58 @@ func NewEnemyNPC(enemyRef references.EnemyReference, npcNum int) NPCEnemy {
 	return enemy
 }
 
+// NewPirateShipNPC creates a pirate ship - it has no entry in the enemy references, the tile it is
+// drawn with is the direction it is heading
+func NewPirateShipNPC(pirateShipTile *references.Tile, npcNum int) NPCEnemy {
+	enemyRef := references.EnemyReference{} //nolint:exhaustruct
+	enemyRef.KeyFrameTile = pirateShipTile
+	enemyRef.AdditionalEnemyFlags.Name = "Pirate Ship"
+	enemyRef.AdditionalEnemyFlags.IsWaterEnemy = true
+	enemyRef.AdditionalEnemyFlags.ActivelyAttacks = true
+
+	enemy := NewEnemyNPC(enemyRef, npcNum)
+	enemy.hullPoints = PirateShipHullPoints
+	return enemy
+}
+
+// IsPirateShip returns true if the enemy is a pirate ship rather than a monster
+func (enemy *NPCEnemy) IsPirateShip() bool {
+	if enemy.EnemyReference.KeyFrameTile == nil {
+		return false
+	}
+	switch enemy.EnemyReference.KeyFrameTile.Index { //nolint:exhaustive
+	case indexes.PirateShip_Up, indexes.PirateShip_Right, indexes.PirateShip_Left, indexes.PirateShip_Down:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsWhirlpool returns true if the enemy is a whirlpool, which drags ships down rather than fighting
+func (enemy *NPCEnemy) IsWhirlpool() bool {
+	return enemy.EnemyReference.KeyFrameTile != nil && enemy.EnemyReference.KeyFrameTile.Index == indexes.Whirlpool_KeyIndex
+}
+
+// GetPirateShipHeading returns the direction a pirate ship is sailing in
+func (enemy *NPCEnemy) GetPirateShipHeading() references.Direction {
+	switch enemy.EnemyReference.KeyFrameTile.Index { //nolint:exhaustive
+	case indexes.PirateShip_Up:
+		return references.Up
+	case indexes.PirateShip_Left:
+		return references.Left
+	case indexes.PirateShip_Down:
+		return references.Down
+	default:
+		return references.Right
+	}
+}
+
+// DamageHull knocks points off a monster hit by a cannonball, returning true if it goes down
+func (enemy *NPCEnemy) DamageHull(points int) bool {
+	enemy.hullPoints -= points
+	return enemy.hullPoints <= sunkHullPoints
+}
+
 func (enemy *NPCEnemy) GetMapUnitType() MapUnitType {
 	return Enemy
 }
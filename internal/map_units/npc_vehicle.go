@@ -7,21 +7,34 @@ import (
 	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
 )
 
+// MaxHullPoints is the hull of a frigate fresh from the shipwright
+const MaxHullPoints = 99
+
 type VehicleDetails struct {
 	VehicleType   references.VehicleType
 	skiffQuantity int
 
 	currentDirection  references.Direction
 	previousDirection references.Direction
+
+	// sailsHoisted is only ever set on a frigate - skiffs are always rowed
+	sailsHoisted bool
+
+	// hullPoints is only meaningful for a frigate - the ship sinks when it reaches 0
+	hullPoints int
 }
 
 func NewVehicleDetails(vehicleType references.VehicleType) VehicleDetails {
-	return VehicleDetails{
+	vehicleDetails := VehicleDetails{
 		VehicleType:       vehicleType,
 		skiffQuantity:     0,
 		currentDirection:  references.Right,
 		previousDirection: references.Right,
 	}
+	if vehicleType == references.FrigateVehicle {
+		vehicleDetails.hullPoints = MaxHullPoints
+	}
+	return vehicleDetails
 }
 
 func (v *VehicleDetails) SetPartyVehicleDirection(direction references.Direction) {
@@ -40,10 +53,16 @@ func (v *VehicleDetails) SetPartyVehicleDirection(direction references.Direction
 }
 
 func (v *VehicleDetails) GetBoardedSpriteIndex() indexes.SpriteIndex {
+	if v.sailsHoisted {
+		return getUnfurledFrigateSpriteByDirection(v.currentDirection)
+	}
 	return v.VehicleType.GetBoardedSpriteByDirection(v.previousDirection, v.currentDirection)
 }
 
 func (v *VehicleDetails) GetUnBoardedSpriteIndex() indexes.SpriteIndex {
+	if v.sailsHoisted {
+		return getUnfurledFrigateSpriteByDirection(v.currentDirection)
+	}
 	return v.VehicleType.GetUnBoardedSpriteByDirection(v.currentDirection)
 }
 
@@ -81,3 +100,56 @@ func (v *VehicleDetails) HasAtLeastOneSkiff() bool {
 func (v *VehicleDetails) IncrementSkiffQuantity() {
 	v.skiffQuantity++
 }
+
+// GetCurrentDirection returns the direction the vehicle is facing (or heading, for a ship)
+func (v *VehicleDetails) GetCurrentDirection() references.Direction {
+	return v.currentDirection
+}
+
+// HoistSails raises the sails of a frigate, returning false for any other vehicle
+func (v *VehicleDetails) HoistSails() bool {
+	if v.VehicleType != references.FrigateVehicle {
+		return false
+	}
+	v.sailsHoisted = true
+	return true
+}
+
+// FurlSails lowers the sails so the ship can only be rowed
+func (v *VehicleDetails) FurlSails() {
+	v.sailsHoisted = false
+}
+
+// AreSailsHoisted returns true if the ship is under sail
+func (v *VehicleDetails) AreSailsHoisted() bool {
+	return v.sailsHoisted
+}
+
+// GetHullPoints returns how much punishment the ship's hull can still take
+func (v *VehicleDetails) GetHullPoints() int {
+	return v.hullPoints
+}
+
+// SetHullPoints sets the hull, keeping it between 0 and MaxHullPoints
+func (v *VehicleDetails) SetHullPoints(hullPoints int) {
+	v.hullPoints = max(0, min(hullPoints, MaxHullPoints))
+}
+
+// DamageHull knocks points off the hull, returning true if the ship has been holed and sinks
+func (v *VehicleDetails) DamageHull(points int) bool {
+	v.SetHullPoints(v.hullPoints - points)
+	return v.hullPoints == 0
+}
+
+func getUnfurledFrigateSpriteByDirection(direction references.Direction) indexes.SpriteIndex {
+	switch direction {
+	case references.Left:
+		return indexes.FrigateLeftUnfurled
+	case references.Up:
+		return indexes.FrigateUpUnfurled
+	case references.Down:
+		return indexes.FrigateDownUnfurled
+	default:
+		return indexes.FrigateRightUnfurled
+	}
+}
@@ -0,0 +1,13 @@
+package party_state
+
+// CombatEffects are transient per-character effects that only exist for the duration of a combat.
+// They are not part of the SAVED.GAM character record, so they are tracked alongside it.
+type CombatEffects struct {
+	Invisible        bool
+	PolymorphedToRat bool
+}
+
+// ClearCombatEffects resets all transient combat effects, typically when combat ends
+func (p *PartyState) ClearCombatEffects() {
+	p.CombatEffects = [NPlayers]CombatEffects{}
+}
@@ -1,6 +1,8 @@
 package party_state
 
 import (
+	"reflect"
+
 	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
 )
 
@@ -22,7 +24,10 @@ func NewInventoryQuantities[TK InventoryItemType, TV ItemQuantity]() InventoryQu
 func (iq *InventoryQuantities[TK, TV]) GetQuantity(itemType TK) *TV {
 	itemQuantity, ok := iq.quantities[itemType]
 	if !ok {
+		// TV is always a pointer type (ie. *ItemQuantitySmall), so the value it points to
+		// must be allocated as well - otherwise every method call would dereference nil
 		newItemQuantity := new(TV)
+		*newItemQuantity = reflect.New(reflect.TypeOf(*newItemQuantity).Elem()).Interface().(TV)
 		iq.quantities[itemType] = newItemQuantity
 
 		return newItemQuantity
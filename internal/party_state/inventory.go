@@ -19,6 +19,7 @@ type Inventory struct {
 	Equipment    InventoryQuantities[references.Equipment, *ItemQuantitySmall]
 	Spells       InventoryQuantities[references.Spell, *ItemQuantitySmall]
 	Scrolls      InventoryQuantities[references.Scroll, *ItemQuantityLarge]
+	Potions      InventoryQuantities[references.Potion, *ItemQuantitySmall]
 	SpecialItems InventoryQuantities[references.SpecialItem, *ItemQuantitySmall]
 	QuestItems   InventoryQuantities[references.QuestItem, *ItemQuantitySmall]
 	Shards       InventoryQuantities[references.Shard, *ItemQuantitySmall]
@@ -30,6 +31,7 @@ func NewInventory() *Inventory {
 	inv.Equipment = NewInventoryQuantities[references.Equipment, *ItemQuantitySmall]()
 	inv.Spells = NewInventoryQuantities[references.Spell, *ItemQuantitySmall]()
 	inv.Scrolls = NewInventoryQuantities[references.Scroll, *ItemQuantityLarge]()
+	inv.Potions = NewInventoryQuantities[references.Potion, *ItemQuantitySmall]()
 	inv.SpecialItems = NewInventoryQuantities[references.SpecialItem, *ItemQuantitySmall]()
 	inv.QuestItems = NewInventoryQuantities[references.QuestItem, *ItemQuantitySmall]()
 	inv.Shards = NewInventoryQuantities[references.Shard, *ItemQuantitySmall]()
@@ -14,7 +14,7 @@ func NewKarma(karma byte) Karma {
 }
 
 func (k *Karma) DecreaseKarma(decreaseBy int) {
-	k.AddDiff(decreaseBy)
+	k.AddDiff(-decreaseBy)
 }
 
 func (k *Karma) IncreaseKarma(increaseBy int) {
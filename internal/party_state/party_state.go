@@ -12,8 +12,11 @@ type PartyState struct {
 	Characters [NPlayers]PlayerCharacter
 	Inventory  Inventory
 	Karma      Karma
-	metNpcs    map[references.Location][]bool
-	deadNpcs   map[references.Location][]bool
+
+	CombatEffects [NPlayers]CombatEffects
+
+	metNpcs  map[references.Location][]bool
+	deadNpcs map[references.Location][]bool
 }
 
 func newPartyState() *PartyState {
@@ -102,3 +105,12 @@ func (p *PartyState) MetNpcs() map[references.Location][]bool {
 func (p *PartyState) DeadNpcs() map[references.Location][]bool {
 	return p.deadNpcs
 }
+
+// IsCharacterInParty returns true if the character slot is occupied and currently travelling with the party
+func (p *PartyState) IsCharacterInParty(index int) bool {
+	if index < 0 || index >= NPlayers {
+		return false
+	}
+	c := &p.Characters[index]
+	return c.GetNameAsString() != "" && c.PartyStatus == InTheParty
+}
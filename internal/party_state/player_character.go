@@ -3,6 +3,7 @@ package party_state
 import (
 	"strings"
 
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
 	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
 )
 
@@ -25,3 +26,105 @@ func (p *PlayerCharacter) GetKeySpriteIndex() indexes.SpriteIndex {
 	}
 	return 0
 }
+
+// Heal restores up to amount hit points without exceeding MaxHp.
+// Returns false if the character is dead or already at full health.
+func (p *PlayerCharacter) Heal(amount uint16) bool {
+	if p.Status == Dead || p.CurrentHp >= p.MaxHp {
+		return false
+	}
+	p.CurrentHp = min(p.CurrentHp+amount, p.MaxHp)
+	return true
+}
+
+// Damage takes up to amount hit points, and the character dies when they run out.
+// Returns false if the character is already dead.
+func (p *PlayerCharacter) Damage(amount uint16) bool {
+	if p.Status == Dead {
+		return false
+	}
+	p.CurrentHp -= min(amount, p.CurrentHp)
+	if p.CurrentHp == 0 {
+		p.Status = Dead
+	}
+	return true
+}
+
+// Poison poisons a character in good health.
+// Returns false if the character is dead, asleep or already poisoned.
+func (p *PlayerCharacter) Poison() bool {
+	if p.Status != Good {
+		return false
+	}
+	p.Status = Poisoned
+	return true
+}
+
+// GetMaxMp is derived from intelligence - mages and the Avatar get all of it, bards half and fighters none
+func (p *PlayerCharacter) GetMaxMp() byte {
+	switch p.Class {
+	case Avatar, Wizard:
+		return p.Intelligence
+	case Bard:
+		return p.Intelligence / 2
+	default:
+		return 0
+	}
+}
+
+// IsAtTheInn is true when the character has been left at an inn - their party status is then the
+// location of the inn they are staying at
+func (p *PlayerCharacter) IsAtTheInn() bool {
+	return p.GetNameAsString() != "" && p.PartyStatus != InTheParty && p.PartyStatus != HasntJoinedYet && p.PartyStatus != Killed
+}
+
+// GetInnLocation is the inn the character is staying at
+func (p *PlayerCharacter) GetInnLocation() references.Location {
+	if !p.IsAtTheInn() {
+		return references.EmptyLocation
+	}
+	return references.Location(p.PartyStatus)
+}
+
+// LeaveAtTheInn has the character stay behind at the inn in the given location for the months paid for
+func (p *PlayerCharacter) LeaveAtTheInn(location references.Location, months byte) {
+	p.PartyStatus = PartyStatus(location)
+	p.MonthsAtInn += months
+}
+
+// ReturnFromTheInn has the character rejoin the party
+func (p *PlayerCharacter) ReturnFromTheInn() {
+	p.PartyStatus = InTheParty
+	p.MonthsAtInn = 0
+}
+
+// Rest restores the hit points and magic points of a living character
+func (p *PlayerCharacter) Rest() bool {
+	if p.Status == Dead {
+		return false
+	}
+	p.CurrentHp = p.MaxHp
+	p.CurrentMp = p.GetMaxMp()
+	return true
+}
+
+// Cure rids a character of poison.
+// Returns false if the character isn't poisoned.
+func (p *PlayerCharacter) Cure() bool {
+	if p.Status != Poisoned {
+		return false
+	}
+	p.Status = Good
+	return true
+}
+
+// Resurrect brings a dead character back with the given hit points.
+// Returns false if the character isn't dead.
+func (p *PlayerCharacter) Resurrect(hitPoints uint16) bool {
+	if p.Status != Dead {
+		return false
+	}
+	p.Status = Good
+	p.CurrentHp = hitPoints
+	return true
+}
@@ -0,0 +1,108 @@
+package party_state
+
+import (
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+)
+
+// EquipmentSlot is one of the places on a character that a piece of equipment can be readied
+type EquipmentSlot int
+
+const (
+	HelmetSlot EquipmentSlot = iota
+	ArmourSlot
+	LeftHandSlot  // stored in PlayerCharacter.Weapon
+	RightHandSlot // stored in PlayerCharacter.Shield
+	RingSlot
+	AmuletSlot
+)
+
+var AllEquipmentSlots = []EquipmentSlot{HelmetSlot, ArmourSlot, LeftHandSlot, RightHandSlot, RingSlot, AmuletSlot}
+
+func (p *PlayerCharacter) getSlotByte(slot EquipmentSlot) *byte {
+	switch slot {
+	case HelmetSlot:
+		return &p.Helmet
+	case ArmourSlot:
+		return &p.Armor
+	case LeftHandSlot:
+		return &p.Weapon
+	case RightHandSlot:
+		return &p.Shield
+	case RingSlot:
+		return &p.Ring
+	case AmuletSlot:
+		return &p.Amulet
+	}
+	panic("unknown equipment slot")
+}
+
+// GetEquipped returns the equipment readied in the slot, or NoEquipment if it is empty
+func (p *PlayerCharacter) GetEquipped(slot EquipmentSlot) references.Equipment {
+	return references.Equipment(*p.getSlotByte(slot))
+}
+
+// SetEquipped readies equipment in the slot - use NoEquipment to empty it
+func (p *PlayerCharacter) SetEquipped(slot EquipmentSlot, equipment references.Equipment) {
+	*p.getSlotByte(slot) = byte(equipment)
+}
+
+// GetSlotOfEquipped returns the slot the equipment is readied in, if it is readied at all
+func (p *PlayerCharacter) GetSlotOfEquipped(equipment references.Equipment) (EquipmentSlot, bool) {
+	for _, slot := range AllEquipmentSlots {
+		if p.GetEquipped(slot) == equipment {
+			return slot, true
+		}
+	}
+	return 0, false
+}
+
+// GetFreeHands returns the number of empty hands. A two-handed weapon occupies both hands.
+func (p *PlayerCharacter) GetFreeHands() int {
+	nFree := 0
+	for _, slot := range []EquipmentSlot{LeftHandSlot, RightHandSlot} {
+		equipment := p.GetEquipped(slot)
+		if equipment == references.NoEquipment {
+			nFree++
+			continue
+		}
+		if equipment.GetDetails().Usage == references.TwoHandUsage {
+			return 0
+		}
+	}
+	return nFree
+}
+
+// GetTotalRequiredStrength is the sum of the strength requirements of everything readied
+func (p *PlayerCharacter) GetTotalRequiredStrength() int {
+	total := 0
+	for _, slot := range AllEquipmentSlots {
+		total += p.GetEquipped(slot).GetDetails().RequiredStrength
+	}
+	return total
+}
+
+// GetAttackValue is derived from the readied equipment, falling back to bare hands when unarmed
+func (p *PlayerCharacter) GetAttackValue() int {
+	attack := 0
+	bArmed := false
+	for _, slot := range AllEquipmentSlots {
+		details := p.GetEquipped(slot).GetDetails()
+		attack += details.AttackValue
+		if (slot == LeftHandSlot || slot == RightHandSlot) && details.AttackValue > 0 {
+			bArmed = true
+		}
+	}
+	if !bArmed {
+		attack += references.BareHands.GetDetails().AttackValue
+	}
+	return attack
+}
+
+// GetDefenceValue is derived from all readied equipment
+func (p *PlayerCharacter) GetDefenceValue() int {
+	defence := 0
+	for _, slot := range AllEquipmentSlots {
+		defence += p.GetEquipped(slot).GetDetails().DefenceValue
+	}
+	return defence
+}
@@ -8,6 +8,7 @@ const (
 	InTheParty     PartyStatus = 0x00
 	HasntJoinedYet PartyStatus = 0xFF
 	AtTheInn       PartyStatus = 0x01
+	Killed         PartyStatus = 0x7F
 )
 
 type PlayerCharacter struct {
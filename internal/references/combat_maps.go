@@ -0,0 +1,158 @@
+package references
+
+import (
+	"fmt"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+)
+
+// Combat maps (the rooms in DUNGEON.CBT, and the arenas in BRIT.CBT) share one layout - eleven rows
+// of 32 bytes, the first 11 bytes of each row being the map itself and the rest describing where
+// everybody starts and what the triggers change.
+// See docs/010EditorTemplates/dungeon_cbt.bt.
+
+const (
+	XCombatMapTiles = Coordinate(11)
+	YCombatMapTiles = Coordinate(11)
+
+	MaxCombatMapPartyMembers = 6
+	MaxCombatMapMonsters     = 16
+	MaxCombatMapTriggers     = 8
+
+	combatMapRowSizeInBytes = 32
+	combatMapSizeInBytes    = combatMapRowSizeInBytes * int(YCombatMapTiles)
+
+	// monsters are stored as a byte and live in the second half of the tile set
+	combatMapMonsterTileOffset = 0x100
+)
+
+// CombatMapEntryDirection is the side of the map the party comes in from - each has its own set of
+// starting positions
+type CombatMapEntryDirection int
+
+const (
+	EastCombatMapEntry CombatMapEntryDirection = iota
+	WestCombatMapEntry
+	SouthCombatMapEntry
+	NorthCombatMapEntry
+	nCombatMapEntryDirections
+)
+
+// GetCombatMapEntryDirection is the side of the map the party comes in from when travelling in the
+// given direction - heading north they come in from the south
+func GetCombatMapEntryDirection(travelling Direction) CombatMapEntryDirection {
+	switch travelling {
+	case Up:
+		return SouthCombatMapEntry
+	case Down:
+		return NorthCombatMapEntry
+	case Left:
+		return EastCombatMapEntry
+	default:
+		return WestCombatMapEntry
+	}
+}
+
+type CombatMapMonster struct {
+	Tile     indexes.SpriteIndex
+	Position Position
+}
+
+// CombatMapTrigger swaps both of its changed positions to NewTile when something steps on Position
+type CombatMapTrigger struct {
+	Position         Position
+	NewTile          indexes.SpriteIndex
+	ChangedPositions [2]Position
+}
+
+type CombatMapReference struct {
+	Tiles          [YCombatMapTiles][XCombatMapTiles]indexes.SpriteIndex
+	PartyPositions [nCombatMapEntryDirections][MaxCombatMapPartyMembers]Position
+	// Monsters and Triggers are only the slots in use
+	Monsters []CombatMapMonster
+	Triggers []CombatMapTrigger
+}
+
+func (c *CombatMapReference) GetTile(position Position) indexes.SpriteIndex {
+	if !c.IsInBounds(position) {
+		return indexes.NoSprites
+	}
+	return c.Tiles[position.Y][position.X]
+}
+
+func (c *CombatMapReference) IsInBounds(position Position) bool {
+	return position.X >= 0 && position.X < XCombatMapTiles && position.Y >= 0 && position.Y < YCombatMapTiles
+}
+
+// GetPartyPosition is where the given party member starts when coming in from the given side
+func (c *CombatMapReference) GetPartyPosition(entry CombatMapEntryDirection, nPartyMember int) Position {
+	return c.PartyPositions[entry][nPartyMember]
+}
+
+// NewCombatMapReferencesFromBytes reads every map out of the raw contents of a .CBT file
+func NewCombatMapReferencesFromBytes(rawData []byte) ([]*CombatMapReference, error) {
+	if len(rawData)%combatMapSizeInBytes != 0 {
+		return nil, fmt.Errorf("combat maps are %d bytes, expected a multiple of %d", len(rawData), combatMapSizeInBytes)
+	}
+
+	combatMaps := make([]*CombatMapReference, 0, len(rawData)/combatMapSizeInBytes)
+	for offset := 0; offset < len(rawData); offset += combatMapSizeInBytes {
+		combatMaps = append(combatMaps, newCombatMapReferenceFromBytes(rawData[offset:offset+combatMapSizeInBytes]))
+	}
+	return combatMaps, nil
+}
+
+func newCombatMapReferenceFromBytes(rawData []byte) *CombatMapReference {
+	row := func(nRow int) []byte {
+		return rawData[nRow*combatMapRowSizeInBytes : (nRow+1)*combatMapRowSizeInBytes]
+	}
+	// everything after the map in a row
+	details := func(nRow int) []byte {
+		return row(nRow)[XCombatMapTiles:]
+	}
+
+	combatMap := &CombatMapReference{}
+	for y := Coordinate(0); y < YCombatMapTiles; y++ {
+		for x := Coordinate(0); x < XCombatMapTiles; x++ {
+			combatMap.Tiles[y][x] = indexes.SpriteIndex(row(int(y))[x])
+		}
+	}
+
+	for entry := range combatMap.PartyPositions {
+		positions := details(1 + entry)
+		for nPartyMember := range MaxCombatMapPartyMembers {
+			combatMap.PartyPositions[entry][nPartyMember] = Position{
+				X: Coordinate(positions[nPartyMember]),
+				Y: Coordinate(positions[MaxCombatMapPartyMembers+nPartyMember]),
+			}
+		}
+	}
+
+	monsterTiles, monsterXs, monsterYs := details(5), details(6), details(7)
+	for nMonster := range MaxCombatMapMonsters {
+		if monsterTiles[nMonster] == 0 {
+			continue
+		}
+		combatMap.Monsters = append(combatMap.Monsters, CombatMapMonster{
+			Tile:     indexes.SpriteIndex(int(monsterTiles[nMonster]) + combatMapMonsterTileOffset),
+			Position: Position{X: Coordinate(monsterXs[nMonster]), Y: Coordinate(monsterYs[nMonster])},
+		})
+	}
+
+	newTiles, triggerPositions, firstChanged, secondChanged := details(0), details(8), details(9), details(10)
+	for nTrigger := range MaxCombatMapTriggers {
+		if newTiles[nTrigger] == 0 {
+			continue
+		}
+		positionAt := func(positions []byte) Position {
+			return Position{X: Coordinate(positions[nTrigger]), Y: Coordinate(positions[MaxCombatMapTriggers+nTrigger])}
+		}
+		combatMap.Triggers = append(combatMap.Triggers, CombatMapTrigger{
+			Position:         positionAt(triggerPositions),
+			NewTile:          indexes.SpriteIndex(newTiles[nTrigger]),
+			ChangedPositions: [2]Position{positionAt(firstChanged), positionAt(secondChanged)},
+		})
+	}
+
+	return combatMap
+}
@@ -0,0 +1,51 @@
+package references
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCombatMapReference_ReadsTheRowLayout(t *testing.T) {
+	rawData := make([]byte, combatMapSizeInBytes)
+	at := func(nRow, nColumn int) *byte {
+		return &rawData[nRow*combatMapRowSizeInBytes+nColumn]
+	}
+
+	*at(10, 10) = 0x4d
+	// the second party member coming in from the south starts at (4, 9)
+	*at(1+int(SouthCombatMapEntry), 11+1) = 4
+	*at(1+int(SouthCombatMapEntry), 11+6+1) = 9
+	// one monster in the third slot at (5, 2)
+	*at(5, 11+2) = 0x90
+	*at(6, 11+2) = 5
+	*at(7, 11+2) = 2
+	// the first trigger at (5, 5) turns (1, 3) and (9, 3) into floor
+	*at(0, 11) = 0x44
+	*at(8, 11), *at(8, 11+8) = 5, 5
+	*at(9, 11), *at(9, 11+8) = 1, 3
+	*at(10, 11), *at(10, 11+8) = 9, 3
+
+	combatMaps, err := NewCombatMapReferencesFromBytes(rawData)
+	assert.NoError(t, err)
+	assert.Len(t, combatMaps, 1)
+	combatMap := combatMaps[0]
+
+	assert.Equal(t, indexes.SpriteIndex(0x4d), combatMap.GetTile(Position{X: 10, Y: 10}))
+	assert.Equal(t, indexes.NoSprites, combatMap.GetTile(Position{X: 11, Y: 0}))
+	assert.Equal(t, Position{X: 4, Y: 9}, combatMap.GetPartyPosition(SouthCombatMapEntry, 1))
+
+	assert.Equal(t, []CombatMapMonster{{Tile: 0x190, Position: Position{X: 5, Y: 2}}}, combatMap.Monsters)
+
+	assert.Len(t, combatMap.Triggers, 1)
+	trigger := combatMap.Triggers[0]
+	assert.Equal(t, Position{X: 5, Y: 5}, trigger.Position)
+	assert.Equal(t, indexes.SpriteIndex(0x44), trigger.NewTile)
+	assert.Equal(t, [2]Position{{X: 1, Y: 3}, {X: 9, Y: 3}}, trigger.ChangedPositions)
+}
+
+func TestCombatMapEntryDirection_ComesInFromBehind(t *testing.T) {
+	assert.Equal(t, SouthCombatMapEntry, GetCombatMapEntryDirection(Up))
+	assert.Equal(t, EastCombatMapEntry, GetCombatMapEntryDirection(Left))
+}
@@ -0,0 +1,262 @@
+package references
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/config"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/files"
+)
+
+// DUNGEON.DAT holds the eight dungeons one after another, each eight levels deep. A level is an 8x8
+// grid, stored row by row, of one byte per tile - the high nibble is what the tile is and the low
+// nibble is a detail that depends on it (which trap, which fountain, which room...).
+// See docs/010EditorTemplates/dungeon_dat.bt
+
+const (
+	XDungeonTiles = Coordinate(8)
+	YDungeonTiles = Coordinate(8)
+	// DungeonLevels is how many levels deep every dungeon goes
+	DungeonLevels = 8
+	TotalDungeons = 8
+	// DungeonRoomsPerDungeon is the share of DUNGEON.CBT's 112 rooms each of the first seven dungeons gets
+	DungeonRoomsPerDungeon = 16
+
+	dungeonLevelSizeInBytes = int(XDungeonTiles * YDungeonTiles)
+	dungeonSizeInBytes      = dungeonLevelSizeInBytes * DungeonLevels
+)
+
+type DungeonTileType byte
+
+const (
+	DungeonNothing      DungeonTileType = 0x0
+	DungeonLadderUp     DungeonTileType = 0x1
+	DungeonLadderDown   DungeonTileType = 0x2
+	DungeonLadderUpDown DungeonTileType = 0x3
+	DungeonChest        DungeonTileType = 0x4
+	DungeonFountain     DungeonTileType = 0x5
+	DungeonTrap         DungeonTileType = 0x6
+	DungeonOpenChest    DungeonTileType = 0x7
+	DungeonMagicField   DungeonTileType = 0x8
+	DungeonRoomsBroke   DungeonTileType = 0xA
+	DungeonWall         DungeonTileType = 0xB
+	// DungeonSecondaryWall is a wall drawn differently - it can't be walked through either
+	DungeonSecondaryWall DungeonTileType = 0xC
+	DungeonSecretDoor    DungeonTileType = 0xD
+	DungeonDoor          DungeonTileType = 0xE
+	// DungeonRoom marks the way into one of the rooms in DUNGEON.CBT
+	DungeonRoom DungeonTileType = 0xF
+)
+
+type DungeonFountainType byte
+
+const (
+	CurePoisonDungeonFountain DungeonFountainType = 0
+	HealDungeonFountain       DungeonFountainType = 1
+	PoisonDungeonFountain     DungeonFountainType = 2
+	BadTasteDungeonFountain   DungeonFountainType = 3
+)
+
+type DungeonFieldType byte
+
+const (
+	PoisonDungeonField DungeonFieldType = 0
+	SleepDungeonField  DungeonFieldType = 1
+	FireDungeonField   DungeonFieldType = 2
+	EnergyDungeonField DungeonFieldType = 3
+)
+
+type DungeonTrapType byte
+
+const (
+	LowerVisibleDungeonTrap DungeonTrapType = 0
+	BombDungeonTrap         DungeonTrapType = 1
+	InvisibleDungeonTrap    DungeonTrapType = 2
+	// UpperVisibleDungeonTrap hangs from the ceiling rather than sitting in the floor
+	UpperVisibleDungeonTrap DungeonTrapType = 8
+)
+
+const (
+	dungeonTrappedLadder = 0x8
+	// a chest's detail is a set of flags for how it is trapped
+	DungeonChestTrapped1 = 0x1
+	DungeonChestTrapped2 = 0x2
+	DungeonChestPoisoned = 0x4
+)
+
+// DungeonTile is a single raw tile of a dungeon level
+type DungeonTile byte
+
+func NewDungeonTile(tileType DungeonTileType, detail byte) DungeonTile {
+	return DungeonTile(byte(tileType)<<4 | detail&0x0F)
+}
+
+func (t DungeonTile) Type() DungeonTileType {
+	return DungeonTileType(t >> 4)
+}
+
+// Detail is the low nibble - what it means depends on the type of tile
+func (t DungeonTile) Detail() byte {
+	return byte(t) & 0x0F
+}
+
+// IsWall is true for anything that looks like a wall, including secret doors that haven't been found
+func (t DungeonTile) IsWall() bool {
+	switch t.Type() {
+	case DungeonWall, DungeonSecondaryWall, DungeonSecretDoor:
+		return true
+	}
+	return false
+}
+
+func (t DungeonTile) IsPassable() bool {
+	return !t.IsWall()
+}
+
+func (t DungeonTile) IsLadderUp() bool {
+	return t.Type() == DungeonLadderUp || t.Type() == DungeonLadderUpDown
+}
+
+func (t DungeonTile) IsLadderDown() bool {
+	return t.Type() == DungeonLadderDown || t.Type() == DungeonLadderUpDown
+}
+
+func (t DungeonTile) IsTrappedLadder() bool {
+	return (t.IsLadderUp() || t.IsLadderDown()) && t.Detail()&dungeonTrappedLadder != 0
+}
+
+func (t DungeonTile) IsChest() bool {
+	return t.Type() == DungeonChest
+}
+
+func (t DungeonTile) GetFountainType() DungeonFountainType {
+	return DungeonFountainType(t.Detail())
+}
+
+func (t DungeonTile) GetFieldType() DungeonFieldType {
+	return DungeonFieldType(t.Detail() & 0x3)
+}
+
+func (t DungeonTile) GetTrapType() DungeonTrapType {
+	return DungeonTrapType(t.Detail())
+}
+
+// GetRoomNumber is which of the dungeon's rooms a room marker leads into
+func (t DungeonTile) GetRoomNumber() int {
+	return int(t.Detail())
+}
+
+// DungeonLevel is a single 8x8 level, by row then column. Levels wrap around at their edges.
+type DungeonLevel [YDungeonTiles][XDungeonTiles]DungeonTile
+
+func (l *DungeonLevel) GetTile(position Position) DungeonTile {
+	wrapped := position.GetWrapped(XDungeonTiles, YDungeonTiles)
+	return l[wrapped.Y][wrapped.X]
+}
+
+func (l *DungeonLevel) SetTile(position Position, tile DungeonTile) {
+	wrapped := position.GetWrapped(XDungeonTiles, YDungeonTiles)
+	l[wrapped.Y][wrapped.X] = tile
+}
+
+// FindTile is the first position on the level with a tile of the given type
+func (l *DungeonLevel) FindTile(tileType DungeonTileType) (Position, bool) {
+	for y := Coordinate(0); y < YDungeonTiles; y++ {
+		for x := Coordinate(0); x < XDungeonTiles; x++ {
+			if l[y][x].Type() == tileType {
+				return Position{X: x, Y: y}, true
+			}
+		}
+	}
+	return Position{}, false
+}
+
+type DungeonReference struct {
+	Location Location
+	Levels   [DungeonLevels]DungeonLevel
+	// Rooms are the combat maps behind the level's room tiles, by room number
+	Rooms []*CombatMapReference
+}
+
+// GetRoom is the room a room tile leads to, nil if the dungeon has no such room
+func (d *DungeonReference) GetRoom(nRoom int) *CombatMapReference {
+	if nRoom < 0 || nRoom >= len(d.Rooms) {
+		return nil
+	}
+	return d.Rooms[nRoom]
+}
+
+type DungeonReferences struct {
+	dungeons map[Location]*DungeonReference
+}
+
+// GetListOfAllDungeons is every dungeon, in the order they are stored in DUNGEON.DAT
+func GetListOfAllDungeons() []Location {
+	return []Location{Deceit, Despise, Destard, Wrong, Covetous, Shame, Hythloth, Doom}
+}
+
+func NewDungeonReferences(gameConfig *config.UltimaVConfiguration) (*DungeonReferences, error) {
+	rawData, err := os.ReadFile(path.Join(gameConfig.SavedConfigData.DataFilePath, files.DUNGEON_DAT))
+	if err != nil {
+		return nil, err
+	}
+	dungeonRefs, err := NewDungeonReferencesFromBytes(rawData)
+	if err != nil {
+		return nil, err
+	}
+
+	rawRoomData, err := os.ReadFile(path.Join(gameConfig.SavedConfigData.DataFilePath, files.DUNGEON_CBT))
+	if err != nil {
+		return nil, err
+	}
+	if err := dungeonRefs.AddRoomsFromBytes(rawRoomData); err != nil {
+		return nil, err
+	}
+	return dungeonRefs, nil
+}
+
+// NewDungeonReferencesFromBytes reads the dungeons out of the raw contents of DUNGEON.DAT
+func NewDungeonReferencesFromBytes(rawData []byte) (*DungeonReferences, error) {
+	if len(rawData) < dungeonSizeInBytes*TotalDungeons {
+		return nil, fmt.Errorf("%s is %d bytes, expected %d", files.DUNGEON_DAT, len(rawData), dungeonSizeInBytes*TotalDungeons)
+	}
+
+	dungeonRefs := &DungeonReferences{dungeons: make(map[Location]*DungeonReference)}
+	for nDungeon, location := range GetListOfAllDungeons() {
+		dungeon := &DungeonReference{Location: location}
+		offset := nDungeon * dungeonSizeInBytes
+		for nLevel := range dungeon.Levels {
+			for y := Coordinate(0); y < YDungeonTiles; y++ {
+				for x := Coordinate(0); x < XDungeonTiles; x++ {
+					dungeon.Levels[nLevel][y][x] = DungeonTile(rawData[offset])
+					offset++
+				}
+			}
+		}
+		dungeonRefs.dungeons[location] = dungeon
+	}
+	return dungeonRefs, nil
+}
+
+func (d *DungeonReferences) GetDungeon(location Location) *DungeonReference {
+	return d.dungeons[location]
+}
+
+// AddRoomsFromBytes hands out the rooms in the raw contents of DUNGEON.CBT - each dungeon, in order,
+// gets DungeonRoomsPerDungeon of them until they run out, which leaves Doom without any
+func (d *DungeonReferences) AddRoomsFromBytes(rawData []byte) error {
+	rooms, err := NewCombatMapReferencesFromBytes(rawData)
+	if err != nil {
+		return fmt.Errorf("%s: %w", files.DUNGEON_CBT, err)
+	}
+
+	for nDungeon, location := range GetListOfAllDungeons() {
+		first := nDungeon * DungeonRoomsPerDungeon
+		if first >= len(rooms) {
+			break
+		}
+		d.dungeons[location].Rooms = rooms[first:min(first+DungeonRoomsPerDungeon, len(rooms))]
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package references
+
+import (
+	"testing"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites/indexes"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDungeonBytes() []byte {
+	rawData := make([]byte, dungeonSizeInBytes*TotalDungeons)
+	// Deceit, level 0, row 0 - a ladder up in the corner
+	rawData[0] = byte(NewDungeonTile(DungeonLadderUp, 0))
+	// Deceit, level 0, row 1, column 2 - a poisoned chest
+	rawData[int(XDungeonTiles)+2] = byte(NewDungeonTile(DungeonChest, DungeonChestPoisoned))
+	// Deceit, level 1 - a trapped ladder down
+	rawData[dungeonLevelSizeInBytes+5] = byte(NewDungeonTile(DungeonLadderDown, dungeonTrappedLadder))
+	// Doom, last level, last tile - a wall
+	rawData[len(rawData)-1] = byte(NewDungeonTile(DungeonWall, 0))
+	return rawData
+}
+
+func Test_DungeonReferences_ReadsEveryDungeonInOrder(t *testing.T) {
+	dungeonRefs, err := NewDungeonReferencesFromBytes(newTestDungeonBytes())
+	assert.NoError(t, err)
+
+	deceit := dungeonRefs.GetDungeon(Deceit)
+	assert.True(t, deceit.Levels[0].GetTile(Position{X: 0, Y: 0}).IsLadderUp())
+
+	chest := deceit.Levels[0].GetTile(Position{X: 2, Y: 1})
+	assert.True(t, chest.IsChest())
+	assert.Equal(t, byte(DungeonChestPoisoned), chest.Detail())
+
+	ladder := deceit.Levels[1].GetTile(Position{X: 5, Y: 0})
+	assert.True(t, ladder.IsLadderDown())
+	assert.True(t, ladder.IsTrappedLadder())
+
+	doom := dungeonRefs.GetDungeon(Doom)
+	assert.True(t, doom.Levels[DungeonLevels-1].GetTile(Position{X: 7, Y: 7}).IsWall())
+	assert.Nil(t, dungeonRefs.GetDungeon(Britain))
+}
+
+func Test_DungeonLevel_WrapsAtItsEdges(t *testing.T) {
+	dungeonRefs, _ := NewDungeonReferencesFromBytes(newTestDungeonBytes())
+	level := &dungeonRefs.GetDungeon(Doom).Levels[DungeonLevels-1]
+
+	assert.True(t, level.GetTile(Position{X: -1, Y: -1}).IsWall())
+	level.SetTile(Position{X: 8, Y: 8}, NewDungeonTile(DungeonDoor, 0))
+	assert.Equal(t, DungeonDoor, level.GetTile(Position{X: 0, Y: 0}).Type())
+
+	position, ok := level.FindTile(DungeonWall)
+	assert.True(t, ok)
+	assert.Equal(t, Position{X: 7, Y: 7}, position)
+
+	_, ok = level.FindTile(DungeonFountain)
+	assert.False(t, ok)
+}
+
+func Test_DungeonTile_SecretDoorsLookLikeWalls(t *testing.T) {
+	assert.True(t, NewDungeonTile(DungeonSecretDoor, 0).IsWall())
+	assert.True(t, NewDungeonTile(DungeonDoor, 0).IsPassable())
+	assert.Equal(t, SleepDungeonField, NewDungeonTile(DungeonMagicField, 1).GetFieldType())
+}
+
+func Test_DungeonReferences_TooShort(t *testing.T) {
+	_, err := NewDungeonReferencesFromBytes(make([]byte, dungeonSizeInBytes))
+	assert.Error(t, err)
+}
+
+func Test_DungeonReferences_RoomsAreHandedOutSixteenToADungeon(t *testing.T) {
+	dungeonRefs, _ := NewDungeonReferencesFromBytes(newTestDungeonBytes())
+
+	rawRoomData := make([]byte, combatMapSizeInBytes*DungeonRoomsPerDungeon*7)
+	// Despise's second room has a wall in its top left corner
+	rawRoomData[combatMapSizeInBytes*(DungeonRoomsPerDungeon+1)] = 0x4f
+	assert.NoError(t, dungeonRefs.AddRoomsFromBytes(rawRoomData))
+
+	assert.Len(t, dungeonRefs.GetDungeon(Deceit).Rooms, DungeonRoomsPerDungeon)
+	assert.Equal(t, indexes.SpriteIndex(0x4f), dungeonRefs.GetDungeon(Despise).GetRoom(1).GetTile(Position{}))
+	assert.Nil(t, dungeonRefs.GetDungeon(Doom).GetRoom(0))
+	assert.Nil(t, dungeonRefs.GetDungeon(Deceit).GetRoom(DungeonRoomsPerDungeon))
+
+	assert.Error(t, dungeonRefs.AddRoomsFromBytes(make([]byte, combatMapSizeInBytes-1)))
+}
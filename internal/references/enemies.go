@@ -53,3 +53,12 @@ func NewAllEnemyReferences(gameConfig *config.UltimaVConfiguration,
 
 	return &enemyRefs
 }
+
+// GetEnemyReferenceByKeyFrameTile is the enemy drawn with the given key frame, nil if it isn't one
+func (e *EnemyReferences) GetEnemyReferenceByKeyFrameTile(index indexes.SpriteIndex) *EnemyReference {
+	nEnemy := (int(index) - nFirstEnemyTileReferenceIndex) / nFramesPerEnemy
+	if int(index) < nFirstEnemyTileReferenceIndex || nEnemy >= len(*e) {
+		return nil
+	}
+	return &(*e)[nEnemy]
+}
@@ -0,0 +1,112 @@
+package references
+
+// EquipmentUsage is where on the body a piece of equipment is readied (legacy weapon_usage)
+type EquipmentUsage int
+
+const (
+	NoUsage EquipmentUsage = iota
+	HeadUsage
+	BodyUsage
+	OneHandUsage
+	TwoHandUsage
+	RingUsage
+	NeckUsage
+	AmmoUsage
+)
+
+// EquipmentDetails are the combat and readying characteristics of a piece of equipment
+type EquipmentDetails struct {
+	Usage            EquipmentUsage
+	AttackValue      int
+	DefenceValue     int
+	RequiredStrength int
+	// Ammo is the equipment consumed when attacking with a ranged weapon, NoEquipment if none is required
+	Ammo Equipment
+}
+
+// equipmentDetails are the arms and armour characteristics
+// TODO: TBD placeholder values - the original's are in DATA.OVL, but their offsets are not documented
+// yet, so none of these are sourced
+var equipmentDetails = map[Equipment]EquipmentDetails{
+	BareHands: {Usage: NoUsage, AttackValue: 1, Ammo: NoEquipment},
+
+	LeatherHelm: {Usage: HeadUsage, DefenceValue: 1, Ammo: NoEquipment},
+	ChainCoif:   {Usage: HeadUsage, DefenceValue: 2, RequiredStrength: 4, Ammo: NoEquipment},
+	IronHelm:    {Usage: HeadUsage, DefenceValue: 3, RequiredStrength: 8, Ammo: NoEquipment},
+	SpikedHelm:  {Usage: HeadUsage, AttackValue: 2, DefenceValue: 3, RequiredStrength: 10, Ammo: NoEquipment},
+
+	SmallShield:  {Usage: OneHandUsage, DefenceValue: 1, Ammo: NoEquipment},
+	LargeShield:  {Usage: OneHandUsage, DefenceValue: 2, RequiredStrength: 6, Ammo: NoEquipment},
+	SpikedShield: {Usage: OneHandUsage, AttackValue: 2, DefenceValue: 2, RequiredStrength: 8, Ammo: NoEquipment},
+	MagicShield:  {Usage: OneHandUsage, DefenceValue: 3, Ammo: NoEquipment},
+	JewelShield:  {Usage: OneHandUsage, DefenceValue: 4, Ammo: NoEquipment},
+
+	ClothArmour:   {Usage: BodyUsage, DefenceValue: 1, Ammo: NoEquipment},
+	LeatherArmour: {Usage: BodyUsage, DefenceValue: 2, RequiredStrength: 4, Ammo: NoEquipment},
+	RingMail:      {Usage: BodyUsage, DefenceValue: 3, RequiredStrength: 8, Ammo: NoEquipment},
+	ScaleMail:     {Usage: BodyUsage, DefenceValue: 4, RequiredStrength: 12, Ammo: NoEquipment},
+	ChainMail:     {Usage: BodyUsage, DefenceValue: 5, RequiredStrength: 14, Ammo: NoEquipment},
+	PlateMail:     {Usage: BodyUsage, DefenceValue: 7, RequiredStrength: 20, Ammo: NoEquipment},
+	MysticArmour:  {Usage: BodyUsage, DefenceValue: 5, Ammo: NoEquipment},
+
+	Dagger:       {Usage: OneHandUsage, AttackValue: 1, Ammo: NoEquipment},
+	Sling:        {Usage: OneHandUsage, AttackValue: 2, Ammo: NoEquipment},
+	Club:         {Usage: OneHandUsage, AttackValue: 3, Ammo: NoEquipment},
+	FlamingOil:   {Usage: OneHandUsage, AttackValue: 4, Ammo: NoEquipment},
+	MainGauche:   {Usage: OneHandUsage, AttackValue: 4, DefenceValue: 1, Ammo: NoEquipment},
+	Spear:        {Usage: OneHandUsage, AttackValue: 4, RequiredStrength: 10, Ammo: NoEquipment},
+	ThrowingAxe:  {Usage: OneHandUsage, AttackValue: 3, RequiredStrength: 10, Ammo: NoEquipment},
+	ShortSword:   {Usage: OneHandUsage, AttackValue: 6, RequiredStrength: 12, Ammo: NoEquipment},
+	Mace:         {Usage: OneHandUsage, AttackValue: 6, RequiredStrength: 14, Ammo: NoEquipment},
+	MorningStar:  {Usage: OneHandUsage, AttackValue: 8, RequiredStrength: 16, Ammo: NoEquipment},
+	Bow:          {Usage: TwoHandUsage, AttackValue: 6, RequiredStrength: 12, Ammo: Arrows},
+	Arrows:       {Usage: AmmoUsage, Ammo: NoEquipment},
+	Crossbow:     {Usage: TwoHandUsage, AttackValue: 8, RequiredStrength: 18, Ammo: Quarrels},
+	Quarrels:     {Usage: AmmoUsage, Ammo: NoEquipment},
+	LongSword:    {Usage: OneHandUsage, AttackValue: 10, RequiredStrength: 16, Ammo: NoEquipment},
+	TwoHHammer:   {Usage: TwoHandUsage, AttackValue: 12, RequiredStrength: 18, Ammo: NoEquipment},
+	TwoHAxe:      {Usage: TwoHandUsage, AttackValue: 14, RequiredStrength: 20, Ammo: NoEquipment},
+	TwoHSword:    {Usage: TwoHandUsage, AttackValue: 16, RequiredStrength: 22, Ammo: NoEquipment},
+	Halberd:      {Usage: TwoHandUsage, AttackValue: 18, RequiredStrength: 24, Ammo: NoEquipment},
+	ChaosSword:   {Usage: OneHandUsage, AttackValue: 15, Ammo: NoEquipment},
+	MagicBow:     {Usage: TwoHandUsage, AttackValue: 10, RequiredStrength: 14, Ammo: Arrows},
+	SilverSword:  {Usage: OneHandUsage, AttackValue: 16, RequiredStrength: 18, Ammo: NoEquipment},
+	MagicAxe:     {Usage: OneHandUsage, AttackValue: 16, RequiredStrength: 20, Ammo: NoEquipment},
+	GlassSword:   {Usage: OneHandUsage, AttackValue: 99, Ammo: NoEquipment},
+	JeweledSword: {Usage: OneHandUsage, AttackValue: 18, RequiredStrength: 18, Ammo: NoEquipment},
+	MysticSword:  {Usage: OneHandUsage, AttackValue: 20, Ammo: NoEquipment},
+
+	RingInvisibility: {Usage: RingUsage, Ammo: NoEquipment},
+	RingProtection:   {Usage: RingUsage, DefenceValue: 2, Ammo: NoEquipment},
+	RingRegeneration: {Usage: RingUsage, Ammo: NoEquipment},
+	AmuletOfTurning:  {Usage: NeckUsage, DefenceValue: 2, Ammo: NoEquipment},
+	SpikedCollar:     {Usage: NeckUsage, DefenceValue: 2, Ammo: NoEquipment},
+	Ankh:             {Usage: NeckUsage, DefenceValue: 2, Ammo: NoEquipment},
+}
+
+// GetDetails returns the readying and combat details of the equipment.
+// Unknown equipment (including NoEquipment) has no usage and no values.
+func (e Equipment) GetDetails() EquipmentDetails {
+	details, ok := equipmentDetails[e]
+	if !ok {
+		return EquipmentDetails{Usage: NoUsage, Ammo: NoEquipment}
+	}
+	return details
+}
+
+// IsArmour returns true for equipment that cannot be changed in the heat of battle
+func (e Equipment) IsArmour() bool {
+	switch e.GetDetails().Usage {
+	case HeadUsage, BodyUsage:
+		return true
+	case OneHandUsage:
+		return e >= SmallShield && e <= JewelShield
+	default:
+		return false
+	}
+}
+
+// RequiresAmmo returns true for ranged weapons that consume arrows or quarrels
+func (e Equipment) RequiresAmmo() bool {
+	return e.GetDetails().Ammo != NoEquipment
+}
@@ -0,0 +1,30 @@
+package references
+
+// JailConfig is a towne's jail cell - where the party is locked up and the door that keeps them there.
+// See Towns.md Jail Flow.
+type JailConfig struct {
+	Location Location    `json:"location" yaml:"location"`
+	Floor    FloorNumber `json:"floor" yaml:"floor"`
+	Cell     Position    `json:"cell" yaml:"cell"`
+	Door     Position    `json:"door" yaml:"door"`
+}
+
+type JailReferences struct {
+	Jails []JailConfig `json:"jails" yaml:"jails"`
+}
+
+// NewJailReferences lists the jail cell of every towne that has one
+// TODO: the cell and door positions still need to be taken from the original maps - until then no
+// towne has a jail and nobody is ever locked up
+func NewJailReferences() *JailReferences {
+	return &JailReferences{Jails: []JailConfig{}}
+}
+
+func (j *JailReferences) GetJailConfig(location Location) (JailConfig, bool) {
+	for _, jail := range j.Jails {
+		if jail.Location == location {
+			return jail, true
+		}
+	}
+	return JailConfig{}, false
+}
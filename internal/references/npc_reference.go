@@ -36,18 +36,8 @@ const secondHalfSpriteTableIndex = 0x100
 
 func isValidType(nType int) bool {
 	switch NPCType(nType) {
-	case Blacksmith:
-	case Barkeeper:
-	case HorseSeller:
-	case Shipwright:
-	case Healer:
-	case InnKeeper:
-	case MagicSeller:
-	case GuildMaster:
-	case NoStatedNpc:
-	case Guard:
-	case WishingWell:
-	case Vehicle:
+	case Blacksmith, Barkeeper, HorseSeller, Shipwright, Healer, InnKeeper, MagicSeller, GuildMaster,
+		NoStatedNpc, Guard, WishingWell, Vehicle:
 		return true
 	}
 	return false
@@ -21,6 +21,52 @@ type NPCSchedule struct {
 	Time  [totalScheduleItemsPerNpc + 1]byte `json:"time" yaml:"time"`
 }
 
+// ScheduleAction is how an NPC is getting itself to its scheduled destination. Only the Avatar's floor is
+// ever animated, so the action depends on which floor the Avatar is on as much as where the NPC is going.
+type ScheduleAction int
+
+const (
+	// ScheduleInactive NPCs are where they should be and simply carry on with their behaviour
+	ScheduleInactive ScheduleAction = iota
+	// ScheduleMove NPCs walk to a destination on the Avatar's floor
+	ScheduleMove
+	// ScheduleLeaveUp and ScheduleLeaveDown NPCs walk to a ladder or stairs to get off the Avatar's floor
+	ScheduleLeaveUp
+	ScheduleLeaveDown
+	// ScheduleArriveFromAbove and ScheduleArriveFromBelow NPCs appear on a ladder or stairs on the Avatar's floor
+	ScheduleArriveFromAbove
+	ScheduleArriveFromBelow
+	// SchedulePop NPCs go between floors the Avatar can't see, so they are simply put where they are going
+	SchedulePop
+)
+
+// GetScheduleAction works out how an NPC on npcFloor gets to a destination on destFloor while the Avatar
+// is on currentFloor
+func GetScheduleAction(npcFloor, destFloor, currentFloor FloorNumber, atDestination bool) ScheduleAction {
+	if atDestination {
+		return ScheduleInactive
+	}
+
+	if npcFloor == currentFloor {
+		switch {
+		case destFloor == currentFloor:
+			return ScheduleMove
+		case destFloor > currentFloor:
+			return ScheduleLeaveUp
+		default:
+			return ScheduleLeaveDown
+		}
+	}
+
+	if destFloor != currentFloor {
+		return SchedulePop
+	}
+	if npcFloor > currentFloor {
+		return ScheduleArriveFromAbove
+	}
+	return ScheduleArriveFromBelow
+}
+
 type IndividualNPCBehaviour struct {
 	Ai       AiType      `json:"ai" yaml:"ai"`
 	Position Position    `json:"position" yaml:"position"`
@@ -71,6 +117,16 @@ func (n *NPCSchedule) GetIndividualNPCBehaviourByUltimaDate(ud datetime.UltimaDa
 	}
 }
 
+// IsTransitionHour is true when the NPC sets off for its next destination on the hour
+func (n *NPCSchedule) IsTransitionHour(hour byte) bool {
+	for _, transitionHour := range n.Time {
+		if transitionHour == hour {
+			return true
+		}
+	}
+	return false
+}
+
 func (n *NPCSchedule) getScheduleIndex(date datetime.UltimaDate) int {
 	const totalSchedules = totalScheduleItemsPerNpc // Alias for readability
 	nHour := int(date.Hour)                         // Extract the hour from UltimaDate
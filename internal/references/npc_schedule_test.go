@@ -0,0 +1,48 @@
+package references
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/datetime"
+)
+
+func TestGetScheduleAction_JudgedAgainstTheAvatarsFloor(t *testing.T) {
+	// already there - nothing to do
+	assert.Equal(t, ScheduleInactive, GetScheduleAction(0, 0, 0, true))
+	assert.Equal(t, ScheduleInactive, GetScheduleAction(1, 1, 0, true))
+
+	// setting off from the Avatar's floor
+	assert.Equal(t, ScheduleMove, GetScheduleAction(0, 0, 0, false))
+	assert.Equal(t, ScheduleLeaveUp, GetScheduleAction(0, 1, 0, false))
+	assert.Equal(t, ScheduleLeaveDown, GetScheduleAction(0, -1, 0, false))
+
+	// coming to the Avatar's floor
+	assert.Equal(t, ScheduleArriveFromAbove, GetScheduleAction(1, 0, 0, false))
+	assert.Equal(t, ScheduleArriveFromBelow, GetScheduleAction(-1, 0, 0, false))
+
+	// nowhere the Avatar can see
+	assert.Equal(t, SchedulePop, GetScheduleAction(1, 2, 0, false))
+	assert.Equal(t, SchedulePop, GetScheduleAction(1, 1, 0, false))
+}
+
+func TestNPCSchedule_TransitionHoursMatchTheScheduleIndex(t *testing.T) {
+	schedule := NPCSchedule{
+		Ai:    [3]byte{byte(Fixed), byte(Wander), byte(Fixed)},
+		X:     [3]byte{1, 2, 3},
+		Y:     [3]byte{4, 5, 6},
+		Floor: [3]byte{0, 1, 0},
+		Time:  [4]byte{6, 12, 18, 22},
+	}
+
+	expectedPositions := map[byte]Position{6: {X: 1, Y: 4}, 12: {X: 2, Y: 5}, 18: {X: 3, Y: 6}, 22: {X: 2, Y: 5}}
+	for hour := byte(0); hour < 24; hour++ {
+		expected, ok := expectedPositions[hour]
+		assert.Equal(t, ok, schedule.IsTransitionHour(hour), "hour %d", hour)
+		if ok {
+			behaviour := schedule.GetIndividualNPCBehaviourByUltimaDate(datetime.UltimaDate{Hour: hour})
+			assert.Equal(t, expected, behaviour.Position, "hour %d", hour)
+		}
+	}
+}
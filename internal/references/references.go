@@ -17,8 +17,11 @@ type GameReferences struct {
 	LookReferences          *LookReferences          `json:"look_references" yaml:"look_references"`
 	NPCReferences           *NPCReferences           `json:"npc_references" yaml:"npc_references"`
 	DockReferences          *DockReferences          `json:"dock_references" yaml:"dock_references"`
+	JailReferences          *JailReferences          `json:"jail_references" yaml:"jail_references"`
 	EnemyReferences         *EnemyReferences         `json:"enemy_references" yaml:"enemy_references"`
 	TalkReferences          *TalkReferences          `json:"talk_references" yaml:"talk_references"`
+	DungeonReferences       *DungeonReferences       `json:"dungeon_references" yaml:"dungeon_references"`
+	ShoppeDialogue          *ShoppeDialogue          `json:"shoppe_dialogue" yaml:"shoppe_dialogue"`
 }
 
 func NewGameReferences(gameConfig *config.UltimaVConfiguration) (*GameReferences, error) {
@@ -47,10 +50,21 @@ func NewGameReferences(gameConfig *config.UltimaVConfiguration) (*GameReferences
 
 	gameRefs.NPCReferences = NewNPCReferences(gameConfig)
 	gameRefs.DockReferences = NewDocks(gameConfig)
+	gameRefs.JailReferences = NewJailReferences()
 
 	gameRefs.EnemyReferences = NewAllEnemyReferences(gameConfig, gameRefs.TileReferences)
 
 	gameRefs.TalkReferences = NewTalkReferences(gameConfig, gameRefs.DataOvl)
 
+	gameRefs.ShoppeDialogue, err = NewShoppeDialogue(gameConfig, gameRefs.TalkReferences.WordDict)
+	if err != nil {
+		return nil, err
+	}
+
+	gameRefs.DungeonReferences, err = NewDungeonReferences(gameConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	return gameRefs, nil
 }
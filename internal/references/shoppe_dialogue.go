@@ -0,0 +1,126 @@
+package references
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/config"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/files"
+)
+
+// SHOPPE.DAT is every line the shopkeepers say, one null terminated string after another, using the
+// same compressed words as the TLK files. A line has blanks that are filled in as it is said:
+//
+//	%  the gold being haggled over
+//	&  the item being haggled over
+//	$  the shopkeeper's name
+//	#  the shoppe's name
+//	@  the time of day
+//
+// See docs/ALGOS/Shops.md.
+
+// ShoppeDialogueLine is the kind of thing a shopkeeper is saying
+type ShoppeDialogueLine int
+
+const (
+	ShoppeGreeting ShoppeDialogueLine = iota
+	// ShoppeBuying is haggling over something the party is buying
+	ShoppeBuying
+	// ShoppeSelling is haggling over something the party is selling
+	ShoppeSelling
+	ShoppeGoodbye
+)
+
+// shoppeDialogueRange is the first and last SHOPPE.DAT line a shopkeeper picks from
+type shoppeDialogueRange struct {
+	First int
+	Last  int
+}
+
+// shoppeDialogueRanges are the lines each trade picks from
+// TODO: the guild's lines 34 to 42 are unsourced placeholders until SHOPPE.DAT's layout is confirmed
+var shoppeDialogueRanges = map[ShopType]map[ShoppeDialogueLine]shoppeDialogueRange{
+	ArmsShop: {
+		ShoppeGreeting: {First: 0, Last: 3},
+		ShoppeBuying:   {First: 49, Last: 52},
+		ShoppeSelling:  {First: 53, Last: 56},
+		ShoppeGoodbye:  {First: 8, Last: 9},
+	},
+	ReagentShop: {
+		ShoppeGreeting: {First: 24, Last: 27},
+		ShoppeBuying:   {First: 28, Last: 31},
+		ShoppeGoodbye:  {First: 32, Last: 33},
+	},
+	GuildShop: {
+		ShoppeGreeting: {First: 34, Last: 36},
+		ShoppeBuying:   {First: 37, Last: 40},
+		ShoppeGoodbye:  {First: 41, Last: 42},
+	},
+}
+
+// MerchantStringValues fill in the blanks of a shopkeeper's line
+type MerchantStringValues struct {
+	Gold       int
+	Item       string
+	KeeperName string
+	ShopName   string
+	TimeOfDay  string
+}
+
+type ShoppeDialogue struct {
+	lines []string
+}
+
+func NewShoppeDialogue(gameConfig *config.UltimaVConfiguration, wordDict *WordDict) (*ShoppeDialogue, error) {
+	rawData, err := os.ReadFile(path.Join(gameConfig.SavedConfigData.DataFilePath, files.SHOPPE_DAT))
+	if err != nil {
+		return nil, err
+	}
+	return NewShoppeDialogueFromBytes(rawData, wordDict)
+}
+
+// NewShoppeDialogueFromBytes reads every line out of the raw contents of SHOPPE.DAT
+func NewShoppeDialogueFromBytes(rawData []byte, wordDict *WordDict) (*ShoppeDialogue, error) {
+	dialogue := &ShoppeDialogue{}
+	for _, rawLine := range bytes.Split(bytes.TrimSuffix(rawData, []byte{0}), []byte{0}) {
+		line, err := wordDict.ReplaceMerchantString(string(rawLine))
+		if err != nil {
+			return nil, err
+		}
+		dialogue.lines = append(dialogue.lines, line)
+	}
+	return dialogue, nil
+}
+
+func (s *ShoppeDialogue) GetNumberOfLines() int {
+	return len(s.lines)
+}
+
+// GetLineRange is the lines a shopkeeper of the given trade picks from - it is false if they have
+// nothing to say
+func (s *ShoppeDialogue) GetLineRange(shopType ShopType, line ShoppeDialogueLine) (first int, last int, ok bool) {
+	lineRange, ok := shoppeDialogueRanges[shopType][line]
+	if !ok || lineRange.Last >= len(s.lines) {
+		return 0, 0, false
+	}
+	return lineRange.First, lineRange.Last, true
+}
+
+// GetMerchantString is a line with its blanks filled in, empty if there is no such line
+func (s *ShoppeDialogue) GetMerchantString(nLine int, values MerchantStringValues) string {
+	if nLine < 0 || nLine >= len(s.lines) {
+		return ""
+	}
+
+	replacer := strings.NewReplacer(
+		"%", strconv.Itoa(values.Gold),
+		"&", values.Item,
+		"$", values.KeeperName,
+		"#", values.ShopName,
+		"@", values.TimeOfDay,
+	)
+	return replacer.Replace(s.lines[nLine])
+}
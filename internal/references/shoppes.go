@@ -0,0 +1,281 @@
+package references
+
+import (
+	"math"
+)
+
+// Shoppes are kept by the shopkeepers - the NPCs whose dialog number names their trade. Everything a
+// shoppe sells has a base price that is scaled by the town it is sold in, shoppes keep hours, and
+// what the shopkeepers say is read out of SHOPPE.DAT. Only the dialogue comes from the game data - the
+// hours, town multipliers, prices and stock below are the placeholder tables from Shops.md until the
+// original ones are found.
+// See docs/ALGOS/Shops.md.
+
+// ShopType is the trade a shopkeeper plies
+type ShopType int
+
+const (
+	ArmsShop ShopType = iota
+	ReagentShop
+	HealerShop
+	GuildShop
+	HorseShop
+	ShipwrightShop
+	InnShop
+	nShopTypes
+)
+
+// shopTypesByNPCType is the shoppe each kind of shopkeeper keeps
+var shopTypesByNPCType = map[NPCType]ShopType{
+	Blacksmith:  ArmsShop,
+	MagicSeller: ReagentShop,
+	Healer:      HealerShop,
+	GuildMaster: GuildShop,
+	HorseSeller: HorseShop,
+	Shipwright:  ShipwrightShop,
+	InnKeeper:   InnShop,
+}
+
+// GetShopTypeByNPCType is the shoppe kept by the given kind of NPC - it is false if they don't keep one
+func GetShopTypeByNPCType(npcType NPCType) (ShopType, bool) {
+	shopType, ok := shopTypesByNPCType[npcType]
+	return shopType, ok
+}
+
+func (s ShopType) String() string {
+	switch s {
+	case ArmsShop:
+		return "Arms"
+	case ReagentShop:
+		return "Reagents"
+	case HealerShop:
+		return "Healer"
+	case GuildShop:
+		return "Guild"
+	case HorseShop:
+		return "Horses"
+	case ShipwrightShop:
+		return "Shipwright"
+	case InnShop:
+		return "Inn"
+	default:
+		return "Unknown"
+	}
+}
+
+// ShopHours are the hours a shoppe is open, from Open up to but not including Close. A shoppe that
+// opens and closes at the same hour never closes.
+type ShopHours struct {
+	Open  byte
+	Close byte
+}
+
+// IsOpen is true when the shoppe is open at the given hour
+func (h ShopHours) IsOpen(hour byte) bool {
+	switch {
+	case h.Open == h.Close:
+		return true
+	case h.Open < h.Close:
+		return hour >= h.Open && hour < h.Close
+	default:
+		return hour >= h.Open || hour < h.Close
+	}
+}
+
+// shopHours are when each trade is open for business - inns and healers never close, and the guild
+// keeps shorter hours than the other shoppes
+// TODO: every one of these is a placeholder - the guild's 9 to 17 included - until the original hours are found
+var shopHours = [nShopTypes]ShopHours{
+	ArmsShop:       {Open: 8, Close: 20},
+	ReagentShop:    {Open: 8, Close: 20},
+	HealerShop:     {Open: 0, Close: 0},
+	GuildShop:      {Open: 9, Close: 17},
+	HorseShop:      {Open: 8, Close: 20},
+	ShipwrightShop: {Open: 8, Close: 20},
+	InnShop:        {Open: 0, Close: 0},
+}
+
+func (s ShopType) GetHours() ShopHours {
+	return shopHours[s]
+}
+
+// shopTownMultipliers scale every price a trade charges in a town - towns that aren't listed charge
+// the base price
+var shopTownMultipliers = map[ShopType]map[Location]float64{
+	ArmsShop:    {Moonglow: 1.10, Jhelom: 0.90},
+	ReagentShop: {Moonglow: 1.10, Jhelom: 0.90},
+	HealerShop:  {Moonglow: 1.10, Jhelom: 1.20, Cove: 0.80},
+	InnShop:     {Moonglow: 1.10, Jhelom: 0.90},
+}
+
+// GetPrice is what the given base price comes to for this trade in the given town
+func (s ShopType) GetPrice(location Location, basePrice int) int {
+	multiplier, ok := shopTownMultipliers[s][location]
+	if !ok {
+		multiplier = 1
+	}
+	return int(math.Round(float64(basePrice) * multiplier))
+}
+
+const (
+	// innRoomBasePrice is a night's stay for the whole party
+	innRoomBasePrice = 20
+	// innMonthlyBasePrice keeps one companion at the inn for a month
+	innMonthlyBasePrice = 30
+)
+
+// GetInnRoomPrice is what a night at the inn costs in the given town
+func GetInnRoomPrice(location Location) int {
+	return InnShop.GetPrice(location, innRoomBasePrice)
+}
+
+// GetInnMonthlyPrice is what the inn in the given town charges for each month a companion stays
+func GetInnMonthlyPrice(location Location) int {
+	return InnShop.GetPrice(location, innMonthlyBasePrice)
+}
+
+// HealerService is something a healer will do for a party member
+type HealerService int
+
+const (
+	HealerCure HealerService = iota
+	HealerHeal
+	HealerResurrect
+	nHealerServices
+)
+
+func (h HealerService) String() string {
+	switch h {
+	case HealerCure:
+		return "Cure"
+	case HealerHeal:
+		return "Heal"
+	case HealerResurrect:
+		return "Resurrect"
+	default:
+		return "Unknown"
+	}
+}
+
+// healerBasePrices are what each of a healer's services costs before the town's multiplier
+// TODO: TBD - these are not sourced from the original game
+var healerBasePrices = [nHealerServices]int{
+	HealerCure:      30,
+	HealerHeal:      50,
+	HealerResurrect: 300,
+}
+
+// GetHealerPrice is what the healer in the given town charges for a service
+func GetHealerPrice(location Location, service HealerService) int {
+	return HealerShop.GetPrice(location, healerBasePrices[service])
+}
+
+const (
+	horseBasePrice   = 200
+	frigateBasePrice = 1500
+	skiffBasePrice   = 200
+	// hullRepairBasePrice is for each point of hull the shipwright patches
+	hullRepairBasePrice = 3
+)
+
+// GetHorsePrice is what the horse seller in the given town asks for a horse
+func GetHorsePrice(location Location) int {
+	return HorseShop.GetPrice(location, horseBasePrice)
+}
+
+// GetShipPrice is what the shipwright in the given town asks for a frigate or a skiff
+func GetShipPrice(location Location, vehicleType VehicleType) int {
+	if vehicleType == FrigateVehicle {
+		return ShipwrightShop.GetPrice(location, frigateBasePrice)
+	}
+	return ShipwrightShop.GetPrice(location, skiffBasePrice)
+}
+
+// GetHullRepairPrice is what the shipwright in the given town asks to patch the given hull points
+func GetHullRepairPrice(location Location, nHullPoints int) int {
+	return ShipwrightShop.GetPrice(location, hullRepairBasePrice*nHullPoints)
+}
+
+// ShopGoods is something a shoppe sells, and what it costs before the town's multiplier
+type ShopGoods struct {
+	Item      Item
+	BasePrice int
+}
+
+// armsShopStock is what every arms shoppe sells - the rarer arms are found, not bought
+var armsShopStock = []ShopGoods{
+	{Item: LeatherHelm, BasePrice: 20},
+	{Item: ChainCoif, BasePrice: 100},
+	{Item: IronHelm, BasePrice: 200},
+	{Item: SmallShield, BasePrice: 30},
+	{Item: LargeShield, BasePrice: 100},
+	{Item: ClothArmour, BasePrice: 10},
+	{Item: LeatherArmour, BasePrice: 100},
+	{Item: RingMail, BasePrice: 200},
+	{Item: ScaleMail, BasePrice: 400},
+	{Item: ChainMail, BasePrice: 600},
+	{Item: PlateMail, BasePrice: 1500},
+	{Item: Dagger, BasePrice: 10},
+	{Item: Sling, BasePrice: 20},
+	{Item: Club, BasePrice: 20},
+	{Item: FlamingOil, BasePrice: 5},
+	{Item: MainGauche, BasePrice: 30},
+	{Item: Spear, BasePrice: 40},
+	{Item: ThrowingAxe, BasePrice: 50},
+	{Item: ShortSword, BasePrice: 60},
+	{Item: Mace, BasePrice: 80},
+	{Item: MorningStar, BasePrice: 120},
+	{Item: Bow, BasePrice: 100},
+	{Item: Arrows, BasePrice: 5},
+	{Item: Crossbow, BasePrice: 200},
+	{Item: Quarrels, BasePrice: 10},
+	{Item: LongSword, BasePrice: 200},
+	{Item: TwoHHammer, BasePrice: 300},
+	{Item: TwoHAxe, BasePrice: 400},
+	{Item: TwoHSword, BasePrice: 600},
+	{Item: Halberd, BasePrice: 800},
+}
+
+// reagentShopStock is what every reagent shoppe sells - nightshade and mandrake root have to be
+// dug up
+var reagentShopStock = []ShopGoods{
+	{Item: SulfurAsh, BasePrice: 4},
+	{Item: Ginseng, BasePrice: 6},
+	{Item: Garlic, BasePrice: 4},
+	{Item: SpiderSilk, BasePrice: 6},
+	{Item: BloodMoss, BasePrice: 8},
+	{Item: BlackPearl, BasePrice: 10},
+}
+
+// guildShopStock is the guild's general goods. The sextant isn't sold until using it is implemented.
+// TODO: the guild's prices are unsourced placeholders until the original ones are found
+var guildShopStock = []ShopGoods{
+	{Item: Key, BasePrice: 20},
+	{Item: Gem, BasePrice: 60},
+	{Item: Torches, BasePrice: 8},
+}
+
+// GetShopStock is what a shoppe of the given trade has on its shelves. Shoppes that sell services
+// rather than goods have no stock.
+func GetShopStock(shopType ShopType) []ShopGoods {
+	switch shopType {
+	case ArmsShop:
+		return armsShopStock
+	case ReagentShop:
+		return reagentShopStock
+	case GuildShop:
+		return guildShopStock
+	default:
+		return nil
+	}
+}
+
+// GetShopGoods is the stock line for an item - it is false if the shoppe doesn't deal in it
+func GetShopGoods(shopType ShopType, item Item) (ShopGoods, bool) {
+	for _, goods := range GetShopStock(shopType) {
+		if goods.Item == item {
+			return goods, true
+		}
+	}
+	return ShopGoods{}, false
+}
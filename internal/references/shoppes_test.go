@@ -0,0 +1,94 @@
+package references
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShopHours_OpenDuringBusinessHours(t *testing.T) {
+	hours := ArmsShop.GetHours()
+	assert.False(t, hours.IsOpen(7))
+	assert.True(t, hours.IsOpen(8))
+	assert.True(t, hours.IsOpen(19))
+	assert.False(t, hours.IsOpen(20))
+
+	// the guild keeps shorter hours
+	assert.False(t, GuildShop.GetHours().IsOpen(8))
+	assert.True(t, GuildShop.GetHours().IsOpen(9))
+	assert.False(t, GuildShop.GetHours().IsOpen(17))
+
+	for hour := byte(0); hour < 24; hour++ {
+		assert.True(t, InnShop.GetHours().IsOpen(hour), "inns never close")
+	}
+
+	overnight := ShopHours{Open: 20, Close: 5}
+	assert.True(t, overnight.IsOpen(23))
+	assert.True(t, overnight.IsOpen(2))
+	assert.False(t, overnight.IsOpen(12))
+}
+
+func TestShopType_GetPriceScaledByTown(t *testing.T) {
+	assert.Equal(t, 100, ArmsShop.GetPrice(Britain, 100))
+	assert.Equal(t, 110, ArmsShop.GetPrice(Moonglow, 100))
+	assert.Equal(t, 90, ArmsShop.GetPrice(Jhelom, 100))
+	// 5 * 1.1 rounds to the nearest gold piece
+	assert.Equal(t, 6, ArmsShop.GetPrice(Moonglow, 5))
+	// each trade has its own multipliers
+	assert.Equal(t, 120, HealerShop.GetPrice(Jhelom, 100))
+	assert.Equal(t, 100, HorseShop.GetPrice(Jhelom, 100))
+}
+
+func TestGetShopTypeByNPCType_OnlyShopkeepers(t *testing.T) {
+	shopType, ok := GetShopTypeByNPCType(Blacksmith)
+	assert.True(t, ok)
+	assert.Equal(t, ArmsShop, shopType)
+
+	_, ok = GetShopTypeByNPCType(Guard)
+	assert.False(t, ok)
+}
+
+func TestGetShopGoods_OnlyWhatIsStocked(t *testing.T) {
+	goods, ok := GetShopGoods(ReagentShop, BlackPearl)
+	assert.True(t, ok)
+	assert.Equal(t, 10, goods.BasePrice)
+
+	_, ok = GetShopGoods(ReagentShop, NightShade)
+	assert.False(t, ok)
+	_, ok = GetShopGoods(ArmsShop, BlackPearl)
+	assert.False(t, ok)
+	_, ok = GetShopGoods(GuildShop, Torches)
+	assert.True(t, ok)
+	_, ok = GetShopGoods(GuildShop, Sextant)
+	assert.False(t, ok)
+	_, ok = GetShopGoods(GuildShop, SkullKeys)
+	assert.False(t, ok)
+	assert.Empty(t, GetShopStock(InnShop))
+}
+
+func TestShoppeDialogue_FillsInTheBlanks(t *testing.T) {
+	var rawData bytes.Buffer
+	for i := 0; i < 60; i++ {
+		line := "Filler"
+		if i == 49 {
+			line = "% for &"
+		}
+		rawData.WriteString(line)
+		rawData.WriteByte(0)
+	}
+
+	dialogue, err := NewShoppeDialogueFromBytes(rawData.Bytes(), NewWordDict(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 60, dialogue.GetNumberOfLines())
+
+	first, last, ok := dialogue.GetLineRange(ArmsShop, ShoppeBuying)
+	assert.True(t, ok)
+	assert.Equal(t, 49, first)
+	assert.Equal(t, 52, last)
+	assert.Equal(t, "12 for Dagger", dialogue.GetMerchantString(first, MerchantStringValues{Gold: 12, Item: "Dagger"}))
+
+	_, _, ok = dialogue.GetLineRange(InnShop, ShoppeBuying)
+	assert.False(t, ok)
+	assert.Empty(t, dialogue.GetMerchantString(60, MerchantStringValues{}))
+}
@@ -162,6 +162,17 @@ func (s *SmallLocationReference) GetNPCReferences() *[]NPCReference {
 }
 
 func (s *SmallLocationReference) GetClosestLadder(npcCurrentPosition Position, nCurrentFloor, nTargetFloor FloorNumber) Position {
+	bestPosition, ok := s.TryGetClosestLadder(npcCurrentPosition, nCurrentFloor, nTargetFloor)
+	if !ok {
+		log.Fatal("Unexpected: every NPC should have a ladder or stair close to them")
+	}
+
+	return bestPosition
+}
+
+// TryGetClosestLadder finds the ladder or stairs on nCurrentFloor closest to npcCurrentPosition that heads
+// towards nTargetFloor, if there is one at all
+func (s *SmallLocationReference) TryGetClosestLadder(npcCurrentPosition Position, nCurrentFloor, nTargetFloor FloorNumber) (Position, bool) {
 	ladderOrStairType := LadderOrStairDown
 	if nCurrentFloor < nTargetFloor {
 		ladderOrStairType = LadderOrStairUp
@@ -179,11 +190,7 @@ func (s *SmallLocationReference) GetClosestLadder(npcCurrentPosition Position, n
 		}
 	}
 
-	if bestPosition.IsZeros() {
-		log.Fatal("Unexpected: every NPC should have a ladder or stair close to them")
-	}
-
-	return bestPosition
+	return bestPosition, !bestPosition.IsZeros()
 }
 
 func (s *SmallLocationReference) getListOfAllLaddersAndStairs(nFloor FloorNumber, ladderOrStairType LadderOrStairType) []Position {
@@ -78,6 +78,11 @@ func getMapMasterFromLocation(location Location) SmallMapMasterTypes {
 	return None
 }
 
+// GetSmallMapMasterType is the kind of settlement (castle, towne, keep...) a location is
+func (l Location) GetSmallMapMasterType() SmallMapMasterTypes {
+	return getMapMasterFromLocation(l)
+}
+
 func NewSmallMapReferences(gameConfig *config.UltimaVConfiguration, dataOvl *DataOvl) (*LocationReferences, error) {
 	smr := newSingleMapReferences(gameConfig, dataOvl)
 
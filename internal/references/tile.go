@@ -39,6 +39,7 @@ type Tile struct {
 	IsGuessableFloor     bool   `json:"IsGuessableFloor"`
 	BlocksLight          bool   `json:"BlocksLight"`
 	IsWindow             bool   `json:"IsWindow"`
+	IsTalkOverable       bool   `json:"IsTalkOverable"`
 	CombatMapIndex       string `json:"CombatMapIndex"`
 }
 
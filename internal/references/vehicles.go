@@ -150,7 +150,8 @@ func GetVehicleFromSpriteIndex(s indexes.SpriteIndex) VehicleType {
 		return CarpetVehicle
 	case indexes.HorseRight, indexes.HorseLeft:
 		return HorseVehicle
-	case indexes.FrigateDownFurled, indexes.FrigateUpFurled, indexes.FrigateLeftFurled, indexes.FrigateRightFurled:
+	case indexes.FrigateDownFurled, indexes.FrigateUpFurled, indexes.FrigateLeftFurled, indexes.FrigateRightFurled,
+		indexes.FrigateDownUnfurled, indexes.FrigateUpUnfurled, indexes.FrigateLeftUnfurled, indexes.FrigateRightUnfurled:
 		return FrigateVehicle
 	case indexes.SkiffLeft, indexes.SkiffRight, indexes.SkiffUp, indexes.SkiffDown:
 		return SkiffVehicle
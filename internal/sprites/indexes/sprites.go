@@ -250,6 +250,11 @@ func (s SpriteIndex) IsWindowedDoor() bool {
 	return s == RegularDoorView || s == LockedDoorView || s == MagicLockDoorWithView
 }
 
+// IsField returns true for the poison, sleep (magic), fire and electric energy fields
+func (s SpriteIndex) IsField() bool {
+	return s >= PoisonField && s <= ElectricField
+}
+
 func (s SpriteIndex) IsPushableFloor() bool {
 	return s == BrickFloor || s == HexMetalGridFloor
 }
@@ -0,0 +1,67 @@
+package sprites
+
+import (
+	_ "embed"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/datetime"
+)
+
+var (
+	//go:embed assets/MoonAndSun/Moons_NewMoon.png
+	moonNewMoon []byte
+	//go:embed assets/MoonAndSun/Moons_CrescentWaxing.png
+	moonCrescentWaxing []byte
+	//go:embed assets/MoonAndSun/Moons_FirstQuarter.png
+	moonFirstQuarter []byte
+	//go:embed assets/MoonAndSun/Moons_GibbousWaxing.png
+	moonGibbousWaxing []byte
+	//go:embed assets/MoonAndSun/Moons_FullMoon.png
+	moonFullMoon []byte
+	//go:embed assets/MoonAndSun/Moons_GibbousWaning.png
+	moonGibbousWaning []byte
+	//go:embed assets/MoonAndSun/Moons_LastQuarter.png
+	moonLastQuarter []byte
+	//go:embed assets/MoonAndSun/Moons_CrescentWaning.png
+	moonCrescentWaning []byte
+	//go:embed assets/MoonAndSun/sun.png
+	sun []byte
+	//go:embed assets/MoonAndSun/DayTime-SemiCircle.png
+	dayTimeSky []byte
+	//go:embed assets/MoonAndSun/NightTime-SemiCircle.png
+	nightTimeSky []byte
+	//go:embed assets/MoonAndSun/Sunrise-SemiCircle.png
+	sunriseSky []byte
+	//go:embed assets/MoonAndSun/Sunset-SemiCircle.png
+	sunsetSky []byte
+)
+
+// MoonAndSunSprites are the pieces of the sun and moon indicator - the moons are indexed by phase
+type MoonAndSunSprites struct {
+	Moons [datetime.NumberOfMoonPhases]*ebiten.Image
+	Sun   *ebiten.Image
+
+	DayTimeSky   *ebiten.Image
+	NightTimeSky *ebiten.Image
+	SunriseSky   *ebiten.Image
+	SunsetSky    *ebiten.Image
+}
+
+func NewMoonAndSunSprites() *MoonAndSunSprites {
+	moonAndSunSprites := &MoonAndSunSprites{}
+
+	moons := NewSpriteSlice([][]byte{
+		moonNewMoon, moonCrescentWaxing, moonFirstQuarter, moonGibbousWaxing,
+		moonFullMoon, moonGibbousWaning, moonLastQuarter, moonCrescentWaning,
+	})
+	copy(moonAndSunSprites.Moons[:], moons)
+
+	moonAndSunSprites.Sun = NewPngSprite(sun)
+	moonAndSunSprites.DayTimeSky = NewPngSprite(dayTimeSky)
+	moonAndSunSprites.NightTimeSky = NewPngSprite(nightTimeSky)
+	moonAndSunSprites.SunriseSky = NewPngSprite(sunriseSky)
+	moonAndSunSprites.SunsetSky = NewPngSprite(sunsetSky)
+
+	return moonAndSunSprites
+}
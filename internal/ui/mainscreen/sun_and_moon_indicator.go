@@ -0,0 +1,87 @@
+package mainscreen
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/datetime"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites"
+)
+
+// the sky image is the size of the semicircle sprites
+const (
+	skyIndicatorWidth  = 180
+	skyIndicatorHeight = 90
+
+	sunTrackRadius     = 62
+	trammelTrackRadius = 66
+	feluccaTrackRadius = 40
+	skyBodySize        = 30
+)
+
+// the indicator sits in the empty top left corner of the provisions box
+var sunAndMoonIndicatorPercents = sprites.PercentBasedPlacement{
+	StartPercentX: leftImageStartX + .005,
+	EndPercentX:   leftImageStartX + .045,
+	StartPercentY: .812,
+	EndPercentY:   .812 + .0356,
+}
+
+// SunAndMoonIndicator shows the sun crossing the sky by day and Trammel and Felucca, in their
+// current phases, crossing it by night
+type SunAndMoonIndicator struct {
+	moonAndSunSprites *sprites.MoonAndSunSprites
+	skyImage          *ebiten.Image
+}
+
+func NewSunAndMoonIndicator(moonAndSunSprites *sprites.MoonAndSunSprites) *SunAndMoonIndicator {
+	return &SunAndMoonIndicator{
+		moonAndSunSprites: moonAndSunSprites,
+		skyImage:          ebiten.NewImage(skyIndicatorWidth, skyIndicatorHeight),
+	}
+}
+
+func (s *SunAndMoonIndicator) Draw(date *datetime.UltimaDate, screen *ebiten.Image) {
+	s.skyImage.Clear()
+	s.drawOnSky(s.getSkyBackground(date), 0, 0, skyIndicatorWidth)
+
+	arcFraction := date.GetSkyArcFraction()
+	if date.IsDayLight() {
+		s.drawOnArc(s.moonAndSunSprites.Sun, arcFraction, sunTrackRadius)
+	} else {
+		trammel, felucca := date.GetMoonPhases()
+		s.drawOnArc(s.moonAndSunSprites.Moons[trammel], arcFraction, trammelTrackRadius)
+		s.drawOnArc(s.moonAndSunSprites.Moons[felucca], arcFraction, feluccaTrackRadius)
+	}
+
+	screen.DrawImage(s.skyImage, sprites.GetDrawOptionsFromPercentsForWholeScreen(s.skyImage, sunAndMoonIndicatorPercents))
+}
+
+func (s *SunAndMoonIndicator) getSkyBackground(date *datetime.UltimaDate) *ebiten.Image {
+	switch {
+	case date.IsSunrise():
+		return s.moonAndSunSprites.SunriseSky
+	case date.IsSunset():
+		return s.moonAndSunSprites.SunsetSky
+	case date.IsDayLight():
+		return s.moonAndSunSprites.DayTimeSky
+	default:
+		return s.moonAndSunSprites.NightTimeSky
+	}
+}
+
+// drawOnArc centres a sun or moon on a semicircular track, rising on the left and setting on the right
+func (s *SunAndMoonIndicator) drawOnArc(body *ebiten.Image, arcFraction, radius float64) {
+	angle := math.Pi * (1 - arcFraction)
+	x := skyIndicatorWidth/2 + radius*math.Cos(angle)
+	y := skyIndicatorHeight - radius*math.Sin(angle)
+	s.drawOnSky(body, x-skyBodySize/2, y-skyBodySize/2, skyBodySize)
+}
+
+func (s *SunAndMoonIndicator) drawOnSky(img *ebiten.Image, x, y, size float64) {
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(size/float64(img.Bounds().Dx()), size/float64(img.Bounds().Dx()))
+	op.GeoM.Translate(x, y)
+	s.skyImage.DrawImage(img, op)
+}
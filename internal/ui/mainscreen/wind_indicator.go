@@ -0,0 +1,50 @@
+package mainscreen
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/bradhannah/Ultima5ReduxGo/internal/game_state"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/references"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/sprites"
+	"github.com/bradhannah/Ultima5ReduxGo/internal/text"
+)
+
+// the wind is shown in a gap cut into the bottom of the game screen border
+var windIndicatorPercents = sprites.PercentBasedPlacement{
+	StartPercentX: .3125,
+	EndPercentX:   .4525,
+	StartPercentY: .977,
+	EndPercentY:   1,
+}
+
+// WindIndicator shows the wind while the party is outdoors, where it fills (or fails to fill) their sails
+type WindIndicator struct {
+	ultimaFont *text.UltimaFont
+	output     *text.Output
+}
+
+func NewWindIndicator() *WindIndicator {
+	windIndicator := &WindIndicator{}
+	windIndicator.ultimaFont = text.NewUltimaFont(text.GetScaledNumberToResolutionLegacy(fontPoint))
+	windIndicator.output = text.NewOutput(windIndicator.ultimaFont, lineSpacing, 1, maxCharsPerLine)
+	return windIndicator
+}
+
+func (w *WindIndicator) Draw(gameState *game_state.GameState, screen *ebiten.Image) {
+	if gameState.MapState.PlayerLocation.Location.GetMapType() != references.LargeMapType {
+		return
+	}
+
+	rect := sprites.GetRectangleFromPercents(windIndicatorPercents)
+	vector.DrawFilledRect(screen, float32(rect.Min.X), float32(rect.Min.Y), float32(rect.Dx()), float32(rect.Dy()), color.Black, false)
+
+	textDop := ebiten.DrawImageOptions{}
+	textDop.GeoM.Translate(sprites.GetTranslateXYByPercent(sprites.PercentBasedCenterPoint{
+		X: (windIndicatorPercents.StartPercentX + windIndicatorPercents.EndPercentX) / 2,
+		Y: windIndicatorPercents.StartPercentY,
+	}))
+	w.output.DrawTextCenter(screen, gameState.GetWindDescription(), &textDop)
+}
@@ -0,0 +1,36 @@
+package grammar
+
+import "strings"
+
+// MatchAnyString accepts whatever word is typed - it never hints, so it suits questions whose
+// answers the player has to know
+type MatchAnyString struct {
+	Description string
+}
+
+func (m MatchAnyString) ShouldAutofillWithFirstCharacter() bool {
+	return false
+}
+
+func (m MatchAnyString) GetDescription() string {
+	return m.Description
+}
+
+func (m MatchAnyString) GetString() string {
+	return ""
+}
+
+func (m MatchAnyString) GetPartialMatches(s string) ([]string, error) {
+	if s == "" {
+		return []string{}, nil
+	}
+	return []string{strings.ToUpper(s)}, nil
+}
+
+func (m MatchAnyString) PartiallyMatches(str string) (bool, error) {
+	return str != "", nil
+}
+
+func (m MatchAnyString) GetSuffixHint(_ string) string {
+	return ""
+}